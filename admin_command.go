@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+	"github.com/syrlramadhan/desa-sukamaju-api/repositories"
+)
+
+// runAdmin menjalankan subcommand admin create dan admin reset-password.
+// Password dibaca dari stdin agar tidak tersimpan di riwayat shell.
+func runAdmin(db *sql.DB, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("penggunaan: admin create -username <u> [-role <role>] | admin reset-password -username <u> [-disable-2fa]")
+	}
+
+	switch args[0] {
+	case "create":
+		return runAdminCreate(db, args[1:])
+	case "reset-password":
+		return runAdminResetPassword(db, args[1:])
+	default:
+		return fmt.Errorf("subcommand admin %s tidak dikenal", args[0])
+	}
+}
+
+func runAdminCreate(db *sql.DB, args []string) error {
+	flags := flag.NewFlagSet("admin create", flag.ContinueOnError)
+	username := flags.String("username", "", "username admin baru")
+	role := flags.String("role", models.RoleSuperadmin, "role admin baru")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *username == "" {
+		return fmt.Errorf("username tidak boleh kosong")
+	}
+
+	if !models.IsValidRole(*role) {
+		return fmt.Errorf("role %s tidak valid", *role)
+	}
+
+	password, err := readNewPassword()
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := helpers.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("gagal hash password: %v", err)
+	}
+
+	ctx := context.Background()
+	adminRepo := repositories.NewAdminRepository()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	exists, err := adminRepo.IsUsernameExists(ctx, tx, *username)
+	if err != nil {
+		return fmt.Errorf("gagal memeriksa username: %v", err)
+	}
+
+	if exists {
+		return fmt.Errorf("username %s sudah digunakan", *username)
+	}
+
+	admin, err := adminRepo.AddAdmin(ctx, tx, models.Admin{
+		IdAdmin:  uuid.New().String(),
+		Username: *username,
+		Password: hashedPassword,
+		Role:     *role,
+		Status:   models.AdminStatusAktif,
+	})
+	if err != nil {
+		return fmt.Errorf("gagal menambahkan admin: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	fmt.Printf("admin %s (%s) berhasil dibuat dengan id %s\n", admin.Username, admin.Role, admin.IdAdmin)
+	return nil
+}
+
+func runAdminResetPassword(db *sql.DB, args []string) error {
+	flags := flag.NewFlagSet("admin reset-password", flag.ContinueOnError)
+	username := flags.String("username", "", "username admin")
+	disableTwoFactor := flags.Bool("disable-2fa", false, "nonaktifkan verifikasi dua langkah, misalnya jika perangkat authenticator hilang")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *username == "" {
+		return fmt.Errorf("username tidak boleh kosong")
+	}
+
+	password, err := readNewPassword()
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := helpers.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("gagal hash password: %v", err)
+	}
+
+	ctx := context.Background()
+	adminRepo := repositories.NewAdminRepository()
+	sessionRepo := repositories.NewSessionRepository()
+	recoveryCodeRepo := repositories.NewRecoveryCodeRepository()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	admin, err := adminRepo.GetAdmin(ctx, tx, *username)
+	if err != nil {
+		return fmt.Errorf("gagal mendapatkan admin: %v", err)
+	}
+
+	err = adminRepo.UpdatePasswordAdmin(ctx, tx, admin.IdAdmin, hashedPassword)
+	if err != nil {
+		return fmt.Errorf("gagal memperbarui password: %v", err)
+	}
+
+	err = sessionRepo.DeleteSessionsByAdminId(ctx, tx, admin.IdAdmin)
+	if err != nil {
+		return fmt.Errorf("gagal mencabut sesi admin: %v", err)
+	}
+
+	if *disableTwoFactor {
+		admin.TotpSecret = ""
+		admin.TotpEnabled = false
+		admin.TotpLastStep = 0
+
+		err = adminRepo.UpdateTotpAdmin(ctx, tx, admin)
+		if err != nil {
+			return fmt.Errorf("gagal menonaktifkan verifikasi dua langkah: %v", err)
+		}
+
+		err = recoveryCodeRepo.DeleteRecoveryCodesByAdminId(ctx, tx, admin.IdAdmin)
+		if err != nil {
+			return fmt.Errorf("gagal menghapus kode pemulihan: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	fmt.Printf("password admin %s berhasil diganti dan seluruh sesi dicabut\n", admin.Username)
+	return nil
+}
+
+// readNewPassword membaca password dan konfirmasinya dari stdin, satu baris untuk masing-masing
+func readNewPassword() (string, error) {
+	reader := bufio.NewReader(os.Stdin)
+
+	password, err := readLine(reader, "password: ")
+	if err != nil {
+		return "", err
+	}
+
+	if password == "" {
+		return "", fmt.Errorf("password tidak boleh kosong")
+	}
+
+	konfirmasiPassword, err := readLine(reader, "konfirmasi password: ")
+	if err != nil {
+		return "", err
+	}
+
+	if password != konfirmasiPassword {
+		return "", fmt.Errorf("password dan konfirmasi password tidak cocok")
+	}
+
+	return password, nil
+}
+
+func readLine(reader *bufio.Reader, prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("gagal membaca input: %v", err)
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/config"
+	"github.com/syrlramadhan/desa-sukamaju-api/dto"
+	"github.com/syrlramadhan/desa-sukamaju-api/repositories"
+	"github.com/syrlramadhan/desa-sukamaju-api/services"
+)
+
+// runCheckUploads membandingkan file yang direferensikan database dengan isi storage.
+// Baris yang menunjuk ke file yang tidak ada dilaporkan sebagai hilang, sedangkan file di
+// storage yang tidak direferensikan dilaporkan sebagai yatim dan dapat dikarantina.
+func runCheckUploads(db *sql.DB, args []string) error {
+	flags := flag.NewFlagSet("check-uploads", flag.ContinueOnError)
+	quarantine := flags.Bool("quarantine", false, "pindahkan file yatim ke folder quarantine")
+	minAge := flags.Duration("min-age", time.Hour, "umur minimal file sebelum dianggap yatim")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	fileStorage, err := config.NewStorage()
+	if err != nil {
+		return err
+	}
+
+	uploadCheckService := services.NewUploadCheckService(repositories.NewAparatRepository(), repositories.NewBeritaRepository(), fileStorage, db)
+
+	report, _, err := uploadCheckService.CheckUploads(context.Background(), *quarantine, *minAge)
+	printUploadCheckReport(report)
+	if err != nil {
+		return err
+	}
+
+	problems := len(report.Missing) + len(report.Orphaned) - len(report.Quarantined)
+	if problems > 0 {
+		return fmt.Errorf("ditemukan %d file bermasalah", problems)
+	}
+
+	if len(report.Quarantined) > 0 {
+		fmt.Printf("%d file yatim dipindahkan ke karantina\n", len(report.Quarantined))
+		return nil
+	}
+
+	fmt.Println("semua file upload sesuai dengan database")
+	return nil
+}
+
+func printUploadCheckReport(report dto.UploadCheckResponse) {
+	for _, missing := range report.Missing {
+		fmt.Printf("hilang\t%s\t(%s %s)\n", missing.Key, missing.Entity, missing.IdEntity)
+	}
+
+	quarantined := make(map[string]bool)
+	for _, key := range report.Quarantined {
+		quarantined[key] = true
+	}
+
+	for _, key := range report.Orphaned {
+		if quarantined[key] {
+			fmt.Printf("dikarantina\t%s\n", key)
+		} else {
+			fmt.Printf("tidak terpakai\t%s\n", key)
+		}
+	}
+}
+
+// startUploadCheckJob menjalankan pemeriksaan file upload secara berkala di background
+// selama server berjalan. Hasilnya hanya dicatat ke log kecuali quarantine diaktifkan.
+// Jika beberapa instance berjalan, pemeriksaan dilewati saat instance lain sedang memeriksa.
+func startUploadCheckJob(db *sql.DB, uploadCheckService services.UploadCheckService, interval time.Duration, quarantine bool) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			var report dto.UploadCheckResponse
+			_, err := services.RunExclusive(context.Background(), db, services.JobLockUploadCheck, func() error {
+				var err error
+				report, _, err = uploadCheckService.CheckUploads(context.Background(), quarantine, time.Hour)
+				return err
+			})
+			if err != nil {
+				fmt.Printf("gagal memeriksa file upload: %v\n", err)
+				continue
+			}
+
+			if len(report.Missing) > 0 || len(report.Orphaned) > 0 {
+				fmt.Printf("pemeriksaan file upload: %d hilang, %d yatim, %d dikarantina\n", len(report.Missing), len(report.Orphaned), len(report.Quarantined))
+				printUploadCheckReport(report)
+			}
+		}
+	}()
+}
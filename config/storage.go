@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/storage"
+)
+
+// NewStorage memilih backend penyimpanan file dari env STORAGE_DRIVER (local atau s3).
+// Bawaannya local di folder uploads agar instalasi lama tetap berjalan tanpa konfigurasi tambahan.
+func NewStorage() (storage.Storage, error) {
+	switch driver := os.Getenv("STORAGE_DRIVER"); driver {
+	case "", "local":
+		root := os.Getenv("STORAGE_LOCAL_DIR")
+		if root == "" {
+			root = "uploads"
+		}
+		return storage.NewLocalStorage(root, "/uploads"), nil
+	case "s3":
+		return storage.NewS3Storage(storage.S3Config{
+			Endpoint:     os.Getenv("S3_ENDPOINT"),
+			Region:       os.Getenv("S3_REGION"),
+			Bucket:       os.Getenv("S3_BUCKET"),
+			AccessKey:    os.Getenv("S3_ACCESS_KEY"),
+			SecretKey:    os.Getenv("S3_SECRET_KEY"),
+			PublicURL:    os.Getenv("S3_PUBLIC_URL"),
+			UsePathStyle: os.Getenv("S3_USE_PATH_STYLE") != "false",
+		})
+	default:
+		return nil, fmt.Errorf("STORAGE_DRIVER %s tidak dikenal, gunakan local atau s3", driver)
+	}
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/syrlramadhan/desa-sukamaju-api/dto"
@@ -12,8 +13,20 @@ import (
 type AdminController interface {
 	GetAdminById(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 	LoginAdmin(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	RefreshToken(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	Logout(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	LogoutAll(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	GetFailedLoginAttempts(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	LoginTwoFactor(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	SetupTwoFactor(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	EnableTwoFactor(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	DisableTwoFactor(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
 	UpdatePasswordAdmin(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 	UpdateUsernameAdmin(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	CreateAdmin(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	GetAllAdmin(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	UpdateAdmin(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	DeleteAdmin(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 }
 
 type AdminControllerImpl struct {
@@ -42,7 +55,26 @@ func (a *AdminControllerImpl) LoginAdmin(w http.ResponseWriter, r *http.Request,
 	loginReq := dto.LoginRequest{}
 	helpers.ReadFromRequestBody(r, &loginReq)
 
-	token, code, err := a.AdminService.LoginAdmin(r.Context(), loginReq)
+	token, code, err := a.AdminService.LoginAdmin(r.Context(), loginReq, helpers.ClientIP(r))
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	if token.TwoFactorRequired {
+		helpers.WriteJSONLogin(w, token, "masukkan kode verifikasi dua langkah")
+		return
+	}
+
+	helpers.WriteJSONLogin(w, token, "berhasil login")
+}
+
+// LoginTwoFactor implements AdminController.
+func (a *AdminControllerImpl) LoginTwoFactor(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	loginReq := dto.TwoFactorLoginRequest{}
+	helpers.ReadFromRequestBody(r, &loginReq)
+
+	token, code, err := a.AdminService.LoginTwoFactor(r.Context(), loginReq, helpers.ClientIP(r))
 	if err != nil {
 		helpers.WriteJSONError(w, code, err.Error())
 		return
@@ -51,6 +83,42 @@ func (a *AdminControllerImpl) LoginAdmin(w http.ResponseWriter, r *http.Request,
 	helpers.WriteJSONLogin(w, token, "berhasil login")
 }
 
+// RefreshToken implements AdminController.
+func (a *AdminControllerImpl) RefreshToken(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	refreshReq := dto.RefreshTokenRequest{}
+	helpers.ReadFromRequestBody(r, &refreshReq)
+
+	token, code, err := a.AdminService.RefreshToken(r.Context(), refreshReq)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONLogin(w, token, "berhasil memperbarui token")
+}
+
+// Logout implements AdminController.
+func (a *AdminControllerImpl) Logout(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	code, err := a.AdminService.Logout(r.Context())
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONNoData(w, "berhasil logout")
+}
+
+// LogoutAll implements AdminController.
+func (a *AdminControllerImpl) LogoutAll(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	code, err := a.AdminService.LogoutAll(r.Context())
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONNoData(w, "berhasil logout dari semua perangkat")
+}
+
 // UpdatePasswordAdmin implements AdminController.
 func (a *AdminControllerImpl) UpdatePasswordAdmin(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	passReq := dto.UpdatePasswordRequest{}
@@ -78,3 +146,105 @@ func (a *AdminControllerImpl) UpdateUsernameAdmin(w http.ResponseWriter, r *http
 
 	helpers.WriteJSONNoData(w, "username berhasil diperbarui")
 }
+
+// CreateAdmin implements AdminController.
+func (a *AdminControllerImpl) CreateAdmin(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	adminReq := dto.CreateAdminRequest{}
+	helpers.ReadFromRequestBody(r, &adminReq)
+
+	adminResponse, code, err := a.AdminService.CreateAdmin(r.Context(), adminReq)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONSuccess(w, adminResponse, "berhasil menambahkan admin")
+}
+
+// GetAllAdmin implements AdminController.
+func (a *AdminControllerImpl) GetAllAdmin(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	adminResponse, code, err := a.AdminService.GetAllAdmin(r.Context())
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONSuccess(w, adminResponse, "berhasil mendapatkan data admin")
+}
+
+// UpdateAdmin implements AdminController.
+func (a *AdminControllerImpl) UpdateAdmin(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	adminReq := dto.UpdateAdminRequest{}
+	helpers.ReadFromRequestBody(r, &adminReq)
+
+	adminResponse, code, err := a.AdminService.UpdateAdmin(r.Context(), ps.ByName("id_admin"), adminReq)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONSuccess(w, adminResponse, "berhasil memperbarui admin")
+}
+
+// DeleteAdmin implements AdminController.
+func (a *AdminControllerImpl) DeleteAdmin(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	code, err := a.AdminService.DeleteAdmin(r.Context(), ps.ByName("id_admin"))
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONNoData(w, "berhasil menghapus admin")
+}
+
+// GetFailedLoginAttempts implements AdminController.
+func (a *AdminControllerImpl) GetFailedLoginAttempts(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	attemptResponse, code, err := a.AdminService.GetFailedLoginAttempts(r.Context(), r.URL.Query().Get("username"), limit)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONSuccess(w, attemptResponse, "berhasil mendapatkan data percobaan login gagal")
+}
+
+// SetupTwoFactor implements AdminController.
+func (a *AdminControllerImpl) SetupTwoFactor(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	setupResponse, code, err := a.AdminService.SetupTwoFactor(r.Context())
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONSuccess(w, setupResponse, "pindai otpauth_uri dengan aplikasi authenticator lalu verifikasi kodenya")
+}
+
+// EnableTwoFactor implements AdminController.
+func (a *AdminControllerImpl) EnableTwoFactor(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	codeReq := dto.TwoFactorCodeRequest{}
+	helpers.ReadFromRequestBody(r, &codeReq)
+
+	recoveryResponse, code, err := a.AdminService.EnableTwoFactor(r.Context(), codeReq)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONSuccess(w, recoveryResponse, "verifikasi dua langkah berhasil diaktifkan, simpan kode pemulihan di tempat aman")
+}
+
+// DisableTwoFactor implements AdminController.
+func (a *AdminControllerImpl) DisableTwoFactor(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	disableReq := dto.DisableTwoFactorRequest{}
+	helpers.ReadFromRequestBody(r, &disableReq)
+
+	code, err := a.AdminService.DisableTwoFactor(r.Context(), disableReq)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONNoData(w, "verifikasi dua langkah berhasil dinonaktifkan")
+}
@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/syrlramadhan/desa-sukamaju-api/dto"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/services"
+)
+
+type AlbumController interface {
+	CreateAlbum(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	GetAllAlbum(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	GetAlbumById(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	UpdateAlbum(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	DeleteAlbum(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	CreatePhotoAlbum(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	UpdatePhotoAlbum(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	UpdateUrutanPhotoAlbum(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	SetCoverPhotoAlbum(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	DeletePhotoAlbumByFilename(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+}
+
+type albumControllerImpl struct {
+	AlbumService services.AlbumService
+}
+
+func NewAlbumController(albumService services.AlbumService) AlbumController {
+	return &albumControllerImpl{
+		AlbumService: albumService,
+	}
+}
+
+// CreateAlbum implements AlbumController.
+func (a *albumControllerImpl) CreateAlbum(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	albumReq := dto.AlbumRequest{}
+	helpers.ReadFromRequestBody(r, &albumReq)
+
+	albumResponse, code, err := a.AlbumService.CreateAlbum(r.Context(), albumReq)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONSuccess(w, albumResponse, "berhasil menambahkan album")
+}
+
+// GetAllAlbum implements AlbumController.
+func (a *albumControllerImpl) GetAllAlbum(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	page, perPage := helpers.ParsePagination(r.URL.Query())
+
+	albumResponse, meta, code, err := a.AlbumService.GetAllAlbum(r.Context(), dto.AlbumQuery{Page: page, PerPage: perPage})
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.SetPaginationLinks(&meta, r.URL)
+	helpers.WriteJSONPaginated(w, albumResponse, meta, "berhasil mendapatkan data album")
+}
+
+// GetAlbumById implements AlbumController.
+func (a *albumControllerImpl) GetAlbumById(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	albumResponse, code, err := a.AlbumService.GetAlbumById(r.Context(), ps.ByName("id_album"))
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONSuccess(w, albumResponse, "berhasil mendapatkan data album")
+}
+
+// UpdateAlbum implements AlbumController.
+func (a *albumControllerImpl) UpdateAlbum(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	albumReq := dto.AlbumRequest{}
+	helpers.ReadFromRequestBody(r, &albumReq)
+
+	albumResponse, code, err := a.AlbumService.UpdateAlbum(r.Context(), ps.ByName("id_album"), albumReq)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONSuccess(w, albumResponse, "berhasil memperbarui album")
+}
+
+// DeleteAlbum implements AlbumController.
+func (a *albumControllerImpl) DeleteAlbum(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	code, err := a.AlbumService.DeleteAlbum(r.Context(), ps.ByName("id_album"))
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONNoData(w, "berhasil menghapus album")
+}
+
+// CreatePhotoAlbum implements AlbumController.
+func (a *albumControllerImpl) CreatePhotoAlbum(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	code, err := a.AlbumService.CreatePhotoAlbum(r.Context(), r, ps.ByName("id_album"))
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONNoData(w, "berhasil menambahkan foto album")
+}
+
+// UpdatePhotoAlbum implements AlbumController.
+func (a *albumControllerImpl) UpdatePhotoAlbum(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	photoReq := dto.GaleriPhotoRequest{}
+	helpers.ReadFromRequestBody(r, &photoReq)
+
+	code, err := a.AlbumService.UpdatePhotoAlbum(r.Context(), ps.ByName("filename"), photoReq)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONNoData(w, "berhasil mengupdate foto")
+}
+
+// UpdateUrutanPhotoAlbum implements AlbumController.
+func (a *albumControllerImpl) UpdateUrutanPhotoAlbum(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	urutanReq := dto.GaleriUrutanRequest{}
+	helpers.ReadFromRequestBody(r, &urutanReq)
+
+	code, err := a.AlbumService.UpdateUrutanPhotoAlbum(r.Context(), ps.ByName("id_album"), urutanReq)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONNoData(w, "berhasil mengubah urutan foto album")
+}
+
+// SetCoverPhotoAlbum implements AlbumController.
+func (a *albumControllerImpl) SetCoverPhotoAlbum(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	coverReq := dto.GaleriCoverRequest{}
+	helpers.ReadFromRequestBody(r, &coverReq)
+
+	code, err := a.AlbumService.SetCoverPhotoAlbum(r.Context(), ps.ByName("id_album"), coverReq)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONNoData(w, "berhasil mengubah sampul album")
+}
+
+// DeletePhotoAlbumByFilename implements AlbumController.
+func (a *albumControllerImpl) DeletePhotoAlbumByFilename(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	code, err := a.AlbumService.DeletePhotoAlbumByFilename(r.Context(), ps.ByName("filename"))
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONNoData(w, "berhasil menghapus foto")
+}
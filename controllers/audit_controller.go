@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/syrlramadhan/desa-sukamaju-api/dto"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/services"
+)
+
+type AuditController interface {
+	GetAllAudit(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+}
+
+type auditControllerImpl struct {
+	AuditService services.AuditService
+}
+
+func NewAuditController(auditService services.AuditService) AuditController {
+	return &auditControllerImpl{
+		AuditService: auditService,
+	}
+}
+
+// GetAllAudit implements AuditController.
+func (a *auditControllerImpl) GetAllAudit(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	query := r.URL.Query()
+	page, perPage := helpers.ParsePagination(query)
+
+	auditQuery := dto.AuditQuery{
+		IdAdmin:  query.Get("id_admin"),
+		Action:   query.Get("action"),
+		Entity:   query.Get("entity"),
+		EntityId: query.Get("entity_id"),
+		From:     query.Get("from"),
+		To:       query.Get("to"),
+		Page:     page,
+		PerPage:  perPage,
+	}
+
+	auditResponse, meta, code, err := a.AuditService.GetAllAudit(r.Context(), auditQuery)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.SetPaginationLinks(&meta, r.URL)
+	helpers.WriteJSONPaginated(w, auditResponse, meta, "berhasil mendapatkan audit log")
+}
@@ -3,7 +3,11 @@ package controllers
 import (
 	"encoding/json"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
+	"github.com/google/uuid"
 	"github.com/julienschmidt/httprouter"
 	"github.com/syrlramadhan/desa-sukamaju-api/dto"
 	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
@@ -15,10 +19,20 @@ type BeritaController interface {
 	CreatePhoto(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
 	GetAllBerita(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
 	GetBeritaById(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	GetBeritaBySlug(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	GetReviewBerita(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	GetReviewBeritaById(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 	UpdateBerita(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	UpdateStatusBerita(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 	DeleteBerita(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 	DeletePhotoByFilename(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
 	BulkDeletePhoto(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	UpdatePhotoBerita(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	UpdateUrutanPhotoBerita(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	SetCoverPhotoBerita(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	GetBeritaRevisi(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	GetBeritaRevisiDiff(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	RestoreBeritaRevisi(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 }
 
 type beritaControllerImpl struct {
@@ -102,20 +116,134 @@ func (b *beritaControllerImpl) BulkDeletePhoto(w http.ResponseWriter, r *http.Re
 	helpers.WriteJSONNoData(w, "berhasil menghapus foto")
 }
 
+// UpdatePhotoBerita implements BeritaController.
+func (b *beritaControllerImpl) UpdatePhotoBerita(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	photoReq := dto.GaleriPhotoRequest{}
+	helpers.ReadFromRequestBody(r, &photoReq)
+
+	code, err := b.BeritaService.UpdatePhotoBerita(r.Context(), ps.ByName("filename"), photoReq)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONNoData(w, "berhasil mengupdate foto")
+}
+
+// UpdateUrutanPhotoBerita implements BeritaController.
+func (b *beritaControllerImpl) UpdateUrutanPhotoBerita(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	urutanReq := dto.GaleriUrutanRequest{}
+	helpers.ReadFromRequestBody(r, &urutanReq)
+
+	code, err := b.BeritaService.UpdateUrutanPhotoBerita(r.Context(), ps.ByName("id_berita"), urutanReq)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONNoData(w, "berhasil mengubah urutan foto berita")
+}
+
+// SetCoverPhotoBerita implements BeritaController.
+func (b *beritaControllerImpl) SetCoverPhotoBerita(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	coverReq := dto.GaleriCoverRequest{}
+	helpers.ReadFromRequestBody(r, &coverReq)
+
+	code, err := b.BeritaService.SetCoverPhotoBerita(r.Context(), ps.ByName("id_berita"), coverReq)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONNoData(w, "berhasil mengubah sampul berita")
+}
+
 // GetAllBerita implements BeritaController.
 func (b *beritaControllerImpl) GetAllBerita(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	responseDTO, code, err := b.BeritaService.GetAllBerita(r.Context())
+	responseDTO, meta, code, err := b.BeritaService.GetAllBerita(r.Context(), parseBeritaQuery(r))
 	if err != nil {
 		helpers.WriteJSONError(w, code, err.Error())
 		return
 	}
 
-	helpers.WriteJSONSuccess(w, responseDTO, "berhasil mendapatkan data berita")
+	helpers.SetPaginationLinks(&meta, r.URL)
+	helpers.WriteJSONPaginated(w, responseDTO, meta, "berhasil mendapatkan data berita")
+}
+
+// GetReviewBerita implements BeritaController.
+func (b *beritaControllerImpl) GetReviewBerita(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	responseDTO, meta, code, err := b.BeritaService.GetReviewBerita(r.Context(), parseBeritaQuery(r))
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.SetPaginationLinks(&meta, r.URL)
+	helpers.WriteJSONPaginated(w, responseDTO, meta, "berhasil mendapatkan data berita")
+}
+
+func parseBeritaQuery(r *http.Request) dto.BeritaQuery {
+	query := r.URL.Query()
+	page, perPage := helpers.ParsePagination(query)
+
+	return dto.BeritaQuery{
+		Status:   query.Get("status"),
+		Kategori: query.Get("kategori"),
+		Tag:      query.Get("tag"),
+		From:     query.Get("from"),
+		To:       query.Get("to"),
+		Sort:     query.Get("sort"),
+		Order:    query.Get("order"),
+		Page:     page,
+		PerPage:  perPage,
+	}
 }
 
 // GetBeritaById implements BeritaController.
+// ID berita selalu berupa UUID, nilai selain UUID diperlakukan sebagai slug sehingga
+// GET /api/v1/berita/:slug juga dapat dipakai sebagai permalink berita.
 func (b *beritaControllerImpl) GetBeritaById(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-	responseDTO, code, err := b.BeritaService.GetBeritaById(r.Context(), ps.ByName("id_berita"))
+	idBerita := ps.ByName("id_berita")
+	if _, err := uuid.Parse(idBerita); err != nil {
+		b.writeBeritaBySlug(w, r, idBerita)
+		return
+	}
+
+	responseDTO, code, err := b.BeritaService.GetBeritaById(r.Context(), idBerita)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONSuccess(w, responseDTO, "berhasil mendapatkan data berita")
+}
+
+// GetBeritaBySlug implements BeritaController.
+func (b *beritaControllerImpl) GetBeritaBySlug(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	b.writeBeritaBySlug(w, r, ps.ByName("slug"))
+}
+
+// writeBeritaBySlug menulis berita berdasarkan slug.
+// Slug lama dialihkan secara permanen ke permalink dengan slug terbaru.
+func (b *beritaControllerImpl) writeBeritaBySlug(w http.ResponseWriter, r *http.Request, slug string) {
+	responseDTO, code, err := b.BeritaService.GetBeritaBySlug(r.Context(), slug)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	if responseDTO.Slug != slug {
+		location := strings.TrimSuffix(r.URL.Path, slug) + url.PathEscape(responseDTO.Slug)
+		http.Redirect(w, r, location, http.StatusMovedPermanently)
+		return
+	}
+
+	helpers.WriteJSONSuccess(w, responseDTO, "berhasil mendapatkan data berita")
+}
+
+// GetReviewBeritaById implements BeritaController.
+func (b *beritaControllerImpl) GetReviewBeritaById(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	responseDTO, code, err := b.BeritaService.GetReviewBeritaById(r.Context(), ps.ByName("id_berita"))
 	if err != nil {
 		helpers.WriteJSONError(w, code, err.Error())
 		return
@@ -136,4 +264,75 @@ func (b *beritaControllerImpl) UpdateBerita(w http.ResponseWriter, r *http.Reque
 	}
 
 	helpers.WriteJSONNoData(w, "berhasil mengupdate berita")
-}
\ No newline at end of file
+}
+
+// UpdateStatusBerita implements BeritaController.
+func (b *beritaControllerImpl) UpdateStatusBerita(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	statusReq := dto.BeritaStatusRequest{}
+	helpers.ReadFromRequestBody(r, &statusReq)
+
+	code, err := b.BeritaService.UpdateStatusBerita(r.Context(), ps.ByName("id_berita"), statusReq)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONNoData(w, "berhasil mengubah status berita")
+}
+
+// GetBeritaRevisi implements BeritaController.
+func (b *beritaControllerImpl) GetBeritaRevisi(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	responseDTO, code, err := b.BeritaService.GetBeritaRevisi(r.Context(), ps.ByName("id_berita"))
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONSuccess(w, responseDTO, "berhasil mendapatkan revisi berita")
+}
+
+// GetBeritaRevisiDiff implements BeritaController.
+// Query from wajib diisi, sedangkan to boleh dikosongkan untuk membandingkan dengan isi berita saat ini.
+func (b *beritaControllerImpl) GetBeritaRevisiDiff(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	query := r.URL.Query()
+
+	from, err := strconv.Atoi(query.Get("from"))
+	if err != nil {
+		helpers.WriteJSONError(w, http.StatusBadRequest, "from harus berupa nomor versi revisi")
+		return
+	}
+
+	to := 0
+	if query.Get("to") != "" {
+		to, err = strconv.Atoi(query.Get("to"))
+		if err != nil {
+			helpers.WriteJSONError(w, http.StatusBadRequest, "to harus berupa nomor versi revisi")
+			return
+		}
+	}
+
+	responseDTO, code, err := b.BeritaService.GetBeritaRevisiDiff(r.Context(), ps.ByName("id_berita"), from, to)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONSuccess(w, responseDTO, "berhasil membandingkan revisi berita")
+}
+
+// RestoreBeritaRevisi implements BeritaController.
+func (b *beritaControllerImpl) RestoreBeritaRevisi(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	versi, err := strconv.Atoi(ps.ByName("versi"))
+	if err != nil {
+		helpers.WriteJSONError(w, http.StatusBadRequest, "versi harus berupa angka")
+		return
+	}
+
+	code, err := b.BeritaService.RestoreBeritaRevisi(r.Context(), ps.ByName("id_berita"), versi)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONNoData(w, "berhasil memulihkan revisi berita")
+}
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/syrlramadhan/desa-sukamaju-api/dto"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/services"
+)
+
+type FeedController interface {
+	GetBeritaRSS(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	GetBeritaAtom(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+}
+
+type feedControllerImpl struct {
+	FeedService services.FeedService
+}
+
+func NewFeedController(feedService services.FeedService) FeedController {
+	return &feedControllerImpl{
+		FeedService: feedService,
+	}
+}
+
+// GetBeritaRSS implements FeedController.
+func (f *feedControllerImpl) GetBeritaRSS(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	feed, lastModified, code, err := f.FeedService.GetBeritaRSS(r.Context(), parseFeedQuery(r))
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	writeFeed(w, r, "application/rss+xml; charset=utf-8", feed, lastModified)
+}
+
+// GetBeritaAtom implements FeedController.
+func (f *feedControllerImpl) GetBeritaAtom(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	feed, lastModified, code, err := f.FeedService.GetBeritaAtom(r.Context(), parseFeedQuery(r))
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	writeFeed(w, r, "application/atom+xml; charset=utf-8", feed, lastModified)
+}
+
+// parseFeedQuery membaca filter kategori, misalnya /feed/berita.rss?kategori=Pembangunan
+func parseFeedQuery(r *http.Request) dto.FeedQuery {
+	baseURL := helpers.RequestBaseURL(r)
+
+	return dto.FeedQuery{
+		Kategori: r.URL.Query().Get("kategori"),
+		BaseURL:  baseURL,
+		SelfURL:  baseURL + r.URL.RequestURI(),
+	}
+}
+
+// writeFeed menulis feed sebagai XML. ETag dihitung dari isi feed sehingga perubahan apa pun,
+// termasuk berita yang diedit atau diarsipkan, membuat feed reader mengambil ulang;
+// http.ServeContent menangani If-None-Match dan If-Modified-Since.
+func writeFeed(w http.ResponseWriter, r *http.Request, contentType string, feed interface{}, lastModified time.Time) {
+	body, err := xml.Marshal(feed)
+	if err != nil {
+		helpers.WriteJSONError(w, http.StatusInternalServerError, "gagal membuat feed")
+		return
+	}
+	body = append([]byte(xml.Header), body...)
+
+	sum := sha256.Sum256(body)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	w.Header().Set("Cache-Control", "public, max-age=300")
+
+	http.ServeContent(w, r, "", lastModified, bytes.NewReader(body))
+}
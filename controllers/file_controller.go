@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/storage"
+)
+
+type FileController interface {
+	ServeFile(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+}
+
+type fileControllerImpl struct {
+	Storage storage.Storage
+}
+
+func NewFileController(fileStorage storage.Storage) FileController {
+	return &fileControllerImpl{
+		Storage: fileStorage,
+	}
+}
+
+// ServeFile implements FileController.
+func (f *fileControllerImpl) ServeFile(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	key := strings.TrimPrefix(ps.ByName("filepath"), "/")
+
+	// File karantina hasil check-uploads dan file milik data di tempat sampah tidak boleh diakses publik
+	if strings.HasPrefix(key, storage.DirQuarantine+"/") || strings.HasPrefix(key, storage.DirTrash+"/") {
+		helpers.WriteJSONError(w, http.StatusNotFound, "file tidak ditemukan")
+		return
+	}
+
+	file, err := f.Storage.Get(r.Context(), key)
+	if err == storage.ErrNotExist {
+		helpers.WriteJSONError(w, http.StatusNotFound, "file tidak ditemukan")
+		return
+	}
+	if err != nil {
+		helpers.WriteJSONError(w, http.StatusInternalServerError, "gagal membaca file")
+		return
+	}
+	defer file.Close()
+
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	// Nama file upload selalu unik sehingga aman di-cache lama oleh browser
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+
+	if seeker, ok := file.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, key, time.Time{}, seeker)
+		return
+	}
+
+	io.Copy(w, file)
+}
@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/syrlramadhan/desa-sukamaju-api/dto"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/services"
+)
+
+type KategoriController interface {
+	CreateKategori(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	GetAllKategori(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	UpdateKategori(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	DeleteKategori(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	GetAllTag(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+}
+
+type kategoriControllerImpl struct {
+	KategoriService services.KategoriService
+}
+
+func NewKategoriController(kategoriService services.KategoriService) KategoriController {
+	return &kategoriControllerImpl{
+		KategoriService: kategoriService,
+	}
+}
+
+// CreateKategori implements KategoriController.
+func (k *kategoriControllerImpl) CreateKategori(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	kategoriReq := dto.KategoriRequest{}
+	helpers.ReadFromRequestBody(r, &kategoriReq)
+
+	kategoriResponse, code, err := k.KategoriService.CreateKategori(r.Context(), kategoriReq)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONSuccess(w, kategoriResponse, "berhasil menambahkan kategori")
+}
+
+// GetAllKategori implements KategoriController.
+func (k *kategoriControllerImpl) GetAllKategori(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	kategoriResponse, code, err := k.KategoriService.GetAllKategori(r.Context())
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONSuccess(w, kategoriResponse, "berhasil mendapatkan data kategori")
+}
+
+// UpdateKategori implements KategoriController.
+func (k *kategoriControllerImpl) UpdateKategori(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	kategoriReq := dto.KategoriRequest{}
+	helpers.ReadFromRequestBody(r, &kategoriReq)
+
+	kategoriResponse, code, err := k.KategoriService.UpdateKategori(r.Context(), ps.ByName("id_kategori"), kategoriReq)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONSuccess(w, kategoriResponse, "berhasil memperbarui kategori")
+}
+
+// DeleteKategori implements KategoriController.
+func (k *kategoriControllerImpl) DeleteKategori(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	code, err := k.KategoriService.DeleteKategori(r.Context(), ps.ByName("id_kategori"))
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONNoData(w, "berhasil menghapus kategori")
+}
+
+// GetAllTag implements KategoriController.
+func (k *kategoriControllerImpl) GetAllTag(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	tagResponse, code, err := k.KategoriService.GetAllTag(r.Context())
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONSuccess(w, tagResponse, "berhasil mendapatkan data tag")
+}
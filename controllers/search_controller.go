@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/services"
+)
+
+type SearchController interface {
+	Search(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+}
+
+type searchControllerImpl struct {
+	SearchService services.SearchService
+}
+
+func NewSearchController(searchService services.SearchService) SearchController {
+	return &searchControllerImpl{
+		SearchService: searchService,
+	}
+}
+
+// Search implements SearchController.
+func (s *searchControllerImpl) Search(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	query := r.URL.Query()
+	page, perPage := helpers.ParsePagination(query)
+
+	searchResponse, meta, code, err := s.SearchService.Search(r.Context(), query.Get("q"), page, perPage)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.SetPaginationLinks(&meta, r.URL)
+	helpers.WriteJSONPaginated(w, searchResponse, meta, "berhasil melakukan pencarian")
+}
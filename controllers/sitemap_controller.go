@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/services"
+)
+
+type SitemapController interface {
+	GetSitemap(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	GetSitemapPage(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	GetRobotsTxt(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+}
+
+type sitemapControllerImpl struct {
+	SitemapService services.SitemapService
+}
+
+func NewSitemapController(sitemapService services.SitemapService) SitemapController {
+	return &sitemapControllerImpl{
+		SitemapService: sitemapService,
+	}
+}
+
+// GetSitemap implements SitemapController.
+// Jika seluruh URL muat dalam satu file, sitemap.xml langsung berisi daftar URL,
+// selain itu berisi sitemap index yang menunjuk ke /sitemap/<n>.xml.
+func (s *sitemapControllerImpl) GetSitemap(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	pages, code, err := s.SitemapService.CountSitemapPages(r.Context())
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	if pages <= 1 {
+		s.writeSitemapPage(w, r, 1)
+		return
+	}
+
+	index, code, err := s.SitemapService.GetSitemapIndex(r.Context(), pages, helpers.RequestBaseURL(r))
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	writeSitemap(w, index)
+}
+
+// GetSitemapPage implements SitemapController.
+func (s *sitemapControllerImpl) GetSitemapPage(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	page, err := strconv.Atoi(strings.TrimSuffix(ps.ByName("file"), ".xml"))
+	if err != nil || !strings.HasSuffix(ps.ByName("file"), ".xml") {
+		helpers.WriteJSONError(w, http.StatusNotFound, "sitemap tidak ditemukan")
+		return
+	}
+
+	s.writeSitemapPage(w, r, page)
+}
+
+// GetRobotsTxt implements SitemapController.
+func (s *sitemapControllerImpl) GetRobotsTxt(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.Write([]byte(s.SitemapService.GetRobotsTxt(helpers.RequestBaseURL(r))))
+}
+
+func (s *sitemapControllerImpl) writeSitemapPage(w http.ResponseWriter, r *http.Request, page int) {
+	urlSet, code, err := s.SitemapService.GetSitemapPage(r.Context(), page)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	writeSitemap(w, urlSet)
+}
+
+func writeSitemap(w http.ResponseWriter, sitemap interface{}) {
+	var body bytes.Buffer
+	body.WriteString(xml.Header)
+	if err := xml.NewEncoder(&body).Encode(sitemap); err != nil {
+		helpers.WriteJSONError(w, http.StatusInternalServerError, "gagal membuat sitemap")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.Write(body.Bytes())
+}
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/syrlramadhan/desa-sukamaju-api/dto"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/services"
+)
+
+type TrashController interface {
+	GetTrashBerita(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	RestoreBerita(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	GetTrashAparat(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	RestoreAparat(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	GetTrashPhoto(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
+	RestorePhoto(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+}
+
+type trashControllerImpl struct {
+	TrashService services.TrashService
+}
+
+func NewTrashController(trashService services.TrashService) TrashController {
+	return &trashControllerImpl{
+		TrashService: trashService,
+	}
+}
+
+// GetTrashBerita implements TrashController.
+func (t *trashControllerImpl) GetTrashBerita(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	page, perPage := helpers.ParsePagination(r.URL.Query())
+
+	beritaResponse, meta, code, err := t.TrashService.GetTrashBerita(r.Context(), dto.TrashQuery{Page: page, PerPage: perPage})
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.SetPaginationLinks(&meta, r.URL)
+	helpers.WriteJSONPaginated(w, beritaResponse, meta, "berhasil mendapatkan berita di tempat sampah")
+}
+
+// RestoreBerita implements TrashController.
+func (t *trashControllerImpl) RestoreBerita(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	beritaResponse, code, err := t.TrashService.RestoreBerita(r.Context(), ps.ByName("id_berita"))
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONSuccess(w, beritaResponse, "berhasil memulihkan berita")
+}
+
+// GetTrashAparat implements TrashController.
+func (t *trashControllerImpl) GetTrashAparat(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	page, perPage := helpers.ParsePagination(r.URL.Query())
+
+	aparatResponse, meta, code, err := t.TrashService.GetTrashAparat(r.Context(), dto.TrashQuery{Page: page, PerPage: perPage})
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.SetPaginationLinks(&meta, r.URL)
+	helpers.WriteJSONPaginated(w, aparatResponse, meta, "berhasil mendapatkan aparat di tempat sampah")
+}
+
+// RestoreAparat implements TrashController.
+func (t *trashControllerImpl) RestoreAparat(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	aparatResponse, code, err := t.TrashService.RestoreAparat(r.Context(), ps.ByName("id_aparat"))
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONSuccess(w, aparatResponse, "berhasil memulihkan aparat")
+}
+
+// GetTrashPhoto implements TrashController.
+func (t *trashControllerImpl) GetTrashPhoto(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	query := r.URL.Query()
+	page, perPage := helpers.ParsePagination(query)
+
+	trashQuery := dto.TrashQuery{
+		IdBerita: query.Get("id_berita"),
+		IdAlbum:  query.Get("id_album"),
+		Page:     page,
+		PerPage:  perPage,
+	}
+
+	photoResponse, meta, code, err := t.TrashService.GetTrashPhoto(r.Context(), trashQuery)
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.SetPaginationLinks(&meta, r.URL)
+	helpers.WriteJSONPaginated(w, photoResponse, meta, "berhasil mendapatkan foto di tempat sampah")
+}
+
+// RestorePhoto implements TrashController.
+func (t *trashControllerImpl) RestorePhoto(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	code, err := t.TrashService.RestorePhoto(r.Context(), ps.ByName("filename"))
+	if err != nil {
+		helpers.WriteJSONError(w, code, err.Error())
+		return
+	}
+
+	helpers.WriteJSONNoData(w, "berhasil memulihkan foto")
+}
@@ -14,3 +14,35 @@ type LoginRequest struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
 }
+
+type CreateAdminRequest struct {
+	Username           string `json:"username"`
+	Password           string `json:"password"`
+	KonfirmasiPassword string `json:"konfirmasi_password"`
+	Role               string `json:"role"`
+}
+
+type UpdateAdminRequest struct {
+	Role   string `json:"role"`
+	Status string `json:"status"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recovery_code"`
+}
+
+type DisableTwoFactorRequest struct {
+	Password     string `json:"password"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
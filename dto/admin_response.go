@@ -3,4 +3,30 @@ package dto
 type AdminResponse struct {
 	IdAdmin  string `json:"id_admin"`
 	Username string `json:"username"`
-}
\ No newline at end of file
+	Role     string `json:"role"`
+	Status   string `json:"status"`
+}
+
+type TokenResponse struct {
+	AccessToken       string
+	RefreshToken      string
+	ExpiresIn         int
+	TwoFactorRequired bool
+	ChallengeToken    string
+}
+
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type LoginAttemptResponse struct {
+	IdLoginAttempt string `json:"id_login_attempt"`
+	Username       string `json:"username"`
+	IpAddress      string `json:"ip_address"`
+	CreatedAt      string `json:"created_at"`
+}
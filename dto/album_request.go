@@ -0,0 +1,11 @@
+package dto
+
+type AlbumRequest struct {
+	Judul     string `json:"judul"`
+	Deskripsi string `json:"deskripsi"`
+}
+
+type AlbumQuery struct {
+	Page    int
+	PerPage int
+}
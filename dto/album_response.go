@@ -0,0 +1,12 @@
+package dto
+
+type AlbumResponse struct {
+	IdAlbum    string                `json:"id_album"`
+	Judul      string                `json:"judul"`
+	Deskripsi  string                `json:"deskripsi"`
+	JumlahFoto int                   `json:"jumlah_foto"`
+	Cover      *GaleriPhotoResponse  `json:"cover"`
+	Foto       []GaleriPhotoResponse `json:"foto,omitempty"`
+	CreatedAt  string                `json:"created_at"`
+	UpdatedAt  string                `json:"updated_at"`
+}
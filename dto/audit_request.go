@@ -0,0 +1,12 @@
+package dto
+
+type AuditQuery struct {
+	IdAdmin  string
+	Action   string
+	Entity   string
+	EntityId string
+	From     string
+	To       string
+	Page     int
+	PerPage  int
+}
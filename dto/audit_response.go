@@ -0,0 +1,15 @@
+package dto
+
+import "encoding/json"
+
+type AuditResponse struct {
+	IdAudit   string          `json:"id_audit"`
+	IdAdmin   string          `json:"id_admin"`
+	Username  string          `json:"username"`
+	Action    string          `json:"action"`
+	Entity    string          `json:"entity"`
+	EntityId  string          `json:"entity_id"`
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+	CreatedAt string          `json:"created_at"`
+}
@@ -2,8 +2,28 @@ package dto
 
 type BeritaRequest struct {
 	JudulBerita        string   `json:"judul_berita"`
+	IdKategori         string   `json:"id_kategori"`
 	Kategori           string   `json:"kategori"`
 	TanggalPelaksanaan string   `json:"tanggal_pelaksanaan"`
 	Deskripsi          string   `json:"deskripsi"`
+	PublishAt          string   `json:"publish_at"`
+	ExpireAt           string   `json:"expire_at"`
+	Tags               []string `json:"tags"`
 	GambarBerita       []string `json:"gambar_berita"`
 }
+
+type BeritaStatusRequest struct {
+	Status string `json:"status"`
+}
+
+type BeritaQuery struct {
+	Status   string
+	Kategori string
+	Tag      string
+	From     string
+	To       string
+	Sort     string
+	Order    string
+	Page     int
+	PerPage  int
+}
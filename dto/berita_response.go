@@ -1,11 +1,22 @@
 package dto
 
 type BeritaResponse struct {
-	IdBerita           string   `json:"id_berita"`
-	JudulBerita        string   `json:"judul_berita"`
-	Kategori           string   `json:"kategori"`
-	TanggalPelaksanaan string   `json:"tanggal_pelaksanaan"`
-	Deskripsi          string   `json:"deskripsi"`
-	GambarBerita       []string `json:"gambar_berita"`
-	CreatedAt          string   `json:"created_at"`
+	IdBerita           string                `json:"id_berita"`
+	JudulBerita        string                `json:"judul_berita"`
+	Slug               string                `json:"slug"`
+	IdKategori         string                `json:"id_kategori"`
+	Kategori           string                `json:"kategori"`
+	KategoriSlug       string                `json:"kategori_slug"`
+	TanggalPelaksanaan string                `json:"tanggal_pelaksanaan"`
+	Deskripsi          string                `json:"deskripsi"`
+	DeskripsiHTML      string                `json:"deskripsi_html"`
+	Ringkasan          string                `json:"ringkasan"`
+	Status             string                `json:"status"`
+	PublishAt          string                `json:"publish_at,omitempty"`
+	ExpireAt           string                `json:"expire_at,omitempty"`
+	Tags               []TagResponse         `json:"tags"`
+	Cover              *GaleriPhotoResponse  `json:"cover"`
+	GambarBerita       []GaleriPhotoResponse `json:"gambar_berita"`
+	CreatedAt          string                `json:"created_at"`
+	DeletedAt          string                `json:"deleted_at,omitempty"`
 }
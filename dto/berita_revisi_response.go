@@ -0,0 +1,30 @@
+package dto
+
+type BeritaRevisiResponse struct {
+	IdRevisi           string `json:"id_revisi"`
+	IdBerita           string `json:"id_berita"`
+	Versi              int    `json:"versi"`
+	JudulBerita        string `json:"judul_berita"`
+	Kategori           string `json:"kategori"`
+	TanggalPelaksanaan string `json:"tanggal_pelaksanaan"`
+	Deskripsi          string `json:"deskripsi"`
+	PublishAt          string `json:"publish_at,omitempty"`
+	ExpireAt           string `json:"expire_at,omitempty"`
+	IdAdmin            string `json:"id_admin"`
+	Username           string `json:"username"`
+	CreatedAt          string `json:"created_at"`
+}
+
+type BeritaRevisiFieldDiff struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// BeritaRevisiDiffResponse membandingkan dua revisi, To bernilai 0 jika dibandingkan dengan isi berita saat ini
+type BeritaRevisiDiffResponse struct {
+	IdBerita string                  `json:"id_berita"`
+	From     int                     `json:"from"`
+	To       int                     `json:"to"`
+	Changes  []BeritaRevisiFieldDiff `json:"changes"`
+}
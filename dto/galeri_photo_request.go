@@ -0,0 +1,14 @@
+package dto
+
+type GaleriPhotoRequest struct {
+	Caption string `json:"caption"`
+	AltText string `json:"alt_text"`
+}
+
+type GaleriUrutanRequest struct {
+	IdGaleri []string `json:"id_galeri"`
+}
+
+type GaleriCoverRequest struct {
+	IdGaleri string `json:"id_galeri"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+// GaleriPhotoResponse menyertakan field ImageResponse secara langsung sehingga url dan varian gambar
+// tetap berada di tingkat yang sama seperti sebelumnya
+type GaleriPhotoResponse struct {
+	IdGaleri string `json:"id_galeri"`
+	Urutan   int    `json:"urutan"`
+	Caption  string `json:"caption"`
+	AltText  string `json:"alt_text"`
+	IsCover  bool   `json:"is_cover"`
+	ImageResponse
+}
@@ -0,0 +1,12 @@
+package dto
+
+type ImageResponse struct {
+	Gambar        string `json:"gambar"`
+	Url           string `json:"url"`
+	Thumbnail     string `json:"thumbnail,omitempty"`
+	Medium        string `json:"medium,omitempty"`
+	Large         string `json:"large,omitempty"`
+	ThumbnailWebp string `json:"thumbnail_webp,omitempty"`
+	MediumWebp    string `json:"medium_webp,omitempty"`
+	LargeWebp     string `json:"large_webp,omitempty"`
+}
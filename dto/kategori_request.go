@@ -0,0 +1,7 @@
+package dto
+
+type KategoriRequest struct {
+	Nama   string `json:"nama"`
+	Slug   string `json:"slug"`
+	Urutan int    `json:"urutan"`
+}
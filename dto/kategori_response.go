@@ -0,0 +1,16 @@
+package dto
+
+type KategoriResponse struct {
+	IdKategori   string `json:"id_kategori"`
+	Nama         string `json:"nama"`
+	Slug         string `json:"slug"`
+	Urutan       int    `json:"urutan"`
+	JumlahBerita int    `json:"jumlah_berita"`
+}
+
+type TagResponse struct {
+	IdTag        string `json:"id_tag"`
+	Nama         string `json:"nama"`
+	Slug         string `json:"slug"`
+	JumlahBerita int    `json:"jumlah_berita,omitempty"`
+}
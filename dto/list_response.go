@@ -1,10 +1,20 @@
 package dto
 
 type ListResponseOK struct {
-	Code    int         `json:"code"`
-	Status  string      `json:"status"`
-	Data    interface{} `json:"data"`
-	Message string      `json:"message"`
+	Code    int             `json:"code"`
+	Status  string          `json:"status"`
+	Data    interface{}     `json:"data"`
+	Meta    *PaginationMeta `json:"meta,omitempty"`
+	Message string          `json:"message"`
+}
+
+type PaginationMeta struct {
+	Page       int    `json:"page"`
+	PerPage    int    `json:"per_page"`
+	Total      int    `json:"total"`
+	TotalPages int    `json:"total_pages"`
+	Next       string `json:"next,omitempty"`
+	Prev       string `json:"prev,omitempty"`
 }
 
 type ListResponseNoData struct {
@@ -14,10 +24,14 @@ type ListResponseNoData struct {
 }
 
 type LoginResponse struct {
-	Code    int    `json:"code"`
-	Status  string `json:"status"`
-	Token   string `json:"token"`
-	Message string `json:"message"`
+	Code              int    `json:"code"`
+	Status            string `json:"status"`
+	Token             string `json:"token"`
+	RefreshToken      string `json:"refresh_token"`
+	ExpiresIn         int    `json:"expires_in"`
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`
+	Message           string `json:"message"`
 }
 
 type ListResponseError struct {
@@ -0,0 +1,9 @@
+package dto
+
+type SearchResponse struct {
+	Type    string  `json:"type"`
+	Id      string  `json:"id"`
+	Title   string  `json:"title"`
+	Snippet string  `json:"snippet"`
+	Score   float64 `json:"score"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+type TrashQuery struct {
+	IdBerita string
+	IdAlbum  string
+	Page     int
+	PerPage  int
+}
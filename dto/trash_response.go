@@ -0,0 +1,9 @@
+package dto
+
+// TrashPhotoResponse adalah foto di tempat sampah beserta pemiliknya, berita atau album
+type TrashPhotoResponse struct {
+	IdBerita  string `json:"id_berita,omitempty"`
+	IdAlbum   string `json:"id_album,omitempty"`
+	DeletedAt string `json:"deleted_at"`
+	GaleriPhotoResponse
+}
@@ -0,0 +1,13 @@
+package dto
+
+type MissingUploadResponse struct {
+	Entity   string `json:"entity"`
+	IdEntity string `json:"id_entity"`
+	Key      string `json:"key"`
+}
+
+type UploadCheckResponse struct {
+	Missing     []MissingUploadResponse `json:"missing"`
+	Orphaned    []string                `json:"orphaned"`
+	Quarantined []string                `json:"quarantined"`
+}
@@ -0,0 +1,18 @@
+package helpers
+
+import "context"
+
+type contextKey string
+
+const claimsContextKey contextKey = "claims"
+
+// ContextWithClaims menyimpan claims admin yang sudah terautentikasi ke dalam context
+func ContextWithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsContextKey, claims)
+}
+
+// ClaimsFromContext mengambil claims admin dari context, false jika request tidak terautentikasi
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey).(*Claims)
+	return claims, ok && claims != nil
+}
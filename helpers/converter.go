@@ -1,11 +1,161 @@
 package helpers
 
 import (
+	"time"
+
 	"github.com/syrlramadhan/desa-sukamaju-api/dto"
 	"github.com/syrlramadhan/desa-sukamaju-api/models"
+	"github.com/syrlramadhan/desa-sukamaju-api/storage"
 )
 
-func ConvertAparatToResponseDTO(aparat models.Aparat) dto.AparatResponse {
+func ConvertImageToResponseDTO(dir, filename string, variants models.ImageVariants, fileStorage storage.Storage) dto.ImageResponse {
+	if filename == "" {
+		return dto.ImageResponse{}
+	}
+
+	url := func(variant string) string {
+		if variant == "" {
+			return ""
+		}
+		return fileStorage.URL(storage.Key(dir, variant))
+	}
+
+	return dto.ImageResponse{
+		Gambar:        filename,
+		Url:           url(filename),
+		Thumbnail:     url(variants.Thumbnail),
+		Medium:        url(variants.Medium),
+		Large:         url(variants.Large),
+		ThumbnailWebp: url(variants.ThumbnailWebp),
+		MediumWebp:    url(variants.MediumWebp),
+		LargeWebp:     url(variants.LargeWebp),
+	}
+}
+
+func ConvertBeritaToResponseDTO(berita models.Berita, fileStorage storage.Storage) dto.BeritaResponse {
+	gambarBerita := []dto.GaleriPhotoResponse{}
+	for _, galeri := range berita.GambarBerita {
+		if galeri.Gambar != "" {
+			gambarBerita = append(gambarBerita, ConvertGaleriPhotoToResponseDTO(galeri, fileStorage))
+		}
+	}
+
+	var cover *dto.GaleriPhotoResponse
+	if photo, ok := berita.CoverPhoto(); ok {
+		coverResponse := ConvertGaleriPhotoToResponseDTO(photo, fileStorage)
+		cover = &coverResponse
+	}
+
+	tags := []dto.TagResponse{}
+	for _, tag := range berita.Tags {
+		tags = append(tags, ConvertTagToResponseDTO(tag))
+	}
+
+	return dto.BeritaResponse{
+		IdBerita:           berita.IdBerita,
+		JudulBerita:        berita.JudulBerita,
+		Slug:               berita.Slug,
+		IdKategori:         berita.IdKategori,
+		Kategori:           berita.Kategori,
+		KategoriSlug:       berita.KategoriSlug,
+		Tags:               tags,
+		Cover:              cover,
+		TanggalPelaksanaan: berita.TanggalPelaksanaan,
+		Deskripsi:          berita.Deskripsi,
+		DeskripsiHTML:      berita.DeskripsiHTML,
+		Ringkasan:          Excerpt(HTMLToText(berita.DeskripsiHTML), ExcerptLength()),
+		Status:             berita.Status,
+		PublishAt:          FormatLocalTime(berita.PublishAt),
+		ExpireAt:           FormatLocalTime(berita.ExpireAt),
+		GambarBerita:       gambarBerita,
+		CreatedAt:          berita.CreatedAt.Format(time.RFC3339),
+		DeletedAt:          FormatLocalTime(berita.DeletedAt),
+	}
+}
+
+func ConvertGaleriPhotoToResponseDTO(photo models.Galeri, fileStorage storage.Storage) dto.GaleriPhotoResponse {
+	return dto.GaleriPhotoResponse{
+		IdGaleri:      photo.IdGaleri,
+		Urutan:        photo.Urutan,
+		Caption:       photo.Caption,
+		AltText:       photo.AltText,
+		IsCover:       photo.IsCover,
+		ImageResponse: ConvertImageToResponseDTO(storage.DirBerita, photo.Gambar, photo.Varian, fileStorage),
+	}
+}
+
+// ConvertAlbumToResponseDTO mengubah album ke response. Foto hanya ikut pada detail album,
+// listing cukup menampilkan sampul dan jumlah foto.
+func ConvertAlbumToResponseDTO(album models.Album, fileStorage storage.Storage) dto.AlbumResponse {
+	var foto []dto.GaleriPhotoResponse
+	for _, photo := range album.Foto {
+		foto = append(foto, ConvertGaleriPhotoToResponseDTO(photo, fileStorage))
+	}
+
+	var cover *dto.GaleriPhotoResponse
+	if album.Cover != nil {
+		coverResponse := ConvertGaleriPhotoToResponseDTO(*album.Cover, fileStorage)
+		cover = &coverResponse
+	}
+
+	return dto.AlbumResponse{
+		IdAlbum:    album.IdAlbum,
+		Judul:      album.Judul,
+		Deskripsi:  album.Deskripsi,
+		JumlahFoto: album.JumlahFoto,
+		Cover:      cover,
+		Foto:       foto,
+		CreatedAt:  album.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  album.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func ConvertTrashPhotoToResponseDTO(photo models.Galeri, fileStorage storage.Storage) dto.TrashPhotoResponse {
+	return dto.TrashPhotoResponse{
+		IdBerita:            photo.IdBerita,
+		IdAlbum:             photo.IdAlbum,
+		DeletedAt:           FormatLocalTime(photo.DeletedAt),
+		GaleriPhotoResponse: ConvertGaleriPhotoToResponseDTO(photo, fileStorage),
+	}
+}
+
+func ConvertKategoriToResponseDTO(kategori models.KategoriBerita) dto.KategoriResponse {
+	return dto.KategoriResponse{
+		IdKategori:   kategori.IdKategori,
+		Nama:         kategori.Nama,
+		Slug:         kategori.Slug,
+		Urutan:       kategori.Urutan,
+		JumlahBerita: kategori.JumlahBerita,
+	}
+}
+
+func ConvertTagToResponseDTO(tag models.Tag) dto.TagResponse {
+	return dto.TagResponse{
+		IdTag:        tag.IdTag,
+		Nama:         tag.Nama,
+		Slug:         tag.Slug,
+		JumlahBerita: tag.JumlahBerita,
+	}
+}
+
+func ConvertBeritaRevisiToResponseDTO(revisi models.BeritaRevisi) dto.BeritaRevisiResponse {
+	return dto.BeritaRevisiResponse{
+		IdRevisi:           revisi.IdRevisi,
+		IdBerita:           revisi.IdBerita,
+		Versi:              revisi.Versi,
+		JudulBerita:        revisi.JudulBerita,
+		Kategori:           revisi.Kategori,
+		TanggalPelaksanaan: revisi.TanggalPelaksanaan,
+		Deskripsi:          revisi.Deskripsi,
+		PublishAt:          FormatLocalTime(revisi.PublishAt),
+		ExpireAt:           FormatLocalTime(revisi.ExpireAt),
+		IdAdmin:            revisi.IdAdmin,
+		Username:           revisi.Username,
+		CreatedAt:          revisi.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ConvertAparatToResponseDTO(aparat models.Aparat, fileStorage storage.Storage) dto.AparatResponse {
 	return dto.AparatResponse{
 		IdAparat:       aparat.IdAparat,
 		Nama:           aparat.Nama,
@@ -15,16 +165,55 @@ func ConvertAparatToResponseDTO(aparat models.Aparat) dto.AparatResponse {
 		Status:         aparat.Status,
 		PeriodeMulai:   aparat.PeriodeMulai,
 		PeriodeSelesai: aparat.PeriodeSelesai,
-		Foto:           aparat.Foto,
+		Foto:           ConvertImageToResponseDTO(storage.DirAparat, aparat.Foto, aparat.VarianFoto, fileStorage),
+		DeletedAt:      FormatLocalTime(aparat.DeletedAt),
 	}
 }
 
-func ConvertAparatToListResDTO(aparats []models.Aparat) []dto.AparatResponse {
+func ConvertAparatToListResDTO(aparats []models.Aparat, fileStorage storage.Storage) []dto.AparatResponse {
 	var aparatResponse []dto.AparatResponse
 
 	for _, aparat := range aparats {
-		aparatResponse = append(aparatResponse, ConvertAparatToResponseDTO(aparat))
+		aparatResponse = append(aparatResponse, ConvertAparatToResponseDTO(aparat, fileStorage))
 	}
 
 	return aparatResponse
-}
\ No newline at end of file
+}
+
+func ConvertAdminToResponseDTO(admin models.Admin) dto.AdminResponse {
+	return dto.AdminResponse{
+		IdAdmin:  admin.IdAdmin,
+		Username: admin.Username,
+		Role:     admin.Role,
+		Status:   admin.Status,
+	}
+}
+
+func ConvertAdminToListResDTO(admins []models.Admin) []dto.AdminResponse {
+	var adminResponse []dto.AdminResponse
+
+	for _, admin := range admins {
+		adminResponse = append(adminResponse, ConvertAdminToResponseDTO(admin))
+	}
+
+	return adminResponse
+}
+
+func ConvertKontakToResponseDTO(kontak models.Kontak) dto.KontakResponse {
+	return dto.KontakResponse{
+		IdKontak:  kontak.IdKontak,
+		Email:     kontak.Email,
+		Telepon:   kontak.Telepon,
+		Facebook:  kontak.Facebook,
+		Youtube:   kontak.Youtube,
+		Instagram: kontak.Instagram,
+	}
+}
+
+func ConvertPendudukToResponseDTO(penduduk models.Penduduk) dto.PendudukResponse {
+	return dto.PendudukResponse{
+		IdPenduduk:          penduduk.IdPenduduk,
+		TotalPenduduk:       penduduk.TotalPenduduk,
+		TotalKepalaKeluarga: penduduk.TotalKepalaKeluarga,
+	}
+}
@@ -0,0 +1,289 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+)
+
+// ResizeImage mengecilkan gambar sehingga sisi terpanjangnya tidak melebihi maxSize dengan
+// mempertahankan rasio. Gambar yang sudah lebih kecil tidak diperbesar. Setiap piksel hasil
+// adalah rata-rata area piksel sumber (box filter) sehingga hasil downscale tetap halus.
+func ResizeImage(src image.Image, maxSize int) *image.RGBA {
+	rgba := ToRGBA(src)
+
+	width, height := rgba.Bounds().Dx(), rgba.Bounds().Dy()
+	if width <= maxSize && height <= maxSize {
+		return rgba
+	}
+
+	dstWidth, dstHeight := maxSize, maxSize
+	if width > height {
+		dstHeight = max(1, height*maxSize/width)
+	} else {
+		dstWidth = max(1, width*maxSize/height)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for y := 0; y < dstHeight; y++ {
+		y0 := y * height / dstHeight
+		y1 := max(y0+1, (y+1)*height/dstHeight)
+
+		for x := 0; x < dstWidth; x++ {
+			x0 := x * width / dstWidth
+			x1 := max(x0+1, (x+1)*width/dstWidth)
+
+			var r, g, b, a, count uint32
+			for sy := y0; sy < y1; sy++ {
+				offset := rgba.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint32(rgba.Pix[offset])
+					g += uint32(rgba.Pix[offset+1])
+					b += uint32(rgba.Pix[offset+2])
+					a += uint32(rgba.Pix[offset+3])
+					offset += 4
+					count++
+				}
+			}
+
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset] = uint8(r / count)
+			dst.Pix[offset+1] = uint8(g / count)
+			dst.Pix[offset+2] = uint8(b / count)
+			dst.Pix[offset+3] = uint8(a / count)
+		}
+	}
+
+	return dst
+}
+
+// ToRGBA menyalin gambar ke *image.RGBA dengan titik awal (0,0)
+func ToRGBA(src image.Image) *image.RGBA {
+	if rgba, ok := src.(*image.RGBA); ok && rgba.Bounds().Min == (image.Point{}) {
+		return rgba
+	}
+
+	bounds := src.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+
+	return rgba
+}
+
+// ApplyOrientation memutar atau membalik gambar sesuai tag EXIF Orientation (1-8),
+// karena metadata EXIF ikut terhapus saat gambar di-encode ulang
+func ApplyOrientation(src image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	rgba := ToRGBA(src)
+	width, height := rgba.Bounds().Dx(), rgba.Bounds().Dy()
+
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // cermin horizontal
+				dx, dy = width-1-x, y
+			case 3: // putar 180
+				dx, dy = width-1-x, height-1-y
+			case 4: // cermin vertikal
+				dx, dy = x, height-1-y
+			case 5: // transpose
+				dx, dy = y, x
+			case 6: // putar 90 searah jarum jam
+				dx, dy = height-1-y, x
+			case 7: // transverse
+				dx, dy = height-1-y, width-1-x
+			case 8: // putar 90 berlawanan jarum jam
+				dx, dy = y, width-1-x
+			}
+
+			copy(dst.Pix[dst.PixOffset(dx, dy):dst.PixOffset(dx, dy)+4], rgba.Pix[rgba.PixOffset(x, y):rgba.PixOffset(x, y)+4])
+		}
+	}
+
+	return dst
+}
+
+// JPEGOrientation membaca tag Orientation dari segmen EXIF (APP1) sebuah file JPEG.
+// Nilai 1 (normal) dikembalikan jika tag tidak ditemukan atau data tidak valid.
+func JPEGOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for offset := 2; offset+4 <= len(data); {
+		if data[offset] != 0xFF {
+			return 1
+		}
+
+		marker := data[offset+1]
+		// Start of scan: setelah ini hanya ada data gambar
+		if marker == 0xDA {
+			return 1
+		}
+
+		length := int(binary.BigEndian.Uint16(data[offset+2:]))
+		if length < 2 || offset+2+length > len(data) {
+			return 1
+		}
+
+		segment := data[offset+4 : offset+2+length]
+		if marker == 0xE1 && len(segment) > 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return exifOrientation(segment[6:])
+		}
+
+		offset += 2 + length
+	}
+
+	return 1
+}
+
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifdOffset := int(order.Uint32(tiff[4:]))
+	if ifdOffset+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[ifdOffset:]))
+	for i := 0; i < entries; i++ {
+		entry := ifdOffset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+
+		// Tag 0x0112 adalah Orientation bertipe SHORT
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8:]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+
+	return 1
+}
+
+// errInvalidGIF dikembalikan StripGIFMetadata jika struktur blok GIF tidak valid
+var errInvalidGIF = errors.New("struktur gif tidak valid")
+
+// StripGIFMetadata menyalin file GIF blok per blok tanpa extension yang tidak dibutuhkan untuk
+// menampilkan gambar, yaitu comment, plain text dan application extension selain loop animasi
+// (misalnya XMP yang dapat berisi lokasi GPS), serta data apa pun setelah trailer. Frame,
+// palet dan graphic control extension disalin apa adanya sehingga animasi tetap utuh.
+func StripGIFMetadata(data []byte) ([]byte, error) {
+	if len(data) < 13 || (string(data[:6]) != "GIF87a" && string(data[:6]) != "GIF89a") {
+		return nil, errInvalidGIF
+	}
+
+	var out bytes.Buffer
+	offset := 13
+	// Global color table
+	if data[10]&0x80 != 0 {
+		offset += 3 << (data[10]&0x07 + 1)
+	}
+	if offset > len(data) {
+		return nil, errInvalidGIF
+	}
+	out.Write(data[:offset])
+
+	for offset < len(data) {
+		switch data[offset] {
+		case 0x3B: // trailer
+			out.WriteByte(0x3B)
+			return out.Bytes(), nil
+		case 0x2C: // image descriptor
+			start := offset
+			if offset+11 > len(data) {
+				return nil, errInvalidGIF
+			}
+			packed := data[offset+9]
+			offset += 10
+			// Local color table
+			if packed&0x80 != 0 {
+				offset += 3 << (packed&0x07 + 1)
+			}
+			// LZW minimum code size lalu sub-block data gambar
+			end, err := skipGIFSubBlocks(data, offset+1)
+			if err != nil {
+				return nil, err
+			}
+			out.Write(data[start:end])
+			offset = end
+		case 0x21: // extension
+			if offset+2 > len(data) {
+				return nil, errInvalidGIF
+			}
+			end, err := skipGIFSubBlocks(data, offset+2)
+			if err != nil {
+				return nil, err
+			}
+			if keepGIFExtension(data[offset+1], data[offset+2:end]) {
+				out.Write(data[offset:end])
+			}
+			offset = end
+		default:
+			return nil, errInvalidGIF
+		}
+	}
+
+	return nil, errInvalidGIF
+}
+
+// keepGIFExtension menentukan extension yang tetap disalin: graphic control extension (jeda
+// dan transparansi frame) dan application extension loop animasi NETSCAPE2.0/ANIMEXTS1.0
+func keepGIFExtension(label byte, blocks []byte) bool {
+	switch label {
+	case 0xF9:
+		return true
+	case 0xFF:
+		if len(blocks) < 12 || blocks[0] != 11 {
+			return false
+		}
+		identifier := string(blocks[1:12])
+		return identifier == "NETSCAPE2.0" || identifier == "ANIMEXTS1.0"
+	}
+	return false
+}
+
+// skipGIFSubBlocks mengembalikan posisi setelah rangkaian sub-block yang dimulai di offset,
+// termasuk block terminator berukuran 0
+func skipGIFSubBlocks(data []byte, offset int) (int, error) {
+	for {
+		if offset >= len(data) {
+			return 0, errInvalidGIF
+		}
+		size := int(data[offset])
+		offset++
+		if size == 0 {
+			return offset, nil
+		}
+		offset += size
+	}
+}
@@ -0,0 +1,218 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+)
+
+func TestResizeImageBounds(t *testing.T) {
+	tests := []struct {
+		name       string
+		width      int
+		height     int
+		maxSize    int
+		wantWidth  int
+		wantHeight int
+	}{
+		{name: "landscape", width: 4000, height: 3000, maxSize: 1600, wantWidth: 1600, wantHeight: 1200},
+		{name: "portrait", width: 3000, height: 4000, maxSize: 1600, wantWidth: 1200, wantHeight: 1600},
+		{name: "square", width: 2000, height: 2000, maxSize: 400, wantWidth: 400, wantHeight: 400},
+		{name: "smaller not enlarged", width: 800, height: 600, maxSize: 1600, wantWidth: 800, wantHeight: 600},
+		{name: "exact size unchanged", width: 1600, height: 900, maxSize: 1600, wantWidth: 1600, wantHeight: 900},
+		{name: "thin strip keeps at least one pixel", width: 5000, height: 10, maxSize: 100, wantWidth: 100, wantHeight: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ResizeImage(image.NewRGBA(image.Rect(0, 0, tt.width, tt.height)), tt.maxSize)
+			if got.Bounds().Dx() != tt.wantWidth || got.Bounds().Dy() != tt.wantHeight {
+				t.Errorf("ResizeImage() = %dx%d, want %dx%d", got.Bounds().Dx(), got.Bounds().Dy(), tt.wantWidth, tt.wantHeight)
+			}
+			if got.Bounds().Min != (image.Point{}) {
+				t.Errorf("ResizeImage() bounds start at %v, want (0,0)", got.Bounds().Min)
+			}
+		})
+	}
+}
+
+func TestResizeImageAveragesPixels(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.Set(0, 0, color.RGBA{0, 0, 0, 255})
+	src.Set(1, 0, color.RGBA{200, 100, 50, 255})
+
+	got := ResizeImage(src, 1).RGBAAt(0, 0)
+	want := color.RGBA{100, 50, 25, 255}
+	if got != want {
+		t.Errorf("ResizeImage() pixel = %v, want %v", got, want)
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// Gambar 3x2 dengan warna unik per piksel, sehingga posisi setiap piksel hasil dapat ditelusuri
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	pixel := func(x, y int) color.RGBA {
+		return color.RGBA{uint8(x*10 + 1), uint8(y*10 + 1), 0, 255}
+	}
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 3; x++ {
+			src.SetRGBA(x, y, pixel(x, y))
+		}
+	}
+
+	tests := []struct {
+		orientation int
+		width       int
+		height      int
+		// Piksel sumber yang diharapkan berada di pojok kiri atas dan kanan atas hasil
+		topLeft  image.Point
+		topRight image.Point
+	}{
+		{orientation: 1, width: 3, height: 2, topLeft: image.Pt(0, 0), topRight: image.Pt(2, 0)},
+		{orientation: 2, width: 3, height: 2, topLeft: image.Pt(2, 0), topRight: image.Pt(0, 0)},
+		{orientation: 3, width: 3, height: 2, topLeft: image.Pt(2, 1), topRight: image.Pt(0, 1)},
+		{orientation: 4, width: 3, height: 2, topLeft: image.Pt(0, 1), topRight: image.Pt(2, 1)},
+		{orientation: 5, width: 2, height: 3, topLeft: image.Pt(0, 0), topRight: image.Pt(0, 1)},
+		{orientation: 6, width: 2, height: 3, topLeft: image.Pt(0, 1), topRight: image.Pt(0, 0)},
+		{orientation: 7, width: 2, height: 3, topLeft: image.Pt(2, 1), topRight: image.Pt(2, 0)},
+		{orientation: 8, width: 2, height: 3, topLeft: image.Pt(2, 0), topRight: image.Pt(2, 1)},
+		// Nilai di luar 1-8 diabaikan
+		{orientation: 9, width: 3, height: 2, topLeft: image.Pt(0, 0), topRight: image.Pt(2, 0)},
+	}
+
+	for _, tt := range tests {
+		got := ToRGBA(ApplyOrientation(src, tt.orientation))
+		if got.Bounds().Dx() != tt.width || got.Bounds().Dy() != tt.height {
+			t.Errorf("orientation %d: size = %dx%d, want %dx%d", tt.orientation, got.Bounds().Dx(), got.Bounds().Dy(), tt.width, tt.height)
+			continue
+		}
+		if c := got.RGBAAt(0, 0); c != pixel(tt.topLeft.X, tt.topLeft.Y) {
+			t.Errorf("orientation %d: top left = %v, want source pixel %v", tt.orientation, c, tt.topLeft)
+		}
+		if c := got.RGBAAt(tt.width-1, 0); c != pixel(tt.topRight.X, tt.topRight.Y) {
+			t.Errorf("orientation %d: top right = %v, want source pixel %v", tt.orientation, c, tt.topRight)
+		}
+	}
+}
+
+// jpegWithOrientation membuat awal file JPEG dengan segmen APP1 EXIF berisi tag Orientation
+func jpegWithOrientation(order binary.ByteOrder, orientation uint16) []byte {
+	tiff := make([]byte, 26)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], 1)
+	order.PutUint16(tiff[10:], 0x0112)
+	order.PutUint16(tiff[12:], 3)
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], orientation)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	data := []byte{0xFF, 0xD8, 0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(data[4:], uint16(len(segment)+2))
+	data = append(data, segment...)
+
+	return append(data, 0xFF, 0xDA, 0, 2)
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{name: "little endian", data: jpegWithOrientation(binary.LittleEndian, 6), want: 6},
+		{name: "big endian", data: jpegWithOrientation(binary.BigEndian, 8), want: 8},
+		{name: "invalid value", data: jpegWithOrientation(binary.LittleEndian, 9), want: 1},
+		{name: "no exif", data: []byte{0xFF, 0xD8, 0xFF, 0xDA, 0, 2}, want: 1},
+		{name: "truncated segment", data: jpegWithOrientation(binary.BigEndian, 6)[:20], want: 1},
+		{name: "not jpeg", data: []byte("\x89PNG\r\n\x1a\n"), want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := JPEGOrientation(tt.data); got != tt.want {
+				t.Errorf("JPEGOrientation() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripGIFMetadata(t *testing.T) {
+	palette := color.Palette{color.Black, color.White}
+	animation := &gif.GIF{LoopCount: 0}
+	for i := 0; i < 2; i++ {
+		frame := image.NewPaletted(image.Rect(0, 0, 4, 4), palette)
+		frame.SetColorIndex(i, i, 1)
+		animation.Image = append(animation.Image, frame)
+		animation.Delay = append(animation.Delay, 10)
+	}
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, animation); err != nil {
+		t.Fatal(err)
+	}
+	clean := buf.Bytes()
+
+	// Sisipkan comment extension dan XMP application extension sebelum trailer,
+	// lalu tambahkan data setelah trailer
+	var dirty []byte
+	dirty = append(dirty, clean[:len(clean)-1]...)
+	dirty = append(dirty, 0x21, 0xFE, 10)
+	dirty = append(dirty, "GPS -6,106"...)
+	dirty = append(dirty, 0)
+	dirty = append(dirty, 0x21, 0xFF, 11)
+	dirty = append(dirty, "XMP DataXMP"...)
+	dirty = append(dirty, 10)
+	dirty = append(dirty, "<x:xmpmeta"...)
+	dirty = append(dirty, 0, 0x3B)
+	dirty = append(dirty, "<script>"...)
+
+	got, err := StripGIFMetadata(dirty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, clean) {
+		t.Errorf("StripGIFMetadata() tidak mengembalikan GIF tanpa metadata")
+	}
+	for _, leaked := range []string{"GPS", "XMP", "<x:xmpmeta", "<script>"} {
+		if bytes.Contains(got, []byte(leaked)) {
+			t.Errorf("StripGIFMetadata() masih memuat %q", leaked)
+		}
+	}
+
+	decoded, err := gif.DecodeAll(bytes.NewReader(got))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Image) != 2 || decoded.LoopCount != 0 || decoded.Delay[1] != 10 {
+		t.Errorf("animasi tidak utuh: %d frame, loop %d", len(decoded.Image), decoded.LoopCount)
+	}
+}
+
+func TestStripGIFMetadataInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "not gif", data: []byte("\x89PNG\r\n\x1a\n0000000")},
+		{name: "missing trailer", data: []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00")},
+		{name: "truncated extension", data: []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00\x21\xFE\x05ab")},
+		{name: "unknown block", data: []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00\x00")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := StripGIFMetadata(tt.data); err == nil {
+				t.Error("StripGIFMetadata() = nil error, want error")
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package helpers
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"os"
 	"time"
 
@@ -8,24 +12,63 @@ import (
 	"github.com/joho/godotenv"
 )
 
+const (
+	AccessTokenTTL    = 15 * time.Minute
+	RefreshTokenTTL   = 30 * 24 * time.Hour
+	ChallengeTokenTTL = 5 * time.Minute
+)
+
+// TokenPurposeTwoFactor menandai token tantangan login dua langkah yang tidak boleh
+// dipakai untuk mengakses endpoint lain.
+const TokenPurposeTwoFactor = "2fa"
+
 type Claims struct {
-	Id       string `json:"id"`
-	Username string `json:"username"`
+	Id        string `json:"id"`
+	Username  string `json:"username"`
+	Role      string `json:"role"`
+	IdSession string `json:"sid"`
+	Purpose   string `json:"purpose,omitempty"`
 	jwt.StandardClaims
 }
 
-func GenerateJWT(id, username string) (string, error) {
+func GenerateJWT(id, username, role, idSession string) (string, error) {
 	err := godotenv.Load()
 	if err != nil {
 		panic(err)
 	}
 
 	jwtKey := os.Getenv("JWT_SECRET")
-	expirationTime := time.Now().Add(5 * time.Hour)
+	expirationTime := time.Now().Add(AccessTokenTTL)
+
+	claims := &Claims{
+		Id:        id,
+		Username:  username,
+		Role:      role,
+		IdSession: idSession,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: expirationTime.Unix(),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	return token.SignedString([]byte(jwtKey))
+}
+
+// GenerateChallengeJWT menghasilkan token tantangan berumur pendek untuk langkah kedua login
+func GenerateChallengeJWT(id, username string) (string, error) {
+	err := godotenv.Load()
+	if err != nil {
+		panic(err)
+	}
+
+	jwtKey := os.Getenv("JWT_SECRET")
+	expirationTime := time.Now().Add(ChallengeTokenTTL)
 
 	claims := &Claims{
 		Id:       id,
 		Username: username,
+		Purpose:  TokenPurposeTwoFactor,
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: expirationTime.Unix(),
 		},
@@ -46,6 +89,9 @@ func ValidateJWT(tokenString string) (*Claims, error) {
 
 	claims := &Claims{}
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.NewValidationError("metode signing tidak valid", jwt.ValidationErrorSignatureInvalid)
+		}
 		return []byte(jwtKey), nil
 	})
 
@@ -58,4 +104,19 @@ func ValidateJWT(tokenString string) (*Claims, error) {
 	}
 
 	return claims, nil
-}
\ No newline at end of file
+}
+
+// GenerateRefreshToken menghasilkan refresh token acak yang hanya dikirim sekali ke client
+func GenerateRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken menghasilkan hash SHA-256 dari token agar token asli tidak tersimpan di database
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
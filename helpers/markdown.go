@@ -0,0 +1,459 @@
+package helpers
+
+import (
+	"html"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultExcerptLength dipakai jika BERITA_EXCERPT_LENGTH tidak diisi atau tidak valid
+const DefaultExcerptLength = 200
+
+var (
+	markdownHeading    = regexp.MustCompile(`^(#{1,6})(?:[ \t]+(.*?))?[ \t]*#*[ \t]*$`)
+	markdownRule       = regexp.MustCompile(`^(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	markdownFence      = regexp.MustCompile("^(```+|~~~+)[ \t]*([A-Za-z0-9_+#-]*)")
+	markdownBullet     = regexp.MustCompile(`^[-*+][ \t]+`)
+	markdownOrdered    = regexp.MustCompile(`^(\d{1,9})[.)][ \t]+`)
+	markdownSetext     = regexp.MustCompile(`^(=+|-+)[ \t]*$`)
+	markdownHTMLBlock  = regexp.MustCompile(`(?i)^<(?:!--|/?(?:p|div|h[1-6]|ul|ol|li|table|thead|tbody|tr|td|th|blockquote|pre|hr|br|section|article|figure|html|head|body|meta|style|script|o:p)(?:[\s/>]|$))`)
+	markdownEntity     = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[A-Za-z][A-Za-z0-9]{1,31});`)
+	markdownInlineHTML = regexp.MustCompile(`^(?:<!--[\s\S]*?-->|</?[A-Za-z][A-Za-z0-9:-]*(?:\s[^<>]*)?/?>)`)
+	markdownAutolink   = regexp.MustCompile(`^<(https?://[^\s<>]+)>`)
+)
+
+// ExcerptLength mengembalikan panjang ringkasan deskripsi berita dari env BERITA_EXCERPT_LENGTH
+func ExcerptLength() int {
+	length, err := strconv.Atoi(os.Getenv("BERITA_EXCERPT_LENGTH"))
+	if err != nil || length <= 0 {
+		return DefaultExcerptLength
+	}
+	return length
+}
+
+// RenderMarkdown mengubah Markdown menjadi HTML yang sudah disanitasi. HTML terbatas di dalam
+// Markdown ikut diproses sanitizer, sehingga teks tempelan dari Word tetap aman ditampilkan.
+// Baris baru tunggal dijadikan <br> agar deskripsi lama yang berupa teks biasa tampil seperti aslinya.
+func RenderMarkdown(source string) string {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	source = strings.ReplaceAll(source, "\r", "\n")
+	source = strings.ReplaceAll(source, "\t", "    ")
+
+	var builder strings.Builder
+	renderMarkdownBlocks(&builder, strings.Split(source, "\n"), false)
+
+	return SanitizeHTML(builder.String())
+}
+
+// renderMarkdownBlocks merender baris-baris Markdown per blok. tight bernilai true untuk isi
+// item list tanpa baris kosong, di mana paragraf ditulis tanpa tag <p>.
+func renderMarkdownBlocks(builder *strings.Builder, lines []string, tight bool) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case markdownFence.MatchString(trimmed):
+			match := markdownFence.FindStringSubmatch(trimmed)
+			var code []string
+			i++
+			for i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), match[1]) {
+				code = append(code, lines[i])
+				i++
+			}
+			i++
+
+			builder.WriteString("<pre><code")
+			if match[2] != "" {
+				builder.WriteString(` class="language-` + html.EscapeString(strings.ToLower(match[2])) + `"`)
+			}
+			builder.WriteString(">" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case markdownHTMLBlock.MatchString(trimmed):
+			// Blok HTML diteruskan apa adanya sampai baris kosong, sanitizer yang akan membersihkannya
+			for i < len(lines) && strings.TrimSpace(lines[i]) != "" {
+				builder.WriteString(lines[i] + "\n")
+				i++
+			}
+
+		case markdownHeading.MatchString(trimmed):
+			match := markdownHeading.FindStringSubmatch(trimmed)
+			level := strconv.Itoa(len(match[1]))
+			builder.WriteString("<h" + level + ">" + renderMarkdownInline(match[2]) + "</h" + level + ">\n")
+			i++
+
+		case markdownRule.MatchString(trimmed):
+			builder.WriteString("<hr>\n")
+			i++
+
+		case strings.HasPrefix(trimmed, ">"):
+			var quote []string
+			for i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">") {
+				content := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quote = append(quote, strings.TrimPrefix(content, " "))
+				i++
+			}
+			builder.WriteString("<blockquote>\n")
+			renderMarkdownBlocks(builder, quote, false)
+			builder.WriteString("</blockquote>\n")
+
+		case markdownBullet.MatchString(trimmed) || markdownOrdered.MatchString(trimmed):
+			i = renderMarkdownList(builder, lines, i)
+
+		default:
+			var paragraph []string
+			heading := ""
+			for i < len(lines) {
+				current := strings.TrimSpace(lines[i])
+				if current == "" {
+					break
+				}
+				if len(paragraph) > 0 {
+					if match := markdownSetext.FindStringSubmatch(current); match != nil {
+						heading = "2"
+						if match[1][0] == '=' {
+							heading = "1"
+						}
+						i++
+						break
+					}
+					if interruptsParagraph(current) {
+						break
+					}
+				}
+				paragraph = append(paragraph, strings.TrimLeft(lines[i], " "))
+				i++
+			}
+
+			content := renderMarkdownInline(strings.Join(paragraph, "\n"))
+			switch {
+			case heading != "":
+				builder.WriteString("<h" + heading + ">" + content + "</h" + heading + ">\n")
+			case tight:
+				builder.WriteString(content + "\n")
+			default:
+				builder.WriteString("<p>" + content + "</p>\n")
+			}
+		}
+	}
+}
+
+// interruptsParagraph menentukan apakah baris memulai blok baru tanpa harus didahului baris kosong
+func interruptsParagraph(line string) bool {
+	return markdownHeading.MatchString(line) || markdownFence.MatchString(line) ||
+		markdownRule.MatchString(line) || strings.HasPrefix(line, ">") ||
+		markdownBullet.MatchString(line) || markdownHTMLBlock.MatchString(line)
+}
+
+// renderMarkdownList merender list mulai dari baris ke-start dan mengembalikan indeks baris
+// setelah list. Baris yang menjorok ke dalam dianggap bagian dari item sebelumnya, termasuk list bertingkat.
+func renderMarkdownList(builder *strings.Builder, lines []string, start int) int {
+	first := strings.TrimSpace(lines[start])
+	ordered := !markdownBullet.MatchString(first)
+
+	marker := func(line string) (string, bool) {
+		trimmed := strings.TrimSpace(line)
+		if len(line)-len(strings.TrimLeft(line, " ")) >= 2 {
+			return "", false
+		}
+		if ordered {
+			if loc := markdownOrdered.FindStringIndex(trimmed); loc != nil {
+				return trimmed[loc[1]:], true
+			}
+		} else if loc := markdownBullet.FindStringIndex(trimmed); loc != nil {
+			return trimmed[loc[1]:], true
+		}
+		return "", false
+	}
+
+	var items [][]string
+	tight := true
+	i := start
+	for i < len(lines) {
+		if content, ok := marker(lines[i]); ok {
+			items = append(items, []string{content})
+			i++
+			continue
+		}
+
+		trimmed := strings.TrimSpace(lines[i])
+		indented := len(lines[i])-len(strings.TrimLeft(lines[i], " ")) >= 2
+		if trimmed == "" {
+			// Baris kosong hanya melanjutkan list jika diikuti item baru atau baris yang menjorok
+			if i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+				next := lines[i+1]
+				if _, ok := marker(next); ok || len(next)-len(strings.TrimLeft(next, " ")) >= 2 {
+					tight = false
+					items[len(items)-1] = append(items[len(items)-1], "")
+					i++
+					continue
+				}
+			}
+			break
+		}
+		if !indented && interruptsParagraph(trimmed) {
+			break
+		}
+
+		items[len(items)-1] = append(items[len(items)-1], dedent(lines[i], 4))
+		i++
+	}
+
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+		if number := markdownOrdered.FindStringSubmatch(first)[1]; strings.TrimLeft(number, "0") != "1" {
+			tag = `ol start="` + strings.TrimLeft(number, "0") + `"`
+		}
+	}
+
+	builder.WriteString("<" + tag + ">\n")
+	for _, item := range items {
+		var content strings.Builder
+		renderMarkdownBlocks(&content, item, tight)
+		builder.WriteString("<li>" + strings.TrimSuffix(content.String(), "\n") + "</li>\n")
+	}
+	builder.WriteString("</" + strings.Fields(tag)[0] + ">\n")
+
+	return i
+}
+
+func dedent(line string, max int) string {
+	for n := 0; n < max && strings.HasPrefix(line, " "); n++ {
+		line = line[1:]
+	}
+	return line
+}
+
+// renderMarkdownInline merender elemen inline: penekanan, kode, tautan, gambar dan baris baru
+func renderMarkdownInline(text string) string {
+	var builder strings.Builder
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		rest := text[i:]
+
+		switch {
+		case c == '\\' && i+1 < len(text) && text[i+1] == '\n':
+			builder.WriteString("<br>\n")
+			i += 2
+
+		case c == '\\' && i+1 < len(text) && strings.IndexByte("\\`*_{}[]()#+-.!~<>&|\"'", text[i+1]) >= 0:
+			builder.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+
+		case c == '\n':
+			builder.WriteString("<br>\n")
+			i++
+
+		case c == '`':
+			run := len(rest) - len(strings.TrimLeft(rest, "`"))
+			end := strings.Index(rest[run:], rest[:run])
+			if end < 0 {
+				builder.WriteString(rest[:run])
+				i += run
+				continue
+			}
+			code := strings.TrimSpace(rest[run : run+end])
+			builder.WriteString("<code>" + html.EscapeString(code) + "</code>")
+			i += run + end + run
+
+		case c == '!' && strings.HasPrefix(rest, "!["):
+			if label, url, title, n := parseMarkdownLink(rest[1:]); n > 0 {
+				builder.WriteString(`<img src="` + html.EscapeString(url) + `" alt="` + html.EscapeString(HTMLToText(renderMarkdownInline(label))) + `"`)
+				if title != "" {
+					builder.WriteString(` title="` + html.EscapeString(title) + `"`)
+				}
+				builder.WriteString(">")
+				i += 1 + n
+				continue
+			}
+			builder.WriteByte('!')
+			i++
+
+		case c == '[':
+			if label, url, title, n := parseMarkdownLink(rest); n > 0 {
+				builder.WriteString(`<a href="` + html.EscapeString(url) + `"`)
+				if title != "" {
+					builder.WriteString(` title="` + html.EscapeString(title) + `"`)
+				}
+				builder.WriteString(">" + renderMarkdownInline(label) + "</a>")
+				i += n
+				continue
+			}
+			builder.WriteByte('[')
+			i++
+
+		case c == '*' || c == '_' || c == '~':
+			if n := renderMarkdownEmphasis(&builder, text, i); n > 0 {
+				i += n
+				continue
+			}
+			run := len(rest) - len(strings.TrimLeft(rest, string(c)))
+			builder.WriteString(rest[:run])
+			i += run
+
+		case c == '<':
+			if match := markdownAutolink.FindStringSubmatch(rest); match != nil {
+				url := html.EscapeString(match[1])
+				builder.WriteString(`<a href="` + url + `">` + url + "</a>")
+				i += len(match[0])
+				continue
+			}
+			if match := markdownInlineHTML.FindString(rest); match != "" {
+				builder.WriteString(match)
+				i += len(match)
+				continue
+			}
+			builder.WriteString("&lt;")
+			i++
+
+		case c == '&':
+			if match := markdownEntity.FindString(rest); match != "" {
+				builder.WriteString(match)
+				i += len(match)
+				continue
+			}
+			builder.WriteString("&amp;")
+			i++
+
+		case c == '>':
+			builder.WriteString("&gt;")
+			i++
+
+		default:
+			next := strings.IndexAny(rest, "\\\n`![*_~<&>")
+			if next < 0 {
+				next = len(rest)
+			} else if next == 0 {
+				next = 1
+			}
+			builder.WriteString(rest[:next])
+			i += next
+		}
+	}
+
+	// Dua spasi di akhir baris sudah menjadi <br>, spasinya tidak perlu dipertahankan
+	return strings.ReplaceAll(builder.String(), "  <br>", "<br>")
+}
+
+// renderMarkdownEmphasis menangani **tebal**, *miring*, _miring_ dan ~~coret~~ yang dimulai di
+// posisi start. Mengembalikan jumlah byte yang dipakai, atau 0 jika tidak ada penutup yang cocok.
+func renderMarkdownEmphasis(builder *strings.Builder, text string, start int) int {
+	c := text[start]
+	run := len(text[start:]) - len(strings.TrimLeft(text[start:], string(c)))
+
+	var delimiter, tag string
+	switch {
+	case c == '~' && run == 2:
+		delimiter, tag = "~~", "del"
+	case c == '~':
+		return 0
+	case run >= 2:
+		delimiter, tag = strings.Repeat(string(c), 2), "strong"
+	default:
+		delimiter, tag = string(c), "em"
+	}
+
+	open := start + len(delimiter)
+	if open >= len(text) || isMarkdownSpace(text[open]) {
+		return 0
+	}
+	// Garis bawah di tengah kata, misalnya nama_file, bukan penekanan
+	if c == '_' && start > 0 && isMarkdownWordChar(text[start-1]) {
+		return 0
+	}
+
+	for j := open + 1; j+len(delimiter) <= len(text); j++ {
+		if text[j:j+len(delimiter)] != delimiter || isMarkdownSpace(text[j-1]) {
+			continue
+		}
+		after := j + len(delimiter)
+		if after < len(text) && text[after] == c {
+			// Penutup yang lebih panjang milik penekanan lain, misalnya "*" di depan "**"
+			if len(delimiter) == 1 {
+				j = after
+				continue
+			}
+			j++
+			after++
+		}
+		if c == '_' && after < len(text) && isMarkdownWordChar(text[after]) {
+			continue
+		}
+
+		builder.WriteString("<" + tag + ">" + renderMarkdownInline(text[open:j]) + "</" + tag + ">")
+		return after - start
+	}
+
+	return 0
+}
+
+// parseMarkdownLink membaca [label](url "judul") dan mengembalikan panjangnya, atau 0 jika bukan tautan
+func parseMarkdownLink(text string) (label, url, title string, n int) {
+	depth := 0
+	end := -1
+	for i := 0; i < len(text) && end < 0; i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				end = i
+			}
+		}
+	}
+	if end < 0 || end+1 >= len(text) || text[end+1] != '(' {
+		return "", "", "", 0
+	}
+
+	// Tanda kurung di dalam URL boleh ada selama berpasangan, misalnya https://id.wikipedia.org/wiki/Desa_(Indonesia)
+	closing := -1
+	parens := 0
+	for i := end + 2; i < len(text) && closing < 0; i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '(':
+			parens++
+		case ')':
+			if parens == 0 {
+				closing = i - end - 2
+			}
+			parens--
+		}
+	}
+	if closing < 0 {
+		return "", "", "", 0
+	}
+	destination := strings.TrimSpace(text[end+2 : end+2+closing])
+
+	if space := strings.IndexAny(destination, " \t\n"); space >= 0 {
+		title = strings.TrimSpace(destination[space:])
+		destination = destination[:space]
+		if len(title) < 2 || (title[0] != '"' && title[0] != '\'') || title[len(title)-1] != title[0] {
+			return "", "", "", 0
+		}
+		title = title[1 : len(title)-1]
+	}
+	destination = strings.TrimSuffix(strings.TrimPrefix(destination, "<"), ">")
+
+	// Entitas didekode agar URL seperti "&#106;avascript:" diperiksa sanitizer dalam bentuk aslinya
+	return text[1:end], html.UnescapeString(destination), html.UnescapeString(title), end + 3 + closing
+}
+
+func isMarkdownSpace(c byte) bool {
+	return c == ' ' || c == '\n'
+}
+
+func isMarkdownWordChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
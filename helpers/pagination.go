@@ -0,0 +1,68 @@
+package helpers
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/dto"
+)
+
+const (
+	DefaultPerPage = 10
+	MaxPerPage     = 100
+)
+
+// ParsePagination membaca parameter page dan per_page dari query string dengan nilai bawaan yang aman
+func ParsePagination(query url.Values) (int, int) {
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	perPage, err := strconv.Atoi(query.Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = DefaultPerPage
+	}
+	if perPage > MaxPerPage {
+		perPage = MaxPerPage
+	}
+
+	return page, perPage
+}
+
+// NewPaginationMeta menghitung metadata pagination dari total data
+func NewPaginationMeta(page, perPage, total int) dto.PaginationMeta {
+	totalPages := 0
+	if perPage > 0 {
+		totalPages = (total + perPage - 1) / perPage
+	}
+
+	return dto.PaginationMeta{
+		Page:       page,
+		PerPage:    perPage,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
+
+// SetPaginationLinks mengisi link halaman berikutnya dan sebelumnya dengan mempertahankan query string lain
+func SetPaginationLinks(meta *dto.PaginationMeta, requestURL *url.URL) {
+	link := func(page int) string {
+		query := requestURL.Query()
+		query.Set("page", strconv.Itoa(page))
+		query.Set("per_page", strconv.Itoa(meta.PerPage))
+		return requestURL.Path + "?" + query.Encode()
+	}
+
+	if meta.Page < meta.TotalPages {
+		meta.Next = link(meta.Page + 1)
+	}
+
+	if meta.Page > 1 {
+		prevPage := meta.Page - 1
+		if prevPage > meta.TotalPages && meta.TotalPages > 0 {
+			prevPage = meta.TotalPages
+		}
+		meta.Prev = link(prevPage)
+	}
+}
@@ -22,6 +22,22 @@ func WriteJSONSuccess(w http.ResponseWriter, data interface{}, message string) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// WriteJSONPaginated digunakan untuk mengirim response sukses beserta metadata pagination
+func WriteJSONPaginated(w http.ResponseWriter, data interface{}, meta dto.PaginationMeta, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := dto.ListResponseOK{
+		Code:    http.StatusOK,
+		Status:  http.StatusText(http.StatusOK),
+		Data:    data,
+		Meta:    &meta,
+		Message: message,
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
 // WriteJSONNoData digunakan untuk mengirim response sukses dalam format JSON
 func WriteJSONNoData(w http.ResponseWriter, message string) {
 	w.Header().Set("Content-Type", "application/json")
@@ -37,15 +53,19 @@ func WriteJSONNoData(w http.ResponseWriter, message string) {
 }
 
 // WriteJSONLogin digunakan untuk mengirim response sukses dalam format JSON
-func WriteJSONLogin(w http.ResponseWriter, token string, message string) {
+func WriteJSONLogin(w http.ResponseWriter, token dto.TokenResponse, message string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
 	response := dto.LoginResponse{
-		Code:    http.StatusOK,
-		Status:  http.StatusText(http.StatusOK),
-		Token:   token,
-		Message: message,
+		Code:              http.StatusOK,
+		Status:            http.StatusText(http.StatusOK),
+		Token:             token.AccessToken,
+		RefreshToken:      token.RefreshToken,
+		ExpiresIn:         token.ExpiresIn,
+		TwoFactorRequired: token.TwoFactorRequired,
+		ChallengeToken:    token.ChallengeToken,
+		Message:           message,
 	}
 
 	json.NewEncoder(w).Encode(response)
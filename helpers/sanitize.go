@@ -0,0 +1,331 @@
+package helpers
+
+import (
+	"html"
+	"strings"
+)
+
+// sanitizeAllowedTags adalah daftar tag yang boleh tampil di deskripsi berita beserta atribut
+// yang diizinkan. Tag lain dibuang tetapi isi teksnya tetap dipertahankan.
+var sanitizeAllowedTags = map[string][]string{
+	"p": nil, "br": nil, "hr": nil,
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+	"strong": nil, "b": nil, "em": nil, "i": nil, "u": nil, "s": nil, "del": nil,
+	"sub": nil, "sup": nil, "blockquote": nil, "pre": nil, "code": {"class"},
+	"ul": nil, "ol": {"start"}, "li": nil,
+	"a": {"href", "title"}, "img": {"src", "alt", "title"},
+	"table": nil, "thead": nil, "tbody": nil, "tr": nil,
+	"th": {"colspan", "rowspan"}, "td": {"colspan", "rowspan"},
+}
+
+// sanitizeVoidTags adalah tag tanpa tag penutup
+var sanitizeVoidTags = map[string]bool{"br": true, "hr": true, "img": true}
+
+// sanitizeDroppedTags dibuang bersama seluruh isinya karena isinya bukan teks yang layak tampil
+var sanitizeDroppedTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true, "noscript": true,
+	"template": true, "svg": true, "math": true, "head": true, "title": true, "textarea": true, "xml": true,
+}
+
+type htmlAttribute struct {
+	name  string
+	value string
+}
+
+// SanitizeHTML membersihkan HTML dengan allow-list: hanya tag dan atribut di sanitizeAllowedTags
+// yang dipertahankan, URL hanya boleh http, https, mailto, tel atau relatif, komentar dibuang, dan
+// tag yang tidak ditutup ditutup otomatis. Cocok untuk HTML hasil salin dari Word.
+func SanitizeHTML(input string) string {
+	var builder strings.Builder
+	var stack []string
+
+	for i := 0; i < len(input); {
+		if input[i] != '<' {
+			next := strings.IndexByte(input[i:], '<')
+			if next < 0 {
+				next = len(input) - i
+			}
+			builder.WriteString(html.EscapeString(html.UnescapeString(input[i : i+next])))
+			i += next
+			continue
+		}
+
+		rest := input[i:]
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			end := strings.Index(rest[4:], "-->")
+			if end < 0 {
+				return closeOpenTags(&builder, stack)
+			}
+			i += 4 + end + 3
+			continue
+		case strings.HasPrefix(rest, "<!") || strings.HasPrefix(rest, "<?"):
+			end := strings.IndexByte(rest, '>')
+			if end < 0 {
+				return closeOpenTags(&builder, stack)
+			}
+			i += end + 1
+			continue
+		}
+
+		name, attrs, closing, selfClosing, n := parseHTMLTag(rest)
+		if n == 0 {
+			builder.WriteString("&lt;")
+			i++
+			continue
+		}
+		i += n
+
+		if !closing && sanitizeDroppedTags[name] && !selfClosing {
+			end := indexFold(input[i:], "</"+name)
+			if end < 0 {
+				return closeOpenTags(&builder, stack)
+			}
+			i += end
+			if gt := strings.IndexByte(input[i:], '>'); gt >= 0 {
+				i += gt + 1
+			} else {
+				i = len(input)
+			}
+			continue
+		}
+
+		allowedAttrs, allowed := sanitizeAllowedTags[name]
+		if !allowed {
+			continue
+		}
+
+		if closing {
+			for j := len(stack) - 1; j >= 0; j-- {
+				if stack[j] != name {
+					continue
+				}
+				for k := len(stack) - 1; k >= j; k-- {
+					builder.WriteString("</" + stack[k] + ">")
+				}
+				stack = stack[:j]
+				break
+			}
+			continue
+		}
+
+		builder.WriteString("<" + name)
+		for _, attr := range attrs {
+			if value, ok := sanitizeAttribute(name, attr, allowedAttrs); ok {
+				builder.WriteString(" " + attr.name + `="` + html.EscapeString(value) + `"`)
+			}
+		}
+		if name == "a" {
+			builder.WriteString(` rel="nofollow noopener noreferrer"`)
+		}
+		builder.WriteString(">")
+
+		if !sanitizeVoidTags[name] {
+			stack = append(stack, name)
+		}
+	}
+
+	return closeOpenTags(&builder, stack)
+}
+
+func closeOpenTags(builder *strings.Builder, stack []string) string {
+	for i := len(stack) - 1; i >= 0; i-- {
+		builder.WriteString("</" + stack[i] + ">")
+	}
+	return builder.String()
+}
+
+// sanitizeAttribute memeriksa satu atribut dan mengembalikan nilainya jika boleh dipertahankan
+func sanitizeAttribute(tag string, attr htmlAttribute, allowedAttrs []string) (string, bool) {
+	allowed := false
+	for _, name := range allowedAttrs {
+		if attr.name == name {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return "", false
+	}
+
+	value := strings.TrimSpace(attr.value)
+	switch attr.name {
+	case "href", "src":
+		return value, isSafeURL(value, tag == "a")
+	case "start", "colspan", "rowspan":
+		if value == "" || len(value) > 4 || strings.Trim(value, "0123456789") != "" {
+			return "", false
+		}
+		return value, true
+	case "class":
+		// Hanya penanda bahasa dari blok kode Markdown, misalnya "language-go"
+		if !strings.HasPrefix(value, "language-") || strings.ContainsAny(value, " \t\n\"'<>") {
+			return "", false
+		}
+		return value, true
+	default:
+		return value, true
+	}
+}
+
+// isSafeURL menolak skema seperti javascript: dan data:. Skema diperiksa setelah karakter kontrol
+// dan spasi dibuang karena browser juga mengabaikannya, misalnya "java\tscript:".
+func isSafeURL(value string, allowMail bool) bool {
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, value)
+	if cleaned == "" {
+		return false
+	}
+
+	colon := strings.IndexByte(cleaned, ':')
+	if colon < 0 || strings.ContainsAny(cleaned[:colon], "/?#") {
+		return true
+	}
+
+	switch strings.ToLower(cleaned[:colon]) {
+	case "http", "https":
+		return true
+	case "mailto", "tel":
+		return allowMail
+	default:
+		return false
+	}
+}
+
+// parseHTMLTag membaca tag pembuka atau penutup di awal input. n bernilai 0 jika input tidak
+// diawali tag yang valid, sehingga "<" diperlakukan sebagai teks biasa.
+func parseHTMLTag(input string) (name string, attrs []htmlAttribute, closing, selfClosing bool, n int) {
+	i := 1
+	if i < len(input) && input[i] == '/' {
+		closing = true
+		i++
+	}
+
+	start := i
+	for i < len(input) && isTagNameChar(input[i], i == start) {
+		i++
+	}
+	if i == start {
+		return "", nil, false, false, 0
+	}
+	name = strings.ToLower(input[start:i])
+
+	for {
+		for i < len(input) && isHTMLSpace(input[i]) {
+			i++
+		}
+		if i >= len(input) {
+			return "", nil, false, false, 0
+		}
+
+		switch input[i] {
+		case '>':
+			return name, attrs, closing, selfClosing, i + 1
+		case '/':
+			selfClosing = true
+			i++
+			continue
+		}
+		selfClosing = false
+
+		attrStart := i
+		for i < len(input) && !isHTMLSpace(input[i]) && !strings.ContainsRune("=>/", rune(input[i])) {
+			i++
+		}
+		attr := htmlAttribute{name: strings.ToLower(input[attrStart:i])}
+		if attr.name == "" {
+			// Karakter "=" tanpa nama atribut, dilewati
+			i++
+			continue
+		}
+
+		for i < len(input) && isHTMLSpace(input[i]) {
+			i++
+		}
+		if i < len(input) && input[i] == '=' {
+			i++
+			for i < len(input) && isHTMLSpace(input[i]) {
+				i++
+			}
+			if i < len(input) && (input[i] == '"' || input[i] == '\'') {
+				quote := input[i]
+				end := strings.IndexByte(input[i+1:], quote)
+				if end < 0 {
+					return "", nil, false, false, 0
+				}
+				attr.value = input[i+1 : i+1+end]
+				i += end + 2
+			} else {
+				valueStart := i
+				for i < len(input) && !isHTMLSpace(input[i]) && input[i] != '>' {
+					i++
+				}
+				attr.value = input[valueStart:i]
+			}
+		}
+		attr.value = html.UnescapeString(attr.value)
+		attrs = append(attrs, attr)
+	}
+}
+
+func isTagNameChar(c byte, first bool) bool {
+	if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' {
+		return true
+	}
+	return !first && (c >= '0' && c <= '9' || c == '-' || c == ':')
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// indexFold seperti strings.Index tetapi tidak membedakan huruf besar dan kecil
+func indexFold(s, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if strings.EqualFold(s[i:i+len(substr)], substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+// HTMLToText mengubah HTML menjadi teks biasa: tag dibuang, entitas didekode dan spasi dirapikan
+func HTMLToText(input string) string {
+	var builder strings.Builder
+	for i := 0; i < len(input); {
+		if input[i] == '<' {
+			if _, _, _, _, n := parseHTMLTag(input[i:]); n > 0 {
+				builder.WriteByte(' ')
+				i += n
+				continue
+			}
+		}
+		next := strings.IndexByte(input[i+1:], '<')
+		if next < 0 {
+			next = len(input) - i - 1
+		}
+		builder.WriteString(input[i : i+1+next])
+		i += 1 + next
+	}
+
+	return strings.Join(strings.Fields(html.UnescapeString(builder.String())), " ")
+}
+
+// Excerpt memotong teks menjadi paling banyak length karakter pada batas kata dan menambahkan "…"
+func Excerpt(text string, length int) string {
+	runes := []rune(text)
+	if length <= 0 || len(runes) <= length {
+		return text
+	}
+
+	cut := string(runes[:length])
+	if i := strings.LastIndexByte(cut, ' '); i > 0 {
+		cut = cut[:i]
+	}
+
+	return strings.TrimRight(cut, " ,.;:") + "…"
+}
@@ -0,0 +1,299 @@
+package helpers
+
+import "testing"
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "allowed tags kept",
+			input: "<p>Halo <strong>warga</strong> <em>desa</em></p>",
+			want:  "<p>Halo <strong>warga</strong> <em>desa</em></p>",
+		},
+		{
+			name:  "javascript href",
+			input: `<a href="javascript:alert(1)">klik</a>`,
+			want:  `<a rel="nofollow noopener noreferrer">klik</a>`,
+		},
+		{
+			name:  "javascript href with uppercase and spaces",
+			input: `<a href="  JavaScript:alert(1)">klik</a>`,
+			want:  `<a rel="nofollow noopener noreferrer">klik</a>`,
+		},
+		{
+			name:  "javascript href split by tab",
+			input: "<a href=\"java\tscript:alert(1)\">klik</a>",
+			want:  `<a rel="nofollow noopener noreferrer">klik</a>`,
+		},
+		{
+			name:  "javascript href split by encoded tab",
+			input: `<a href="java&#x09;script:alert(1)">klik</a>`,
+			want:  `<a rel="nofollow noopener noreferrer">klik</a>`,
+		},
+		{
+			name:  "javascript href with decimal entity",
+			input: `<a href="&#106;avascript:alert(1)">klik</a>`,
+			want:  `<a rel="nofollow noopener noreferrer">klik</a>`,
+		},
+		{
+			name:  "javascript href with hex entity and no semicolon",
+			input: `<a href="&#x6A;avascript&colon;alert(1)">klik</a>`,
+			want:  `<a rel="nofollow noopener noreferrer">klik</a>`,
+		},
+		{
+			name:  "vbscript href",
+			input: `<a href='vbscript:msgbox(1)'>klik</a>`,
+			want:  `<a rel="nofollow noopener noreferrer">klik</a>`,
+		},
+		{
+			name:  "safe hrefs kept",
+			input: `<a href="https://desa.id/a?b=1&amp;c=2">a</a><a href="/berita/x">b</a><a href="mailto:desa@desa.id">c</a>`,
+			want:  `<a href="https://desa.id/a?b=1&amp;c=2" rel="nofollow noopener noreferrer">a</a><a href="/berita/x" rel="nofollow noopener noreferrer">b</a><a href="mailto:desa@desa.id" rel="nofollow noopener noreferrer">c</a>`,
+		},
+		{
+			name:  "data img src",
+			input: `<img src="data:image/svg+xml;base64,PHN2Zz4=" alt="x">`,
+			want:  `<img alt="x">`,
+		},
+		{
+			name:  "mailto img src",
+			input: `<img src="mailto:desa@desa.id">`,
+			want:  `<img>`,
+		},
+		{
+			name:  "event handler attributes",
+			input: `<img src=x onerror=alert(1)><p onclick="alert(1)" style="color:red">hi</p>`,
+			want:  `<img src="x"><p>hi</p>`,
+		},
+		{
+			name:  "event handler after slash",
+			input: `<img/src="x"/onerror="alert(1)">`,
+			want:  `<img src="x">`,
+		},
+		{
+			name:  "attribute value escaped",
+			input: `<a title='"><script>alert(1)</script>'>x</a>`,
+			want:  `<a title="&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;" rel="nofollow noopener noreferrer">x</a>`,
+		},
+		{
+			name:  "script with content dropped",
+			input: "a<script>alert(1)</script>b",
+			want:  "ab",
+		},
+		{
+			name:  "uppercase script dropped",
+			input: "a<SCRIPT type=\"text/javascript\">alert(1)</ScRiPt >b",
+			want:  "ab",
+		},
+		{
+			name:  "unterminated script",
+			input: "<p>a<script>alert(1)",
+			want:  "<p>a</p>",
+		},
+		{
+			name:  "unterminated script tag",
+			input: "a<script",
+			want:  "a&lt;script",
+		},
+		{
+			name:  "nested script in tag name",
+			input: "<scr<script>ipt>alert(1)</script>",
+			want:  "ipt&gt;alert(1)",
+		},
+		{
+			name:  "script nested in script",
+			input: "<script><script>alert(1)</script>alert(2)</script>",
+			want:  "alert(2)",
+		},
+		{
+			name:  "malformed tag treated as text",
+			input: "1 < 2 <> 3 </ 4",
+			want:  "1 &lt; 2 &lt;&gt; 3 &lt;/ 4",
+		},
+		{
+			name:  "unterminated attribute quote",
+			input: `<a href="javascript:alert(1)>x`,
+			want:  `&lt;a href=&#34;javascript:alert(1)&gt;x`,
+		},
+		{
+			name:  "iframe, style and svg dropped",
+			input: `<iframe src="https://evil"></iframe><style>p{}</style><svg><script>alert(1)</script></svg>ok`,
+			want:  "ok",
+		},
+		{
+			name:  "encoded tags in text stay escaped",
+			input: "&lt;script&gt;alert(1)&lt;/script&gt;",
+			want:  "&lt;script&gt;alert(1)&lt;/script&gt;",
+		},
+		{
+			name:  "word markup",
+			input: `<p class="MsoNormal"><span style="mso-fareast-font-family:Calibri">Rapat<o:p></o:p></span></p><!--[if gte mso 9]><xml><w:WordDocument></w:WordDocument></xml><![endif]-->`,
+			want:  "<p>Rapat</p>",
+		},
+		{
+			name:  "word conditional comment",
+			input: "<!--[if !supportLists]-->1.<!--[endif]-->Satu",
+			want:  "1.Satu",
+		},
+		{
+			name:  "unterminated comment",
+			input: "<p>a<!-- <script>alert(1)</script>",
+			want:  "<p>a</p>",
+		},
+		{
+			name:  "doctype and processing instruction dropped",
+			input: `<!DOCTYPE html><?xml version="1.0"?>teks`,
+			want:  "teks",
+		},
+		{
+			name:  "unclosed tags closed",
+			input: "<p><strong>tebal <em>miring",
+			want:  "<p><strong>tebal <em>miring</em></strong></p>",
+		},
+		{
+			name:  "misnested tags closed",
+			input: "<p><strong>a<em>b</strong>c</em></p>",
+			want:  "<p><strong>a<em>b</em></strong>c</p>",
+		},
+		{
+			name:  "stray closing tag ignored",
+			input: "a</p></strong>b",
+			want:  "ab",
+		},
+		{
+			name:  "void tags not closed",
+			input: "a<br>b<hr/>c",
+			want:  "a<br>b<hr>c",
+		},
+		{
+			name:  "numeric attributes validated",
+			input: `<ol start="3"><li>a</li></ol><ol start="x"></ol><table><tr><td colspan="99999">b</td></tr></table>`,
+			want:  `<ol start="3"><li>a</li></ol><ol></ol><table><tr><td>b</td></tr></table>`,
+		},
+		{
+			name:  "code class limited to language",
+			input: `<code class="language-go">x</code><code class="evil">y</code>`,
+			want:  `<code class="language-go">x</code><code>y</code>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeHTML(tt.input); got != tt.want {
+				t.Errorf("SanitizeHTML(%q)\n got  %q\n want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "paragraph with emphasis",
+			input: "Halo **warga** _desa_",
+			want:  "<p>Halo <strong>warga</strong> <em>desa</em></p>\n",
+		},
+		{
+			name:  "plain text line breaks",
+			input: "baris satu\nbaris dua",
+			want:  "<p>baris satu<br>\nbaris dua</p>\n",
+		},
+		{
+			name:  "heading and list",
+			input: "## Agenda\n\n- satu\n- dua",
+			want:  "<h2>Agenda</h2>\n<ul>\n<li>satu</li>\n<li>dua</li>\n</ul>\n",
+		},
+		{
+			name:  "safe link",
+			input: "[situs](https://desa.id)",
+			want:  "<p><a href=\"https://desa.id\" rel=\"nofollow noopener noreferrer\">situs</a></p>\n",
+		},
+		{
+			name:  "link with balanced parentheses",
+			input: "[desa](https://id.wikipedia.org/wiki/Desa_(Indonesia))",
+			want:  "<p><a href=\"https://id.wikipedia.org/wiki/Desa_(Indonesia)\" rel=\"nofollow noopener noreferrer\">desa</a></p>\n",
+		},
+		{
+			name:  "javascript link",
+			input: "[klik](javascript:alert(1))",
+			want:  "<p><a rel=\"nofollow noopener noreferrer\">klik</a></p>\n",
+		},
+		{
+			name:  "encoded javascript link",
+			input: "[klik](&#106;avascript:alert(1))",
+			want:  "<p><a rel=\"nofollow noopener noreferrer\">klik</a></p>\n",
+		},
+		{
+			name:  "data image",
+			input: "![x](data:image/png;base64,AAAA)",
+			want:  "<p><img alt=\"x\"></p>\n",
+		},
+		{
+			name:  "inline html sanitized",
+			input: `teks <img src=x onerror=alert(1)> <script>alert(1)</script>`,
+			want:  "<p>teks <img src=\"x\"> </p>\n",
+		},
+		{
+			name:  "html block sanitized",
+			input: `<p onclick="alert(1)">a</p>`,
+			want:  "<p>a</p>\n",
+		},
+		{
+			name:  "code span escaped",
+			input: "`<script>`",
+			want:  "<p><code>&lt;script&gt;</code></p>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderMarkdown(tt.input); got != tt.want {
+				t.Errorf("RenderMarkdown(%q)\n got  %q\n want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"<p>Halo <strong>warga</strong></p><p>desa</p>", "Halo warga desa"},
+		{"a &amp; b &lt;c&gt;", "a & b <c>"},
+		{"1 < 2", "1 < 2"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := HTMLToText(tt.input); got != tt.want {
+			t.Errorf("HTMLToText(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		text   string
+		length int
+		want   string
+	}{
+		{"pendek", 10, "pendek"},
+		{"rapat warga desa, sukamaju", 18, "rapat warga desa…"},
+		{"kata", 0, "kata"},
+		{"ééééé ééééé", 7, "ééééé…"},
+	}
+
+	for _, tt := range tests {
+		if got := Excerpt(tt.text, tt.length); got != tt.want {
+			t.Errorf("Excerpt(%q, %d) = %q, want %q", tt.text, tt.length, got, tt.want)
+		}
+	}
+}
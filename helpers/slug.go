@@ -0,0 +1,50 @@
+package helpers
+
+import (
+	"strings"
+	"unicode"
+)
+
+// slugMaxLength adalah panjang maksimal slug sebelum ditambah akhiran angka untuk menghindari bentrok
+const slugMaxLength = 80
+
+// slugTransliterations mengganti huruf beraksen dan simbol umum dengan padanan ASCII-nya
+var slugTransliterations = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'æ': "ae",
+	'ç': "c", 'è': "e", 'é': "e", 'ê': "e", 'ë': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ñ': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'œ': "oe",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ý': "y", 'ÿ': "y", 'ß': "ss",
+	'&': " dan ", '@': " at ", '%': " persen ", '+': " plus ",
+}
+
+// Slugify membuat slug URL dari judul, misalnya "Gotong Royong & Kerja Bakti RT 03"
+// menjadi "gotong-royong-dan-kerja-bakti-rt-03". Mengembalikan string kosong jika judul
+// tidak mengandung huruf atau angka sama sekali.
+func Slugify(title string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(title) {
+		if replacement, ok := slugTransliterations[r]; ok {
+			builder.WriteString(replacement)
+			continue
+		}
+
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			builder.WriteRune(r)
+		} else {
+			builder.WriteByte(' ')
+		}
+	}
+
+	slug := strings.Join(strings.Fields(builder.String()), "-")
+
+	// Potong pada batas kata agar slug tidak berakhir dengan potongan kata
+	if len(slug) > slugMaxLength {
+		slug = slug[:slugMaxLength]
+		if i := strings.LastIndexByte(slug, '-'); i > 0 {
+			slug = slug[:i]
+		}
+	}
+
+	return slug
+}
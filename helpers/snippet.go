@@ -0,0 +1,80 @@
+package helpers
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// HighlightSnippet memotong teks di sekitar kata kunci pertama yang ditemukan lalu menandai
+// setiap kata kunci dengan tag <mark>. Teks di-escape terlebih dahulu sehingga aman ditampilkan
+// sebagai HTML. radius adalah jumlah byte maksimal yang diambil di kiri dan kanan kata kunci.
+func HighlightSnippet(text, query string, radius int) string {
+	var terms []string
+	for _, term := range strings.Fields(query) {
+		terms = append(terms, regexp.QuoteMeta(term))
+	}
+
+	if len(terms) == 0 {
+		return html.EscapeString(truncate(text, 2*radius))
+	}
+
+	pattern := regexp.MustCompile("(?i)" + strings.Join(terms, "|"))
+
+	loc := pattern.FindStringIndex(text)
+	if loc == nil {
+		return html.EscapeString(truncate(text, 2*radius))
+	}
+
+	start := loc[0] - radius
+	if start < 0 {
+		start = 0
+	}
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+
+	end := loc[1] + radius
+	if end > len(text) {
+		end = len(text)
+	}
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+
+	snippet := text[start:end]
+
+	var builder strings.Builder
+	if start > 0 {
+		builder.WriteString("…")
+	}
+
+	last := 0
+	for _, match := range pattern.FindAllStringIndex(snippet, -1) {
+		builder.WriteString(html.EscapeString(snippet[last:match[0]]))
+		builder.WriteString("<mark>")
+		builder.WriteString(html.EscapeString(snippet[match[0]:match[1]]))
+		builder.WriteString("</mark>")
+		last = match[1]
+	}
+	builder.WriteString(html.EscapeString(snippet[last:]))
+
+	if end < len(text) {
+		builder.WriteString("…")
+	}
+
+	return builder.String()
+}
+
+func truncate(text string, length int) string {
+	if len(text) <= length {
+		return text
+	}
+
+	for length > 0 && !utf8.RuneStart(text[length]) {
+		length--
+	}
+
+	return text[:length] + "…"
+}
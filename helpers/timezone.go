@@ -0,0 +1,79 @@
+package helpers
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	// Data zona waktu ikut di-embed agar Asia/Jakarta tetap tersedia di server tanpa tzdata
+	_ "time/tzdata"
+)
+
+// DefaultTimezone dipakai jika APP_TIMEZONE tidak diisi
+const DefaultTimezone = "Asia/Jakarta"
+
+var (
+	locationOnce sync.Once
+	location     *time.Location
+	locationErr  error
+)
+
+// LoadLocation memuat zona waktu server dari env APP_TIMEZONE. Dipanggil saat startup agar
+// konfigurasi yang salah langsung ketahuan.
+func LoadLocation() (*time.Location, error) {
+	locationOnce.Do(func() {
+		name := os.Getenv("APP_TIMEZONE")
+		if name == "" {
+			name = DefaultTimezone
+		}
+
+		location, locationErr = time.LoadLocation(name)
+		if locationErr != nil {
+			locationErr = fmt.Errorf("APP_TIMEZONE %q tidak valid: %v", name, locationErr)
+		}
+	})
+
+	return location, locationErr
+}
+
+// Location mengembalikan zona waktu server, atau UTC jika APP_TIMEZONE tidak valid
+func Location() *time.Location {
+	loc, err := LoadLocation()
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// localTimeLayouts adalah format waktu tanpa offset yang ditafsirkan sesuai zona waktu server
+var localTimeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+}
+
+// ParseLocalTime membaca waktu berformat RFC3339, atau "YYYY-MM-DD HH:MM[:SS]" yang
+// ditafsirkan sesuai zona waktu server
+func ParseLocalTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	for _, layout := range localTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("format waktu %q tidak valid, gunakan YYYY-MM-DD HH:MM", value)
+}
+
+// FormatLocalTime memformat waktu sebagai RFC3339 pada zona waktu server, string kosong jika nil
+func FormatLocalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.In(Location()).Format(time.RFC3339)
+}
@@ -0,0 +1,99 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Key adalah secret SHA1 dari RFC 6238 Appendix B
+var rfc6238Key = []byte("12345678901234567890")
+
+func TestGenerateTOTPRFC6238(t *testing.T) {
+	// RFC 6238 memakai 8 digit, kode 6 digit adalah 6 digit terakhirnya
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+
+	secret := totpEncoding.EncodeToString(rfc6238Key)
+	for _, tt := range tests {
+		if got := generateTOTP(rfc6238Key, uint64(tt.unix/totpPeriod)); got != tt.want {
+			t.Errorf("generateTOTP(T=%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+
+		step, ok := ValidateTOTP(secret, tt.want, time.Unix(tt.unix, 0))
+		if !ok || step != tt.unix/totpPeriod {
+			t.Errorf("ValidateTOTP(T=%d) = %d, %v, want %d, true", tt.unix, step, ok, tt.unix/totpPeriod)
+		}
+	}
+}
+
+func TestValidateTOTPSkew(t *testing.T) {
+	secret := totpEncoding.EncodeToString(rfc6238Key)
+	const step = 37037037
+	code := generateTOTP(rfc6238Key, step)
+	start := time.Unix(step*totpPeriod, 0)
+
+	tests := []struct {
+		name   string
+		offset time.Duration
+		want   bool
+	}{
+		{name: "same step", offset: 0, want: true},
+		{name: "end of same step", offset: 29 * time.Second, want: true},
+		{name: "one step later", offset: 30 * time.Second, want: true},
+		{name: "one step earlier", offset: -30 * time.Second, want: true},
+		{name: "two steps later", offset: 60 * time.Second, want: false},
+		{name: "two steps earlier", offset: -31 * time.Second, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ValidateTOTP(secret, code, start.Add(tt.offset))
+			if ok != tt.want {
+				t.Fatalf("ValidateTOTP() ok = %v, want %v", ok, tt.want)
+			}
+			// Step yang dikembalikan adalah step kode, bukan step waktu validasi
+			if ok && got != step {
+				t.Errorf("ValidateTOTP() step = %d, want %d", got, step)
+			}
+		})
+	}
+}
+
+func TestValidateTOTPInvalidInput(t *testing.T) {
+	secret := totpEncoding.EncodeToString(rfc6238Key)
+	now := time.Unix(59, 0)
+
+	tests := []struct {
+		name   string
+		secret string
+		code   string
+	}{
+		{name: "wrong code", secret: secret, code: "000000"},
+		{name: "too short", secret: secret, code: "28708"},
+		{name: "eight digits", secret: secret, code: "94287082"},
+		{name: "invalid secret", secret: "bukan-base32!", code: "287082"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := ValidateTOTP(tt.secret, tt.code, now); ok {
+				t.Error("ValidateTOTP() = true, want false")
+			}
+		})
+	}
+
+	// Secret huruf kecil dengan spasi di tepi tetap diterima
+	if _, ok := ValidateTOTP(" "+strings.ToLower(secret)+" ", "287082", now); !ok {
+		t.Error("ValidateTOTP() menolak secret huruf kecil")
+	}
+}
@@ -0,0 +1,63 @@
+package helpers
+
+import (
+	"net/http"
+	"os"
+	"strings"
+)
+
+// RequestBaseURL mengembalikan alamat api (scheme dan host) yang dipakai client. Header
+// X-Forwarded-Proto dan X-Forwarded-Host hanya dipercaya jika TRUST_PROXY_HEADERS=true.
+func RequestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	host := r.Host
+
+	if os.Getenv("TRUST_PROXY_HEADERS") == "true" {
+		if proto := r.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+			scheme = proto
+		}
+		if forwardedHost := r.Header.Get("X-Forwarded-Host"); forwardedHost != "" {
+			host = strings.TrimSpace(strings.Split(forwardedHost, ",")[0])
+		}
+	}
+
+	return scheme + "://" + host
+}
+
+// AbsoluteURL menambahkan baseURL pada URL relatif seperti "/uploads/berita/x.jpg"
+func AbsoluteURL(baseURL, u string) string {
+	if strings.HasPrefix(u, "/") && !strings.HasPrefix(u, "//") {
+		return strings.TrimRight(baseURL, "/") + u
+	}
+	return u
+}
+
+// APIURL mengembalikan alamat publik api dari env API_URL, atau baseURL dari request jika tidak diisi.
+// Dipakai untuk URL yang dilayani api sendiri seperti sitemap, karena website desa (SITE_URL)
+// dapat berada di host yang berbeda.
+func APIURL(baseURL string) string {
+	if apiURL := os.Getenv("API_URL"); apiURL != "" {
+		return strings.TrimRight(apiURL, "/")
+	}
+	return strings.TrimRight(baseURL, "/")
+}
+
+// SiteURL mengembalikan alamat website desa dari env SITE_URL, atau alamat api jika tidak diisi
+func SiteURL(baseURL string) string {
+	if siteURL := os.Getenv("SITE_URL"); siteURL != "" {
+		return strings.TrimRight(siteURL, "/")
+	}
+	return APIURL(baseURL)
+}
+
+// BeritaURL mengembalikan permalink berita. Jika SITE_URL diisi, link mengarah ke halaman
+// berita di website desa, selain itu ke endpoint detail berita di api yang juga menerima slug.
+func BeritaURL(baseURL, slug string) string {
+	if siteURL := os.Getenv("SITE_URL"); siteURL != "" {
+		return strings.TrimRight(siteURL, "/") + "/berita/" + slug
+	}
+	return APIURL(baseURL) + "/api/v1/berita/" + slug
+}
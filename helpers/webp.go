@@ -0,0 +1,86 @@
+package helpers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+)
+
+// ErrWebPUnavailable dikembalikan jika encoder cwebp tidak terpasang di server
+var ErrWebPUnavailable = errors.New("encoder webp tidak tersedia")
+
+// Library standar Go hanya dapat membaca WebP, sehingga encode dilakukan lewat binary cwebp
+// dari libwebp. Lokasinya dapat diatur lewat env CWEBP_PATH, bawaannya dicari di PATH.
+func webpEncoderPath() (string, error) {
+	if path := os.Getenv("CWEBP_PATH"); path != "" {
+		return path, nil
+	}
+
+	path, err := exec.LookPath("cwebp")
+	if err != nil {
+		return "", ErrWebPUnavailable
+	}
+
+	return path, nil
+}
+
+// CheckWebPEncoder memeriksa apakah encoder cwebp dapat dijalankan. Tanpa encoder, varian
+// WebP dilewati saat upload, sehingga kondisi ini sebaiknya diperiksa saat server dijalankan.
+func CheckWebPEncoder() error {
+	encoder, err := webpEncoderPath()
+	if err != nil {
+		return err
+	}
+
+	if _, err := exec.LookPath(encoder); err != nil {
+		return fmt.Errorf("CWEBP_PATH %s tidak dapat dijalankan: %v", encoder, err)
+	}
+
+	return nil
+}
+
+// EncodeWebP meng-encode gambar menjadi WebP lossy dengan kualitas 0-100
+func EncodeWebP(img image.Image, quality int) ([]byte, error) {
+	encoder, err := webpEncoderPath()
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "webp-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "input.png")
+	output := filepath.Join(dir, "output.webp")
+
+	file, err := os.Create(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := png.Encode(file, img); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(encoder, "-quiet", "-metadata", "none", "-q", strconv.Itoa(quality), input, "-o", output)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("cwebp gagal: %v %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	return os.ReadFile(output)
+}
@@ -0,0 +1,74 @@
+package helpers
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestCheckWebPEncoderMissing(t *testing.T) {
+	t.Setenv("CWEBP_PATH", "")
+	t.Setenv("PATH", t.TempDir())
+
+	if err := CheckWebPEncoder(); !errors.Is(err, ErrWebPUnavailable) {
+		t.Errorf("CheckWebPEncoder() = %v, want ErrWebPUnavailable", err)
+	}
+
+	if _, err := EncodeWebP(image.NewRGBA(image.Rect(0, 0, 1, 1)), 80); !errors.Is(err, ErrWebPUnavailable) {
+		t.Errorf("EncodeWebP() error = %v, want ErrWebPUnavailable", err)
+	}
+}
+
+func TestCheckWebPEncoderInvalidPath(t *testing.T) {
+	t.Setenv("CWEBP_PATH", filepath.Join(t.TempDir(), "cwebp"))
+
+	if err := CheckWebPEncoder(); err == nil {
+		t.Error("CheckWebPEncoder() = nil, want error for missing CWEBP_PATH")
+	}
+}
+
+func TestEncodeWebPUsesConfiguredEncoder(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("encoder palsu memakai shell script")
+	}
+
+	// Encoder palsu hanya menulis "RIFF" ke path setelah -o, cukup untuk memastikan CWEBP_PATH dipakai
+	encoder := filepath.Join(t.TempDir(), "cwebp")
+	script := "#!/bin/sh\nout=\nwhile [ $# -gt 0 ]; do\n  if [ \"$1\" = \"-o\" ]; then out=$2; fi\n  shift\ndone\nprintf 'RIFF' > \"$out\"\n"
+	if err := os.WriteFile(encoder, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CWEBP_PATH", encoder)
+
+	if err := CheckWebPEncoder(); err != nil {
+		t.Fatalf("CheckWebPEncoder() = %v", err)
+	}
+
+	got, err := EncodeWebP(image.NewRGBA(image.Rect(0, 0, 2, 2)), 80)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "RIFF" {
+		t.Errorf("EncodeWebP() = %q, want output written by encoder", got)
+	}
+}
+
+func TestEncodeWebPWithCwebp(t *testing.T) {
+	if _, err := exec.LookPath("cwebp"); err != nil {
+		t.Skip("cwebp tidak terpasang")
+	}
+	t.Setenv("CWEBP_PATH", "")
+
+	got, err := EncodeWebP(image.NewRGBA(image.Rect(0, 0, 16, 16)), 80)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) < 12 || !bytes.Equal(got[:4], []byte("RIFF")) || !bytes.Equal(got[8:12], []byte("WEBP")) {
+		t.Errorf("EncodeWebP() tidak menghasilkan file WebP")
+	}
+}
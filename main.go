@@ -1,14 +1,21 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/julienschmidt/httprouter"
 	"github.com/syrlramadhan/desa-sukamaju-api/config"
 	"github.com/syrlramadhan/desa-sukamaju-api/controllers"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/middleware"
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
 	"github.com/syrlramadhan/desa-sukamaju-api/repositories"
 	"github.com/syrlramadhan/desa-sukamaju-api/services"
 )
@@ -20,79 +27,273 @@ func main() {
 		return
 	}
 
-	port := os.Getenv("APP_PORT")
-	fmt.Println("api berjalan di port:" + port)
+	command := "serve"
+	var args []string
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+		args = os.Args[2:]
+	}
+
+	commands := map[string]func(db *sql.DB, args []string) error{
+		"serve":         runServe,
+		"migrate":       runMigrate,
+		"admin":         runAdmin,
+		"seed":          runSeed,
+		"check-uploads": runCheckUploads,
+	}
+
+	run, ok := commands[command]
+	if !ok {
+		printUsage()
+		os.Exit(2)
+	}
 
 	db, err := config.ConnectToDatabase()
 	if err != nil {
 		fmt.Println("error saat koneksi ke database:", err)
-		return
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	if err := run(db, args); err != nil {
+		fmt.Printf("error saat menjalankan %s: %v\n", command, err)
+		db.Close()
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	fmt.Println(`penggunaan: desa-sukamaju-api <command> [argumen]
+
+command:
+  serve                              menjalankan server api (bawaan)
+  migrate up | down [jumlah] | status | baseline <versi> | force <versi> up|down
+                                     mengelola migrasi skema database
+  admin create -username <u> [-role <role>]
+                                     membuat akun admin baru
+  admin reset-password -username <u> [-disable-2fa]
+                                     mengganti password admin yang lupa
+  seed [-id-kontak <id>]             membuat baris kontak dan penduduk awal
+  check-uploads [-quarantine] [-min-age <durasi>]
+                                     memeriksa file upload yang hilang atau tidak terpakai`)
+}
+
+// runServe menjalankan server http dengan seluruh route api
+func runServe(db *sql.DB, _ []string) error {
+	fileStorage, err := config.NewStorage()
+	if err != nil {
+		return err
+	}
+
+	// Jadwal terbit berita ditafsirkan sesuai zona waktu server (APP_TIMEZONE, bawaan Asia/Jakarta)
+	if _, err := helpers.LoadLocation(); err != nil {
+		return err
+	}
+
+	// Upload tetap berjalan tanpa cwebp, tetapi varian WebP tidak dibuat sama sekali
+	if err := helpers.CheckWebPEncoder(); err != nil {
+		fmt.Printf("peringatan: %v, varian WebP tidak akan dibuat. Pasang cwebp (libwebp) atau atur CWEBP_PATH\n", err)
 	}
 
 	router := httprouter.New()
 
+	auditRepo := repositories.NewAuditRepository()
+	auditService := services.NewAuditService(auditRepo, db)
+	auditController := controllers.NewAuditController(auditService)
+
 	adminRepo := repositories.NewAdminRepository()
-	adminService := services.NewAdminService(adminRepo, db)
+	sessionRepo := repositories.NewSessionRepository()
+	loginAttemptRepo := repositories.NewLoginAttemptRepository()
+	recoveryCodeRepo := repositories.NewRecoveryCodeRepository()
+	adminService := services.NewAdminService(adminRepo, sessionRepo, loginAttemptRepo, recoveryCodeRepo, auditRepo, db)
 	adminController := controllers.NewAdminController(adminService)
+	authMiddleware := middleware.NewAuthMiddleware(adminService)
 
 	router.POST("/api/v1/admin/login", adminController.LoginAdmin)
-	router.PUT("/api/v1/admin/:username/username", adminController.UpdateUsernameAdmin)
-	router.PUT("/api/v1/admin/:username/password", adminController.UpdatePasswordAdmin)
-	router.GET("/api/v1/admin/:id_admin", adminController.GetAdminById)
+	router.POST("/api/v1/admin/login/2fa", adminController.LoginTwoFactor)
+	router.POST("/api/v1/admin/refresh", adminController.RefreshToken)
+	router.POST("/api/v1/admin/logout", authMiddleware.Authenticate(adminController.Logout))
+	router.POST("/api/v1/admin/logout-all", authMiddleware.Authenticate(adminController.LogoutAll))
+	router.POST("/api/v1/admin/2fa/setup", authMiddleware.Authenticate(adminController.SetupTwoFactor))
+	router.POST("/api/v1/admin/2fa/enable", authMiddleware.Authenticate(adminController.EnableTwoFactor))
+	router.POST("/api/v1/admin/2fa/disable", authMiddleware.Authenticate(adminController.DisableTwoFactor))
+	router.PUT("/api/v1/admin/:username/username", authMiddleware.AuthenticateOwner(adminController.UpdateUsernameAdmin))
+	router.PUT("/api/v1/admin/:username/password", authMiddleware.AuthenticateOwner(adminController.UpdatePasswordAdmin))
+	router.GET("/api/v1/admin/:id_admin", authMiddleware.AuthorizeSelf(adminController.GetAdminById, models.RoleSuperadmin))
+	router.GET("/api/v1/admins", authMiddleware.Authorize(adminController.GetAllAdmin, models.RoleSuperadmin))
+	router.GET("/api/v1/admins/login-attempts", authMiddleware.Authorize(adminController.GetFailedLoginAttempts, models.RoleSuperadmin))
+	router.POST("/api/v1/admins", authMiddleware.Authorize(adminController.CreateAdmin, models.RoleSuperadmin))
+	router.PUT("/api/v1/admins/:id_admin", authMiddleware.Authorize(adminController.UpdateAdmin, models.RoleSuperadmin))
+	router.DELETE("/api/v1/admins/:id_admin", authMiddleware.Authorize(adminController.DeleteAdmin, models.RoleSuperadmin))
+
+	router.GET("/api/v1/audit", authMiddleware.Authorize(auditController.GetAllAudit, models.RoleSuperadmin))
 
 	kontenRepo := repositories.NewKontenRepository()
-	kontenService := services.NewKontenService(kontenRepo, db)
+	kontenService := services.NewKontenService(kontenRepo, auditRepo, db)
 	kontenController := controllers.NewKontenController(kontenService)
 
 	router.GET("/api/v1/kontak/:id_kontak", kontenController.GetKontak)
-	router.PUT("/api/v1/kontak/:id_kontak", kontenController.UpdateKontak)
+	router.PUT("/api/v1/kontak/:id_kontak", authMiddleware.Authorize(kontenController.UpdateKontak, models.RoleSuperadmin))
 
 	aparatRepo := repositories.NewAparatRepository()
-	aparatService := services.NewAparatService(aparatRepo, db)
+	aparatService := services.NewAparatService(aparatRepo, auditRepo, fileStorage, db)
 	aparatController := controllers.NewAparatController(aparatService)
 
-	router.POST("/api/v1/aparat", aparatController.CreateAparat)
+	router.POST("/api/v1/aparat", authMiddleware.Authorize(aparatController.CreateAparat, models.RoleSuperadmin))
 	router.GET("/api/v1/aparat", aparatController.GetAllAparat)
 	router.GET("/api/v1/aparat/:id_aparat", aparatController.GetAparatById)
-	router.PUT("/api/v1/aparat/:id_aparat", aparatController.UpdateAparat)
-	router.DELETE("/api/v1/aparat/:id_aparat", aparatController.DeleteAparat)
-	router.DELETE("/api/v1/bulk/aparat", aparatController.BulkDeleteAparat)
+	router.PUT("/api/v1/aparat/:id_aparat", authMiddleware.Authorize(aparatController.UpdateAparat, models.RoleSuperadmin))
+	router.DELETE("/api/v1/aparat/:id_aparat", authMiddleware.Authorize(aparatController.DeleteAparat, models.RoleSuperadmin))
+	router.DELETE("/api/v1/bulk/aparat", authMiddleware.Authorize(aparatController.BulkDeleteAparat, models.RoleSuperadmin))
+
+	kategoriRepo := repositories.NewKategoriRepository()
+	tagRepo := repositories.NewTagRepository()
+	kategoriService := services.NewKategoriService(kategoriRepo, tagRepo, auditRepo, db)
+	kategoriController := controllers.NewKategoriController(kategoriService)
+
+	// Kategori hasil migrasi dari kategori teks bebas diberi slug saat server dijalankan
+	if _, err := kategoriService.GenerateMissingSlugs(context.Background()); err != nil {
+		return err
+	}
+
+	router.GET("/api/v1/kategori", kategoriController.GetAllKategori)
+	router.POST("/api/v1/kategori", authMiddleware.Authorize(kategoriController.CreateKategori, models.RoleSuperadmin))
+	router.PUT("/api/v1/kategori/:id_kategori", authMiddleware.Authorize(kategoriController.UpdateKategori, models.RoleSuperadmin))
+	router.DELETE("/api/v1/kategori/:id_kategori", authMiddleware.Authorize(kategoriController.DeleteKategori, models.RoleSuperadmin))
+	router.GET("/api/v1/tag", kategoriController.GetAllTag)
 
 	beritaRepo := repositories.NewBeritaRepository()
-	beritaService := services.NewBeritaService(beritaRepo, db)
+	beritaRevisiRepo := repositories.NewBeritaRevisiRepository()
+	galeriRepo := repositories.NewGaleriRepository()
+	beritaService := services.NewBeritaService(beritaRepo, beritaRevisiRepo, kategoriRepo, tagRepo, galeriRepo, auditRepo, fileStorage, db)
 	beritaController := controllers.NewBeritaController(beritaService)
+	beritaRoles := []string{models.RoleSuperadmin, models.RoleEditorBerita}
 
-	router.POST("/api/v1/berita", beritaController.CreateBerita)
+	// Berita lama yang dibuat sebelum ada slug diberi slug saat server dijalankan
+	if _, err := beritaService.GenerateMissingSlugs(context.Background()); err != nil {
+		return err
+	}
+
+	// Deskripsi berita lama dirender ke HTML dan teks pencarian sekali saja, berita baru dirender saat disimpan
+	if _, err := beritaService.RenderMissingDeskripsiHTML(context.Background()); err != nil {
+		return err
+	}
+
+	// Scheduler menerbitkan berita terjadwal dan mengarsipkan berita kedaluwarsa selama server berjalan
+	go services.NewBeritaScheduler(beritaRepo, auditRepo, db).Run(context.Background())
+
+	router.POST("/api/v1/berita", authMiddleware.Authorize(beritaController.CreateBerita, beritaRoles...))
 	router.GET("/api/v1/berita", beritaController.GetAllBerita)
+	// /api/v1/berita/:id_berita juga menerima slug. httprouter tidak mengizinkan
+	// /api/v1/berita/slug/:slug berdampingan dengan wildcard :id_berita, sehingga
+	// /api/v1/slug/berita/:slug tetap dipertahankan untuk client lama.
 	router.GET("/api/v1/berita/:id_berita", beritaController.GetBeritaById)
-	router.PUT("/api/v1/berita/:id_berita", beritaController.UpdateBerita)
-	router.DELETE("/api/v1/berita/:id_berita", beritaController.DeleteBerita)
-	router.POST("/api/v1/photo/berita", beritaController.CreatePhoto)
-	router.DELETE("/api/v1/photo/berita/:filename", beritaController.DeletePhotoByFilename)
-	router.DELETE("/api/v1/bulk/photo/berita", beritaController.BulkDeletePhoto)
+	router.GET("/api/v1/slug/berita/:slug", beritaController.GetBeritaBySlug)
+	router.PUT("/api/v1/berita/:id_berita", authMiddleware.Authorize(beritaController.UpdateBerita, beritaRoles...))
+	router.PUT("/api/v1/berita/:id_berita/status", authMiddleware.Authorize(beritaController.UpdateStatusBerita, beritaRoles...))
+	router.GET("/api/v1/berita/:id_berita/revisi", authMiddleware.Authorize(beritaController.GetBeritaRevisi, beritaRoles...))
+	router.GET("/api/v1/berita/:id_berita/revisi/diff", authMiddleware.Authorize(beritaController.GetBeritaRevisiDiff, beritaRoles...))
+	router.POST("/api/v1/berita/:id_berita/revisi/:versi/restore", authMiddleware.Authorize(beritaController.RestoreBeritaRevisi, beritaRoles...))
+	router.PUT("/api/v1/berita/:id_berita/galeri/urutan", authMiddleware.Authorize(beritaController.UpdateUrutanPhotoBerita, beritaRoles...))
+	router.PUT("/api/v1/berita/:id_berita/galeri/cover", authMiddleware.Authorize(beritaController.SetCoverPhotoBerita, beritaRoles...))
+	router.DELETE("/api/v1/berita/:id_berita", authMiddleware.Authorize(beritaController.DeleteBerita, beritaRoles...))
+	router.GET("/api/v1/review/berita", authMiddleware.Authorize(beritaController.GetReviewBerita, beritaRoles...))
+	router.GET("/api/v1/review/berita/:id_berita", authMiddleware.Authorize(beritaController.GetReviewBeritaById, beritaRoles...))
+	router.POST("/api/v1/photo/berita", authMiddleware.Authorize(beritaController.CreatePhoto, beritaRoles...))
+	router.PUT("/api/v1/photo/berita/:filename", authMiddleware.Authorize(beritaController.UpdatePhotoBerita, beritaRoles...))
+	router.DELETE("/api/v1/photo/berita/:filename", authMiddleware.Authorize(beritaController.DeletePhotoByFilename, beritaRoles...))
+	router.DELETE("/api/v1/bulk/photo/berita", authMiddleware.Authorize(beritaController.BulkDeletePhoto, beritaRoles...))
+
+	albumRepo := repositories.NewAlbumRepository()
+	albumService := services.NewAlbumService(albumRepo, galeriRepo, auditRepo, fileStorage, db)
+	albumController := controllers.NewAlbumController(albumService)
+
+	router.GET("/api/v1/album", albumController.GetAllAlbum)
+	router.GET("/api/v1/album/:id_album", albumController.GetAlbumById)
+	router.POST("/api/v1/album", authMiddleware.Authorize(albumController.CreateAlbum, beritaRoles...))
+	router.PUT("/api/v1/album/:id_album", authMiddleware.Authorize(albumController.UpdateAlbum, beritaRoles...))
+	router.DELETE("/api/v1/album/:id_album", authMiddleware.Authorize(albumController.DeleteAlbum, beritaRoles...))
+	router.POST("/api/v1/album/:id_album/photo", authMiddleware.Authorize(albumController.CreatePhotoAlbum, beritaRoles...))
+	router.PUT("/api/v1/album/:id_album/photo/urutan", authMiddleware.Authorize(albumController.UpdateUrutanPhotoAlbum, beritaRoles...))
+	router.PUT("/api/v1/album/:id_album/photo/cover", authMiddleware.Authorize(albumController.SetCoverPhotoAlbum, beritaRoles...))
+	router.PUT("/api/v1/photo/album/:filename", authMiddleware.Authorize(albumController.UpdatePhotoAlbum, beritaRoles...))
+	router.DELETE("/api/v1/photo/album/:filename", authMiddleware.Authorize(albumController.DeletePhotoAlbumByFilename, beritaRoles...))
+
+	trashService := services.NewTrashService(beritaRepo, aparatRepo, albumRepo, galeriRepo, auditRepo, fileStorage, db)
+	trashController := controllers.NewTrashController(trashService)
+
+	router.GET("/api/v1/trash/berita", authMiddleware.Authorize(trashController.GetTrashBerita, beritaRoles...))
+	router.POST("/api/v1/trash/berita/:id_berita/restore", authMiddleware.Authorize(trashController.RestoreBerita, beritaRoles...))
+	router.GET("/api/v1/trash/aparat", authMiddleware.Authorize(trashController.GetTrashAparat, models.RoleSuperadmin))
+	router.POST("/api/v1/trash/aparat/:id_aparat/restore", authMiddleware.Authorize(trashController.RestoreAparat, models.RoleSuperadmin))
+	router.GET("/api/v1/trash/photo", authMiddleware.Authorize(trashController.GetTrashPhoto, beritaRoles...))
+	router.POST("/api/v1/trash/photo/:filename/restore", authMiddleware.Authorize(trashController.RestorePhoto, beritaRoles...))
+
+	// Data di tempat sampah dihapus permanen setelah TRASH_RETENTION_DAYS hari (default 30)
+	trashRetention := services.DefaultTrashRetention
+	if days := os.Getenv("TRASH_RETENTION_DAYS"); days != "" {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("TRASH_RETENTION_DAYS tidak valid: %q", days)
+		}
+		trashRetention = time.Duration(n) * 24 * time.Hour
+	}
+	go services.NewTrashPurger(beritaRepo, aparatRepo, galeriRepo, auditRepo, fileStorage, trashRetention, db).Run(context.Background())
 
 	pendudukRepo := repositories.NewPendudukRepository()
-	pendudukService := services.NewPendudukService(pendudukRepo, db)
+	pendudukService := services.NewPendudukService(pendudukRepo, auditRepo, db)
 	pendudukController := controllers.NewPendudukController(pendudukService)
 
 	router.GET("/api/v1/penduduk", pendudukController.GetPenduduk)
-	router.PUT("/api/v1/penduduk", pendudukController.UpdatePenduduk)
+	router.PUT("/api/v1/penduduk", authMiddleware.Authorize(pendudukController.UpdatePenduduk, models.RoleSuperadmin, models.RoleOperatorKependudukan))
 
-	// Serve static files from uploads directory
-	router.ServeFiles("/uploads/*filepath", http.Dir("uploads"))
+	searchRepo := repositories.NewSearchRepository()
+	searchService := services.NewSearchService(searchRepo, db)
+	searchController := controllers.NewSearchController(searchService)
+
+	router.GET("/api/v1/search", searchController.Search)
+
+	// Pemeriksaan file upload berkala, aktif jika UPLOAD_CHECK_INTERVAL diisi (misalnya "24h")
+	if interval := os.Getenv("UPLOAD_CHECK_INTERVAL"); interval != "" {
+		uploadCheckInterval, err := time.ParseDuration(interval)
+		if err != nil || uploadCheckInterval <= 0 {
+			return fmt.Errorf("UPLOAD_CHECK_INTERVAL tidak valid: %q", interval)
+		}
+
+		uploadCheckService := services.NewUploadCheckService(aparatRepo, beritaRepo, fileStorage, db)
+		startUploadCheckJob(db, uploadCheckService, uploadCheckInterval, os.Getenv("UPLOAD_CHECK_QUARANTINE") == "true")
+	}
+
+	feedService := services.NewFeedService(beritaRepo, auditRepo, fileStorage, db)
+	feedController := controllers.NewFeedController(feedService)
+
+	router.GET("/feed/berita.rss", feedController.GetBeritaRSS)
+	router.GET("/feed/berita.atom", feedController.GetBeritaAtom)
+
+	sitemapRepo := repositories.NewSitemapRepository()
+	sitemapService := services.NewSitemapService(sitemapRepo, db)
+	sitemapController := controllers.NewSitemapController(sitemapService)
+
+	router.GET("/sitemap.xml", sitemapController.GetSitemap)
+	router.GET("/sitemap/:file", sitemapController.GetSitemapPage)
+	router.GET("/robots.txt", sitemapController.GetRobotsTxt)
+
+	fileController := controllers.NewFileController(fileStorage)
+
+	// Serve file upload dari storage yang dikonfigurasi (local atau s3)
+	router.GET("/uploads/*filepath", fileController.ServeFile)
 
 	handler := CorsMiddleware(router)
 
+	port := os.Getenv("APP_PORT")
+	fmt.Println("api berjalan di port:" + port)
+
 	server := http.Server{
 		Addr:    ":" + port,
 		Handler: handler,
 	}
 
-	errServer := server.ListenAndServe()
-	if errServer != nil {
-		fmt.Printf("error saat memulai server: %v\n", errServer)
-		return
-	}
+	return server.ListenAndServe()
 }
 
 func CorsMiddleware(next http.Handler) http.Handler {
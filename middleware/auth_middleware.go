@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/services"
+)
+
+type AuthMiddleware struct {
+	AdminService services.AdminService
+}
+
+func NewAuthMiddleware(adminService services.AdminService) *AuthMiddleware {
+	return &AuthMiddleware{
+		AdminService: adminService,
+	}
+}
+
+// Authenticate memastikan request membawa header Authorization: Bearer <token> yang valid,
+// sesi token belum dicabut, lalu menyimpan identitas admin ke dalam context request.
+func (m *AuthMiddleware) Authenticate(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		tokenString, ok := bearerToken(r)
+		if !ok {
+			helpers.WriteJSONError(w, http.StatusUnauthorized, "token tidak ditemukan")
+			return
+		}
+
+		claims, err := helpers.ValidateJWT(tokenString)
+		if err != nil || claims.Purpose != "" {
+			helpers.WriteJSONError(w, http.StatusUnauthorized, "token tidak valid atau sudah kedaluwarsa")
+			return
+		}
+
+		code, err := m.AdminService.ValidateSession(r.Context(), claims)
+		if err != nil {
+			helpers.WriteJSONError(w, code, err.Error())
+			return
+		}
+
+		next(w, r.WithContext(helpers.ContextWithClaims(r.Context(), claims)), ps)
+	}
+}
+
+// AuthenticateOwner sama seperti Authenticate, namun juga memastikan username pada path
+// sama dengan username pemilik token.
+func (m *AuthMiddleware) AuthenticateOwner(next httprouter.Handle) httprouter.Handle {
+	return m.Authenticate(func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		claims, _ := helpers.ClaimsFromContext(r.Context())
+		if claims.Username != ps.ByName("username") {
+			helpers.WriteJSONError(w, http.StatusForbidden, "tidak memiliki akses ke admin ini")
+			return
+		}
+
+		next(w, r, ps)
+	})
+}
+
+// Authorize sama seperti Authenticate, namun hanya meneruskan request jika role admin
+// termasuk salah satu role yang diizinkan.
+func (m *AuthMiddleware) Authorize(next httprouter.Handle, roles ...string) httprouter.Handle {
+	return m.Authenticate(func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		claims, _ := helpers.ClaimsFromContext(r.Context())
+		if !slices.Contains(roles, claims.Role) {
+			helpers.WriteJSONError(w, http.StatusForbidden, "role admin tidak memiliki akses ke resource ini")
+			return
+		}
+
+		next(w, r, ps)
+	})
+}
+
+// AuthorizeSelf sama seperti Authenticate, namun hanya meneruskan request jika id_admin pada path
+// adalah id pemilik token atau role admin termasuk salah satu role yang diizinkan.
+func (m *AuthMiddleware) AuthorizeSelf(next httprouter.Handle, roles ...string) httprouter.Handle {
+	return m.Authenticate(func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		claims, _ := helpers.ClaimsFromContext(r.Context())
+		if claims.Id != ps.ByName("id_admin") && !slices.Contains(roles, claims.Role) {
+			helpers.WriteJSONError(w, http.StatusForbidden, "tidak memiliki akses ke admin ini")
+			return
+		}
+
+		next(w, r, ps)
+	})
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return "", false
+	}
+
+	parts := strings.SplitN(header, " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return "", false
+	}
+
+	token := strings.TrimSpace(parts[1])
+	return token, token != ""
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/migrations"
+)
+
+// runMigrate menjalankan subcommand migrate up, migrate down [jumlah], migrate status,
+// migrate baseline <versi> dan migrate force <versi> up|down. Database yang tabelnya dibuat
+// manual sebelum ada migrasi cukup di-baseline sekali, misalnya "migrate baseline 5" jika hanya
+// tabel admin sampai penduduk yang ada, lalu dilanjutkan dengan migrate up. Migrasi yang gagal
+// di tengah jalan ditandai dirty dan harus diselesaikan dengan migrate force setelah skema diperiksa.
+func runMigrate(db *sql.DB, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("penggunaan: migrate up | migrate down [jumlah] | migrate status | migrate baseline <versi> | migrate force <versi> up|down")
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrations.Up(ctx, db)
+		for _, migration := range applied {
+			fmt.Printf("migrasi %04d_%s berhasil dijalankan\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("tidak ada migrasi baru")
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("jumlah migrasi yang dibatalkan harus berupa angka positif")
+			}
+			steps = n
+		}
+
+		reverted, err := migrations.Down(ctx, db, steps)
+		for _, migration := range reverted {
+			fmt.Printf("migrasi %04d_%s berhasil dibatalkan\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(reverted) == 0 {
+			fmt.Println("tidak ada migrasi yang dapat dibatalkan")
+		}
+	case "status":
+		statusList, err := migrations.Status(ctx, db)
+		if err != nil {
+			return err
+		}
+		for _, status := range statusList {
+			appliedAt := "belum diterapkan"
+			if status.Dirty {
+				appliedAt = "dirty sejak " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			} else if status.Applied {
+				appliedAt = "diterapkan " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+	case "baseline":
+		if len(args) < 2 {
+			return fmt.Errorf("penggunaan: migrate baseline <versi>, versi 1 sampai %d", migrations.BaselineMaxVersion)
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("versi baseline harus berupa angka")
+		}
+
+		addedColumns, marked, err := migrations.Baseline(ctx, db, version)
+		for _, column := range addedColumns {
+			fmt.Printf("kolom %s ditambahkan\n", column)
+		}
+		for _, migration := range marked {
+			fmt.Printf("migrasi %04d_%s ditandai sudah diterapkan\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(marked) == 0 {
+			fmt.Println("tidak ada migrasi yang perlu ditandai")
+		}
+	case "force":
+		if len(args) < 3 || (args[2] != "up" && args[2] != "down") {
+			return fmt.Errorf("penggunaan: migrate force <versi> up|down")
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("versi migrasi harus berupa angka")
+		}
+
+		migration, err := migrations.Force(ctx, db, version, args[2] == "up")
+		if err != nil {
+			return err
+		}
+		if args[2] == "up" {
+			fmt.Printf("migrasi %04d_%s ditandai sudah diterapkan\n", migration.Version, migration.Name)
+		} else {
+			fmt.Printf("migrasi %04d_%s ditandai belum diterapkan\n", migration.Version, migration.Name)
+		}
+	default:
+		return fmt.Errorf("subcommand migrate %s tidak dikenal", args[0])
+	}
+
+	return nil
+}
@@ -0,0 +1,12 @@
+CREATE TABLE admin (
+    id_admin VARCHAR(36) NOT NULL,
+    username VARCHAR(100) NOT NULL,
+    password VARCHAR(255) NOT NULL,
+    role VARCHAR(50) NOT NULL DEFAULT 'superadmin',
+    status VARCHAR(20) NOT NULL DEFAULT 'aktif',
+    totp_secret VARCHAR(64) NULL,
+    totp_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    totp_last_step BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (id_admin),
+    UNIQUE KEY uq_admin_username (username)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS galeri;
+DROP TABLE IF EXISTS berita;
@@ -0,0 +1,18 @@
+CREATE TABLE berita (
+    id_berita VARCHAR(36) NOT NULL,
+    judul_berita VARCHAR(255) NOT NULL,
+    kategori VARCHAR(100) NOT NULL,
+    tanggal_pelaksanaan DATE NOT NULL,
+    deskripsi TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id_berita)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE galeri (
+    id_galeri VARCHAR(36) NOT NULL,
+    id_berita VARCHAR(36) NOT NULL,
+    gambar VARCHAR(255) NOT NULL,
+    PRIMARY KEY (id_galeri),
+    KEY idx_galeri_gambar (gambar),
+    CONSTRAINT fk_galeri_berita FOREIGN KEY (id_berita) REFERENCES berita (id_berita) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
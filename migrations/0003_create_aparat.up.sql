@@ -0,0 +1,12 @@
+CREATE TABLE aparat (
+    id_aparat VARCHAR(36) NOT NULL,
+    nama VARCHAR(255) NOT NULL,
+    jabatan VARCHAR(100) NOT NULL,
+    no_telepon VARCHAR(20) NOT NULL DEFAULT '',
+    email VARCHAR(100) NOT NULL DEFAULT '',
+    status VARCHAR(50) NOT NULL DEFAULT '',
+    periode_mulai VARCHAR(20) NOT NULL DEFAULT '',
+    periode_selesai VARCHAR(20) NOT NULL DEFAULT '',
+    foto VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (id_aparat)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,9 @@
+CREATE TABLE kontak (
+    id_kontak VARCHAR(36) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    telepon VARCHAR(50) NOT NULL DEFAULT '',
+    facebook VARCHAR(255) NOT NULL DEFAULT '',
+    instagram VARCHAR(255) NOT NULL DEFAULT '',
+    youtube VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (id_kontak)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
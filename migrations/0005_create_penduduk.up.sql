@@ -0,0 +1,6 @@
+CREATE TABLE penduduk (
+    id_penduduk VARCHAR(36) NOT NULL,
+    total_penduduk INT UNSIGNED NOT NULL DEFAULT 0,
+    total_kepala_keluarga INT UNSIGNED NOT NULL DEFAULT 0,
+    PRIMARY KEY (id_penduduk)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
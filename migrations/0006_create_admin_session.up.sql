@@ -0,0 +1,11 @@
+CREATE TABLE admin_session (
+    id_session VARCHAR(36) NOT NULL,
+    id_admin VARCHAR(36) NOT NULL,
+    refresh_token_hash CHAR(64) NOT NULL,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (id_session),
+    UNIQUE KEY uq_admin_session_refresh_token (refresh_token_hash),
+    KEY idx_admin_session_admin (id_admin),
+    CONSTRAINT fk_admin_session_admin FOREIGN KEY (id_admin) REFERENCES admin (id_admin) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
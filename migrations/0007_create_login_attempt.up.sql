@@ -0,0 +1,10 @@
+CREATE TABLE login_attempt (
+    id_login_attempt VARCHAR(36) NOT NULL,
+    username VARCHAR(100) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL,
+    success BOOLEAN NOT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (id_login_attempt),
+    KEY idx_login_attempt_username (username, created_at),
+    KEY idx_login_attempt_ip (ip_address, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
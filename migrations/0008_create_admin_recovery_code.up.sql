@@ -0,0 +1,8 @@
+CREATE TABLE admin_recovery_code (
+    id_recovery_code VARCHAR(36) NOT NULL,
+    id_admin VARCHAR(36) NOT NULL,
+    code_hash VARCHAR(255) NOT NULL,
+    PRIMARY KEY (id_recovery_code),
+    KEY idx_admin_recovery_code_admin (id_admin),
+    CONSTRAINT fk_admin_recovery_code_admin FOREIGN KEY (id_admin) REFERENCES admin (id_admin) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,15 @@
+CREATE TABLE audit_log (
+    id_audit VARCHAR(36) NOT NULL,
+    id_admin VARCHAR(36) NULL,
+    username VARCHAR(100) NOT NULL DEFAULT '',
+    action VARCHAR(20) NOT NULL,
+    entity VARCHAR(50) NOT NULL,
+    entity_id VARCHAR(255) NOT NULL,
+    data_before JSON NULL,
+    data_after JSON NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (id_audit),
+    KEY idx_audit_log_admin (id_admin),
+    KEY idx_audit_log_entity (entity, entity_id),
+    KEY idx_audit_log_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
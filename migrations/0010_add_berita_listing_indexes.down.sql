@@ -0,0 +1,3 @@
+DROP INDEX idx_berita_kategori ON berita;
+DROP INDEX idx_berita_tanggal_pelaksanaan ON berita;
+DROP INDEX idx_berita_created_at ON berita;
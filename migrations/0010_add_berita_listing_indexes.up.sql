@@ -0,0 +1,3 @@
+CREATE INDEX idx_berita_created_at ON berita (created_at);
+CREATE INDEX idx_berita_tanggal_pelaksanaan ON berita (tanggal_pelaksanaan);
+CREATE INDEX idx_berita_kategori ON berita (kategori);
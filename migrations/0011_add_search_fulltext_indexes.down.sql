@@ -0,0 +1,2 @@
+DROP INDEX ft_aparat_search ON aparat;
+DROP INDEX ft_berita_search ON berita;
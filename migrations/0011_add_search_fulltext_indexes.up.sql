@@ -0,0 +1,2 @@
+CREATE FULLTEXT INDEX ft_berita_search ON berita (judul_berita, deskripsi);
+CREATE FULLTEXT INDEX ft_aparat_search ON aparat (nama, jabatan);
@@ -0,0 +1,2 @@
+ALTER TABLE aparat DROP COLUMN varian_foto;
+ALTER TABLE galeri DROP COLUMN varian_gambar;
@@ -0,0 +1,2 @@
+ALTER TABLE galeri ADD COLUMN varian_gambar JSON NULL AFTER gambar;
+ALTER TABLE aparat ADD COLUMN varian_foto JSON NULL AFTER foto;
@@ -0,0 +1,2 @@
+DROP INDEX idx_berita_status_created_at ON berita;
+ALTER TABLE berita DROP COLUMN status;
@@ -0,0 +1,3 @@
+ALTER TABLE berita ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'draft' AFTER deskripsi;
+UPDATE berita SET status = 'published';
+CREATE INDEX idx_berita_status_created_at ON berita (status, created_at);
@@ -0,0 +1,3 @@
+DROP INDEX idx_berita_status_expire_at ON berita;
+DROP INDEX idx_berita_status_publish_at ON berita;
+ALTER TABLE berita DROP COLUMN expire_at, DROP COLUMN publish_at;
@@ -0,0 +1,3 @@
+ALTER TABLE berita ADD COLUMN publish_at DATETIME NULL AFTER status, ADD COLUMN expire_at DATETIME NULL AFTER publish_at;
+CREATE INDEX idx_berita_status_publish_at ON berita (status, publish_at);
+CREATE INDEX idx_berita_status_expire_at ON berita (status, expire_at);
@@ -0,0 +1,3 @@
+DROP TABLE berita_slug_history;
+DROP INDEX uk_berita_slug ON berita;
+ALTER TABLE berita DROP COLUMN slug;
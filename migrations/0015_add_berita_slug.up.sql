@@ -0,0 +1,10 @@
+ALTER TABLE berita ADD COLUMN slug VARCHAR(100) NULL AFTER judul_berita;
+CREATE UNIQUE INDEX uk_berita_slug ON berita (slug);
+CREATE TABLE berita_slug_history (
+    slug VARCHAR(100) NOT NULL,
+    id_berita VARCHAR(36) NOT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (slug),
+    KEY idx_berita_slug_history_berita (id_berita),
+    CONSTRAINT fk_berita_slug_history_berita FOREIGN KEY (id_berita) REFERENCES berita (id_berita) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1 @@
+DROP TABLE berita_revisi;
@@ -0,0 +1,17 @@
+CREATE TABLE berita_revisi (
+    id_revisi VARCHAR(36) NOT NULL,
+    id_berita VARCHAR(36) NOT NULL,
+    versi INT NOT NULL,
+    judul_berita VARCHAR(255) NOT NULL,
+    kategori VARCHAR(100) NOT NULL,
+    tanggal_pelaksanaan DATE NOT NULL,
+    deskripsi TEXT NOT NULL,
+    publish_at DATETIME NULL,
+    expire_at DATETIME NULL,
+    id_admin VARCHAR(36) NULL,
+    username VARCHAR(100) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (id_revisi),
+    UNIQUE KEY uk_berita_revisi_versi (id_berita, versi),
+    CONSTRAINT fk_berita_revisi_berita FOREIGN KEY (id_berita) REFERENCES berita (id_berita) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
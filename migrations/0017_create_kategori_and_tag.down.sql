@@ -0,0 +1,12 @@
+DROP TABLE berita_tag;
+DROP TABLE tag;
+
+ALTER TABLE berita_revisi DROP COLUMN id_kategori;
+
+ALTER TABLE berita ADD COLUMN kategori VARCHAR(100) NOT NULL DEFAULT '' AFTER id_kategori;
+UPDATE berita b JOIN kategori_berita k ON k.id_kategori = b.id_kategori SET b.kategori = k.nama;
+CREATE INDEX idx_berita_kategori ON berita (kategori);
+ALTER TABLE berita DROP FOREIGN KEY fk_berita_kategori;
+ALTER TABLE berita DROP COLUMN id_kategori;
+
+DROP TABLE kategori_berita;
@@ -0,0 +1,47 @@
+CREATE TABLE kategori_berita (
+    id_kategori VARCHAR(36) NOT NULL,
+    nama VARCHAR(100) NOT NULL,
+    slug VARCHAR(100) NULL,
+    urutan INT NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (id_kategori),
+    UNIQUE KEY uk_kategori_berita_nama (nama),
+    UNIQUE KEY uk_kategori_berita_slug (slug),
+    KEY idx_kategori_berita_urutan (urutan, nama)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Kategori teks bebas yang hanya berbeda huruf besar/kecil atau spasi digabung menjadi satu kategori.
+-- Slug diisi saat server dijalankan dengan helpers.Slugify dan akhiran angka jika bentrok,
+-- sampai saat itu slug bernilai NULL yang tidak melanggar uk_kategori_berita_slug.
+INSERT INTO kategori_berita (id_kategori, nama, slug, urutan, created_at)
+SELECT UUID(), MIN(TRIM(kategori)), NULL, 0, UTC_TIMESTAMP()
+FROM berita
+WHERE TRIM(kategori) <> ''
+GROUP BY TRIM(kategori);
+
+ALTER TABLE berita ADD COLUMN id_kategori VARCHAR(36) NULL AFTER slug;
+UPDATE berita b JOIN kategori_berita k ON k.nama = TRIM(b.kategori) SET b.id_kategori = k.id_kategori;
+ALTER TABLE berita ADD CONSTRAINT fk_berita_kategori FOREIGN KEY (id_kategori) REFERENCES kategori_berita (id_kategori);
+DROP INDEX idx_berita_kategori ON berita;
+ALTER TABLE berita DROP COLUMN kategori;
+
+ALTER TABLE berita_revisi ADD COLUMN id_kategori VARCHAR(36) NULL AFTER judul_berita;
+UPDATE berita_revisi r JOIN kategori_berita k ON k.nama = TRIM(r.kategori) SET r.id_kategori = k.id_kategori;
+
+CREATE TABLE tag (
+    id_tag VARCHAR(36) NOT NULL,
+    nama VARCHAR(100) NOT NULL,
+    slug VARCHAR(100) NOT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (id_tag),
+    UNIQUE KEY uk_tag_slug (slug)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE berita_tag (
+    id_berita VARCHAR(36) NOT NULL,
+    id_tag VARCHAR(36) NOT NULL,
+    PRIMARY KEY (id_berita, id_tag),
+    KEY idx_berita_tag_tag (id_tag),
+    CONSTRAINT fk_berita_tag_berita FOREIGN KEY (id_berita) REFERENCES berita (id_berita) ON DELETE CASCADE,
+    CONSTRAINT fk_berita_tag_tag FOREIGN KEY (id_tag) REFERENCES tag (id_tag) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
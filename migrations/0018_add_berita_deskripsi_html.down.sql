@@ -0,0 +1 @@
+ALTER TABLE berita DROP COLUMN deskripsi_html;
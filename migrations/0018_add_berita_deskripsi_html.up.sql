@@ -0,0 +1 @@
+ALTER TABLE berita ADD COLUMN deskripsi_html MEDIUMTEXT NULL AFTER deskripsi;
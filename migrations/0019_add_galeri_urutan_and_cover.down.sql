@@ -0,0 +1,2 @@
+DROP INDEX idx_galeri_berita_urutan ON galeri;
+ALTER TABLE galeri DROP COLUMN is_cover, DROP COLUMN alt_text, DROP COLUMN caption, DROP COLUMN urutan;
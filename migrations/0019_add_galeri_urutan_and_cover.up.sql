@@ -0,0 +1,15 @@
+ALTER TABLE galeri
+    ADD COLUMN urutan INT NOT NULL DEFAULT 0 AFTER varian_gambar,
+    ADD COLUMN caption VARCHAR(255) NOT NULL DEFAULT '' AFTER urutan,
+    ADD COLUMN alt_text VARCHAR(255) NOT NULL DEFAULT '' AFTER caption,
+    ADD COLUMN is_cover TINYINT(1) NOT NULL DEFAULT 0 AFTER alt_text;
+UPDATE galeri g
+    JOIN (
+        SELECT a.id_galeri, COUNT(*) AS urutan
+        FROM galeri a
+        JOIN galeri b ON b.id_berita = a.id_berita AND (b.gambar < a.gambar OR (b.gambar = a.gambar AND b.id_galeri <= a.id_galeri))
+        GROUP BY a.id_galeri
+    ) x ON x.id_galeri = g.id_galeri
+    SET g.urutan = x.urutan;
+UPDATE galeri SET is_cover = 1 WHERE urutan = 1;
+CREATE INDEX idx_galeri_berita_urutan ON galeri (id_berita, urutan);
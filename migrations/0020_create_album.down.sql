@@ -0,0 +1,6 @@
+DELETE FROM galeri WHERE id_berita IS NULL;
+DROP INDEX idx_galeri_album_urutan ON galeri;
+ALTER TABLE galeri DROP FOREIGN KEY fk_galeri_album;
+ALTER TABLE galeri DROP COLUMN id_album;
+ALTER TABLE galeri MODIFY id_berita VARCHAR(36) NOT NULL;
+DROP TABLE album;
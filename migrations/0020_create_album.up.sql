@@ -0,0 +1,13 @@
+CREATE TABLE album (
+    id_album VARCHAR(36) NOT NULL,
+    judul VARCHAR(255) NOT NULL,
+    deskripsi TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL,
+    PRIMARY KEY (id_album),
+    KEY idx_album_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+ALTER TABLE galeri MODIFY id_berita VARCHAR(36) NULL;
+ALTER TABLE galeri ADD COLUMN id_album VARCHAR(36) NULL AFTER id_berita;
+ALTER TABLE galeri ADD CONSTRAINT fk_galeri_album FOREIGN KEY (id_album) REFERENCES album (id_album) ON DELETE CASCADE;
+CREATE INDEX idx_galeri_album_urutan ON galeri (id_album, urutan);
@@ -0,0 +1,9 @@
+DELETE FROM galeri WHERE deleted_at IS NOT NULL;
+DELETE FROM berita WHERE deleted_at IS NOT NULL;
+DELETE FROM aparat WHERE deleted_at IS NOT NULL;
+DROP INDEX idx_galeri_deleted_at ON galeri;
+ALTER TABLE galeri DROP COLUMN deleted_at;
+DROP INDEX idx_aparat_deleted_at ON aparat;
+ALTER TABLE aparat DROP COLUMN deleted_at;
+DROP INDEX idx_berita_deleted_at ON berita;
+ALTER TABLE berita DROP COLUMN deleted_at;
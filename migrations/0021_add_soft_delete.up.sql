@@ -0,0 +1,6 @@
+ALTER TABLE berita ADD COLUMN deleted_at DATETIME NULL;
+CREATE INDEX idx_berita_deleted_at ON berita (deleted_at);
+ALTER TABLE aparat ADD COLUMN deleted_at DATETIME NULL;
+CREATE INDEX idx_aparat_deleted_at ON aparat (deleted_at);
+ALTER TABLE galeri ADD COLUMN deleted_at DATETIME NULL;
+CREATE INDEX idx_galeri_deleted_at ON galeri (deleted_at);
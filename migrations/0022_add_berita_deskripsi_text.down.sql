@@ -0,0 +1,3 @@
+DROP INDEX ft_berita_search ON berita;
+CREATE FULLTEXT INDEX ft_berita_search ON berita (judul_berita, deskripsi);
+ALTER TABLE berita DROP COLUMN deskripsi_text;
@@ -0,0 +1,3 @@
+ALTER TABLE berita ADD COLUMN deskripsi_text MEDIUMTEXT NULL AFTER deskripsi_html;
+DROP INDEX ft_berita_search ON berita;
+CREATE FULLTEXT INDEX ft_berita_search ON berita (judul_berita, deskripsi_text);
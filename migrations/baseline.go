@@ -0,0 +1,149 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// BaselineMaxVersion adalah versi terakhir yang boleh di-baseline. Migrasi 0001 sampai 0009
+// menggambarkan tabel yang sebelumnya dibuat manual, migrasi sesudahnya selalu dijalankan lewat migrate up.
+const BaselineMaxVersion = 9
+
+// baselineTables adalah tabel yang dibuat oleh setiap migrasi baseline
+var baselineTables = map[int][]string{
+	1: {"admin"},
+	2: {"berita", "galeri"},
+	3: {"aparat"},
+	4: {"kontak"},
+	5: {"penduduk"},
+	6: {"admin_session"},
+	7: {"login_attempt"},
+	8: {"admin_recovery_code"},
+	9: {"audit_log"},
+}
+
+// baselineColumns adalah kolom yang ditambahkan ke tabel buatan manual sebelum migrasi ada.
+// Database lama tidak memiliki kolom ini, sehingga baseline menambahkannya jika belum ada
+// agar skema sama dengan hasil migrasi.
+var baselineColumns = []struct {
+	version    int
+	table      string
+	column     string
+	definition string
+}{
+	{1, "admin", "role", "VARCHAR(50) NOT NULL DEFAULT 'superadmin'"},
+	{1, "admin", "status", "VARCHAR(20) NOT NULL DEFAULT 'aktif'"},
+	{1, "admin", "totp_secret", "VARCHAR(64) NULL"},
+	{1, "admin", "totp_enabled", "BOOLEAN NOT NULL DEFAULT FALSE"},
+	{1, "admin", "totp_last_step", "BIGINT NOT NULL DEFAULT 0"},
+	{2, "berita", "created_at", "DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP"},
+}
+
+// Baseline menandai migrasi sampai versi tertentu sebagai sudah diterapkan tanpa menjalankannya,
+// untuk database yang tabelnya dibuat manual sebelum ada migrasi. Seluruh tabel migrasi tersebut
+// harus sudah ada, kolom yang ditambahkan setelah tabel dibuat manual dilengkapi terlebih dahulu.
+// Mengembalikan kolom yang ditambahkan dan migrasi yang ditandai.
+func Baseline(ctx context.Context, db *sql.DB, version int) ([]string, []Migration, error) {
+	if version < 1 || version > BaselineMaxVersion {
+		return nil, nil, fmt.Errorf("versi baseline harus antara 1 dan %d", BaselineMaxVersion)
+	}
+
+	var addedColumns []string
+	var marked []Migration
+	err := withLock(ctx, db, func() error {
+		var err error
+		addedColumns, marked, err = baseline(ctx, db, version)
+		return err
+	})
+
+	return addedColumns, marked, err
+}
+
+func baseline(ctx context.Context, db *sql.DB, version int) ([]string, []Migration, error) {
+	statusList, err := Status(ctx, db)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := checkDirty(statusList); err != nil {
+		return nil, nil, err
+	}
+
+	for _, status := range statusList {
+		if status.Applied && status.Version > version {
+			return nil, nil, fmt.Errorf("migrasi %04d_%s sudah diterapkan, baseline hanya untuk database yang belum memakai migrasi", status.Version, status.Name)
+		}
+	}
+
+	for v := 1; v <= version; v++ {
+		for _, table := range baselineTables[v] {
+			exists, err := tableExists(ctx, db, table)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !exists {
+				return nil, nil, fmt.Errorf("tabel %s tidak ditemukan, jalankan baseline dengan versi sebelum %04d", table, v)
+			}
+		}
+	}
+
+	var addedColumns []string
+	for _, column := range baselineColumns {
+		if column.version > version {
+			continue
+		}
+
+		exists, err := columnExists(ctx, db, column.table, column.column)
+		if err != nil {
+			return addedColumns, nil, err
+		}
+		if exists {
+			continue
+		}
+
+		_, err = db.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", column.table, column.column, column.definition))
+		if err != nil {
+			return addedColumns, nil, fmt.Errorf("gagal menambahkan kolom %s.%s: %v", column.table, column.column, err)
+		}
+		addedColumns = append(addedColumns, column.table+"."+column.column)
+	}
+
+	var marked []Migration
+	for _, status := range statusList {
+		if status.Applied || status.Version > version {
+			continue
+		}
+
+		_, err := db.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)", status.Version, status.Name, time.Now())
+		if err != nil {
+			return addedColumns, marked, fmt.Errorf("gagal mencatat migrasi %04d_%s: %v", status.Version, status.Name, err)
+		}
+		marked = append(marked, status.Migration)
+	}
+
+	return addedColumns, marked, nil
+}
+
+func tableExists(ctx context.Context, db *sql.DB, table string) (bool, error) {
+	query := "SELECT EXISTS (SELECT 1 FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?)"
+
+	var exists bool
+	if err := db.QueryRowContext(ctx, query, table).Scan(&exists); err != nil {
+		return false, fmt.Errorf("gagal memeriksa tabel %s: %v", table, err)
+	}
+
+	return exists, nil
+}
+
+func columnExists(ctx context.Context, db *sql.DB, table, column string) (bool, error) {
+	query := "SELECT EXISTS (SELECT 1 FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?)"
+
+	var exists bool
+	if err := db.QueryRowContext(ctx, query, table, column).Scan(&exists); err != nil {
+		return false, fmt.Errorf("gagal memeriksa kolom %s.%s: %v", table, column, err)
+	}
+
+	return exists, nil
+}
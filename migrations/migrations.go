@@ -0,0 +1,340 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// Nama file migrasi mengikuti format 0001_nama_migrasi.up.sql dan 0001_nama_migrasi.down.sql
+var filenamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+const createSchemaMigrationsQuery = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT NOT NULL,
+		name VARCHAR(255) NOT NULL,
+		applied_at DATETIME NOT NULL,
+		dirty BOOLEAN NOT NULL DEFAULT FALSE,
+		PRIMARY KEY (version)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`
+
+// lockName adalah nama lock MySQL yang dipegang selama migrate up, down, baseline dan force
+// agar dua proses tidak menjalankan migrasi bersamaan, misalnya saat beberapa instance deploy sekaligus
+const lockName = "desa_sukamaju_migrate"
+
+// lockTimeout adalah lama maksimal (detik) menunggu proses migrasi lain selesai
+const lockTimeout = 60
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+	// Dirty bernilai true jika migrasi gagal di tengah jalan. DDL MySQL tidak dapat di-rollback,
+	// sehingga skema harus diperiksa manual lalu ditandai dengan Force sebelum migrasi dilanjutkan.
+	Dirty bool
+}
+
+// Load membaca seluruh migrasi yang tertanam di binary, diurutkan berdasarkan versi
+func Load() ([]Migration, error) {
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca daftar migrasi: %v", err)
+	}
+
+	migrationMap := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := filenamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("nama file migrasi %s tidak valid", entry.Name())
+		}
+
+		version, _ := strconv.Atoi(match[1])
+		content, err := files.ReadFile(entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca migrasi %s: %v", entry.Name(), err)
+		}
+
+		migration, exists := migrationMap[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: match[2]}
+			migrationMap[version] = migration
+		}
+
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("versi migrasi %d digunakan oleh lebih dari satu migrasi", version)
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	var migrationList []Migration
+	for _, migration := range migrationMap {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migrasi %04d_%s harus memiliki file up dan down", migration.Version, migration.Name)
+		}
+		migrationList = append(migrationList, *migration)
+	}
+
+	sort.Slice(migrationList, func(i, j int) bool {
+		return migrationList[i].Version < migrationList[j].Version
+	})
+
+	return migrationList, nil
+}
+
+// Up menjalankan seluruh migrasi yang belum diterapkan dan mengembalikan migrasi yang berhasil dijalankan.
+// Setiap migrasi dicatat dirty sebelum dijalankan dan baru dibersihkan setelah berhasil,
+// sehingga migrasi yang gagal di tengah jalan tidak dilanjutkan begitu saja.
+func Up(ctx context.Context, db *sql.DB) ([]Migration, error) {
+	var applied []Migration
+	err := withLock(ctx, db, func() error {
+		statusList, err := Status(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		if err := checkDirty(statusList); err != nil {
+			return err
+		}
+
+		for _, status := range statusList {
+			if status.Applied {
+				continue
+			}
+
+			_, err := db.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, applied_at, dirty) VALUES (?, ?, ?, TRUE)", status.Version, status.Name, time.Now())
+			if err != nil {
+				return fmt.Errorf("gagal mencatat migrasi %04d_%s: %v", status.Version, status.Name, err)
+			}
+
+			if err := execStatements(ctx, db, status.Up); err != nil {
+				return fmt.Errorf("gagal menjalankan migrasi %04d_%s, migrasi ditandai dirty: %v", status.Version, status.Name, err)
+			}
+
+			_, err = db.ExecContext(ctx, "UPDATE schema_migrations SET dirty = FALSE, applied_at = ? WHERE version = ?", time.Now(), status.Version)
+			if err != nil {
+				return fmt.Errorf("gagal mencatat migrasi %04d_%s: %v", status.Version, status.Name, err)
+			}
+
+			applied = append(applied, status.Migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down membatalkan sejumlah migrasi terakhir yang sudah diterapkan, dimulai dari versi terbaru.
+// Seperti Up, migrasi ditandai dirty selama dibatalkan.
+func Down(ctx context.Context, db *sql.DB, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := withLock(ctx, db, func() error {
+		statusList, err := Status(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		if err := checkDirty(statusList); err != nil {
+			return err
+		}
+
+		for i := len(statusList) - 1; i >= 0 && len(reverted) < steps; i-- {
+			status := statusList[i]
+			if !status.Applied {
+				continue
+			}
+
+			_, err := db.ExecContext(ctx, "UPDATE schema_migrations SET dirty = TRUE WHERE version = ?", status.Version)
+			if err != nil {
+				return fmt.Errorf("gagal menandai migrasi %04d_%s: %v", status.Version, status.Name, err)
+			}
+
+			if err := execStatements(ctx, db, status.Down); err != nil {
+				return fmt.Errorf("gagal membatalkan migrasi %04d_%s, migrasi ditandai dirty: %v", status.Version, status.Name, err)
+			}
+
+			_, err = db.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ?", status.Version)
+			if err != nil {
+				return fmt.Errorf("gagal menghapus catatan migrasi %04d_%s: %v", status.Version, status.Name, err)
+			}
+
+			reverted = append(reverted, status.Migration)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Force membersihkan tanda dirty setelah skema diperbaiki manual. Jika applied bernilai true
+// migrasi dicatat sudah diterapkan, selain itu catatan migrasi dihapus sehingga migrasi
+// akan dijalankan ulang oleh Up berikutnya.
+func Force(ctx context.Context, db *sql.DB, version int, applied bool) (Migration, error) {
+	var forced Migration
+	err := withLock(ctx, db, func() error {
+		statusList, err := Status(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statusList {
+			if status.Version != version {
+				continue
+			}
+
+			if applied {
+				_, err = db.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, applied_at, dirty) VALUES (?, ?, ?, FALSE) ON DUPLICATE KEY UPDATE dirty = FALSE", status.Version, status.Name, time.Now())
+			} else {
+				_, err = db.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ?", status.Version)
+			}
+			if err != nil {
+				return fmt.Errorf("gagal mencatat migrasi %04d_%s: %v", status.Version, status.Name, err)
+			}
+
+			forced = status.Migration
+			return nil
+		}
+
+		return fmt.Errorf("migrasi versi %d tidak ditemukan", version)
+	})
+
+	return forced, err
+}
+
+// Status mengembalikan seluruh migrasi beserta informasi apakah migrasi tersebut sudah diterapkan
+func Status(ctx context.Context, db *sql.DB) ([]MigrationStatus, error) {
+	migrationList, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := db.ExecContext(ctx, createSchemaMigrationsQuery); err != nil {
+		return nil, fmt.Errorf("gagal membuat tabel schema_migrations: %v", err)
+	}
+
+	// Tabel schema_migrations yang dibuat sebelum ada penanda dirty dilengkapi kolomnya
+	exists, err := columnExists(ctx, db, "schema_migrations", "dirty")
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		if _, err := db.ExecContext(ctx, "ALTER TABLE schema_migrations ADD COLUMN dirty BOOLEAN NOT NULL DEFAULT FALSE"); err != nil {
+			return nil, fmt.Errorf("gagal menambahkan kolom schema_migrations.dirty: %v", err)
+		}
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT version, applied_at, dirty FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca tabel schema_migrations: %v", err)
+	}
+	defer rows.Close()
+
+	appliedMap := make(map[int]time.Time)
+	dirtyMap := make(map[int]bool)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		var dirty bool
+		if err := rows.Scan(&version, &appliedAt, &dirty); err != nil {
+			return nil, fmt.Errorf("gagal membaca tabel schema_migrations: %v", err)
+		}
+		appliedMap[version] = appliedAt
+		dirtyMap[version] = dirty
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca tabel schema_migrations: %v", err)
+	}
+
+	var statusList []MigrationStatus
+	for _, migration := range migrationList {
+		appliedAt, applied := appliedMap[migration.Version]
+		statusList = append(statusList, MigrationStatus{
+			Migration: migration,
+			Applied:   applied,
+			AppliedAt: appliedAt,
+			Dirty:     dirtyMap[migration.Version],
+		})
+	}
+
+	return statusList, nil
+}
+
+// checkDirty menolak melanjutkan migrasi selama masih ada migrasi yang gagal di tengah jalan
+func checkDirty(statusList []MigrationStatus) error {
+	for _, status := range statusList {
+		if status.Dirty {
+			return fmt.Errorf("migrasi %04d_%s dalam keadaan dirty, periksa skema database lalu jalankan migrate force %d up jika migrasi sudah lengkap atau migrate force %d down jika perubahannya sudah dibatalkan", status.Version, status.Name, status.Version, status.Version)
+		}
+	}
+
+	return nil
+}
+
+// withLock menjalankan fn selama memegang lock MySQL lockName. Lock dipegang oleh satu
+// koneksi khusus sampai fn selesai, sedangkan fn tetap memakai koneksi lain dari pool.
+func withLock(ctx context.Context, db *sql.DB, fn func() error) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("gagal mendapatkan koneksi untuk lock migrasi: %v", err)
+	}
+	defer conn.Close()
+
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, lockTimeout).Scan(&acquired); err != nil {
+		return fmt.Errorf("gagal mengambil lock migrasi: %v", err)
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		return fmt.Errorf("proses migrasi lain masih berjalan, coba lagi setelah selesai")
+	}
+
+	defer func() {
+		if _, releaseErr := conn.ExecContext(context.Background(), "DO RELEASE_LOCK(?)", lockName); releaseErr != nil {
+			// Koneksi dibuang dari pool agar lock ikut lepas saat sesi MySQL ditutup
+			conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+	}()
+
+	return fn()
+}
+
+// execStatements menjalankan setiap statement secara terpisah karena driver MySQL
+// tidak mengizinkan banyak statement dalam satu Exec tanpa opsi multiStatements.
+func execStatements(ctx context.Context, db *sql.DB, content string) error {
+	for _, statement := range strings.Split(content, ";") {
+		statement = strings.TrimSpace(statement)
+		if statement == "" {
+			continue
+		}
+
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,7 +1,33 @@
 package models
 
+const (
+	RoleSuperadmin           = "superadmin"
+	RoleEditorBerita         = "editor_berita"
+	RoleOperatorKependudukan = "operator_kependudukan"
+)
+
+const (
+	AdminStatusAktif    = "aktif"
+	AdminStatusNonaktif = "nonaktif"
+)
+
 type Admin struct {
 	IdAdmin  string
 	Username string
 	Password string
+	Role     string
+	Status   string
+
+	TotpSecret   string
+	TotpEnabled  bool
+	TotpLastStep int64
+}
+
+// IsValidRole memeriksa apakah role termasuk role admin yang dikenal
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleSuperadmin, RoleEditorBerita, RoleOperatorKependudukan:
+		return true
+	}
+	return false
 }
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type AdminSession struct {
+	IdSession        string
+	IdAdmin          string
+	RefreshTokenHash string
+	ExpiresAt        time.Time
+	CreatedAt        time.Time
+}
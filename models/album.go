@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type Album struct {
+	IdAlbum    string    `json:"id_album"`
+	Judul      string    `json:"judul"`
+	Deskripsi  string    `json:"deskripsi"`
+	JumlahFoto int       `json:"jumlah_foto"`
+	Cover      *Galeri   `json:"cover"`
+	Foto       []Galeri  `json:"foto"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type Aparat struct {
 	IdAparat       string
 	Nama           string
@@ -10,4 +12,6 @@ type Aparat struct {
 	PeriodeMulai   string
 	PeriodeSelesai string
 	Foto           string
-}
\ No newline at end of file
+	VarianFoto     ImageVariants
+	DeletedAt      *time.Time
+}
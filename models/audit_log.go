@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+	// AuditActionRestore dan AuditActionPurge dipakai untuk data di tempat sampah
+	AuditActionRestore = "restore"
+	AuditActionPurge   = "purge"
+)
+
+const (
+	AuditEntityAdmin    = "admin"
+	AuditEntityAlbum    = "album"
+	AuditEntityAparat   = "aparat"
+	AuditEntityBerita   = "berita"
+	AuditEntityGaleri   = "galeri"
+	AuditEntityKategori = "kategori"
+	AuditEntityKontak   = "kontak"
+	AuditEntityPenduduk = "penduduk"
+)
+
+type AuditLog struct {
+	IdAudit   string
+	IdAdmin   string
+	Username  string
+	Action    string
+	Entity    string
+	EntityId  string
+	Before    string
+	After     string
+	CreatedAt time.Time
+}
+
+type AuditFilter struct {
+	IdAdmin  string
+	Action   string
+	Entity   string
+	EntityId string
+	From     time.Time
+	To       time.Time
+	Limit    int
+	Offset   int
+}
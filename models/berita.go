@@ -1,10 +1,149 @@
 package models
 
+import "time"
+
+const (
+	BeritaSortCreatedAt          = "created_at"
+	BeritaSortTanggalPelaksanaan = "tanggal_pelaksanaan"
+	// BeritaSortPublishedAt mengurutkan berdasarkan publish_at, atau created_at jika berita
+	// langsung terbit tanpa jadwal
+	BeritaSortPublishedAt = "published_at"
+)
+
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+const (
+	BeritaStatusDraft     = "draft"
+	BeritaStatusInReview  = "in_review"
+	BeritaStatusScheduled = "scheduled"
+	BeritaStatusPublished = "published"
+	BeritaStatusArchived  = "archived"
+)
+
+// beritaTransitions memetakan status asal ke status tujuan beserta role yang boleh melakukannya.
+// Review dan publikasi dilakukan oleh superadmin (sekretaris desa), sedangkan editor berita
+// hanya dapat mengajukan review dan mengembalikan berita ke draft. Perpindahan scheduled ke
+// published dan published ke archived juga dilakukan otomatis oleh scheduler berita.
+var beritaTransitions = map[string]map[string][]string{
+	BeritaStatusDraft: {
+		BeritaStatusInReview:  {RoleSuperadmin, RoleEditorBerita},
+		BeritaStatusScheduled: {RoleSuperadmin},
+		BeritaStatusPublished: {RoleSuperadmin},
+	},
+	BeritaStatusInReview: {
+		BeritaStatusDraft:     {RoleSuperadmin, RoleEditorBerita},
+		BeritaStatusScheduled: {RoleSuperadmin},
+		BeritaStatusPublished: {RoleSuperadmin},
+	},
+	BeritaStatusScheduled: {
+		BeritaStatusDraft:     {RoleSuperadmin, RoleEditorBerita},
+		BeritaStatusPublished: {RoleSuperadmin},
+	},
+	BeritaStatusPublished: {
+		BeritaStatusDraft:    {RoleSuperadmin},
+		BeritaStatusArchived: {RoleSuperadmin},
+	},
+	BeritaStatusArchived: {
+		BeritaStatusDraft:     {RoleSuperadmin, RoleEditorBerita},
+		BeritaStatusPublished: {RoleSuperadmin},
+	},
+}
+
+// IsValidBeritaStatus memeriksa apakah status termasuk status berita yang dikenal
+func IsValidBeritaStatus(status string) bool {
+	_, ok := beritaTransitions[status]
+	return ok
+}
+
+// CanChangeBeritaStatus memeriksa apakah role boleh mengubah status berita dari from ke to
+func CanChangeBeritaStatus(from, to, role string) bool {
+	for _, allowed := range beritaTransitions[from][to] {
+		if allowed == role {
+			return true
+		}
+	}
+	return false
+}
+
+// CanEditBerita memeriksa apakah role boleh mengubah isi, memulihkan revisi atau menghapus berita
+// dengan status tertentu. Berita yang sudah disetujui (scheduled atau published) hanya dapat diubah
+// superadmin, agar isi baru dari editor tidak tayang tanpa review.
+func CanEditBerita(status, role string) bool {
+	if role == RoleSuperadmin {
+		return true
+	}
+	return status != BeritaStatusScheduled && status != BeritaStatusPublished
+}
+
 type Berita struct {
-	IdBerita           string   `json:"id_berita"`
-	JudulBerita        string   `json:"judul_berita"`
-	Kategori           string   `json:"kategori"`
-	TanggalPelaksanaan string   `json:"tanggal_pelaksanaan"`
-	Deskripsi          string   `json:"deskripsi"`
-	GambarBerita       []Galeri `json:"gambar_berita"`
+	IdBerita           string     `json:"id_berita"`
+	JudulBerita        string     `json:"judul_berita"`
+	Slug               string     `json:"slug"`
+	IdKategori         string     `json:"id_kategori"`
+	Kategori           string     `json:"kategori"`
+	KategoriSlug       string     `json:"kategori_slug"`
+	TanggalPelaksanaan string     `json:"tanggal_pelaksanaan"`
+	Deskripsi          string     `json:"deskripsi"`
+	DeskripsiHTML      string     `json:"deskripsi_html"`
+	DeskripsiText      string     `json:"-"` // teks biasa dari DeskripsiHTML untuk pencarian
+	Status             string     `json:"status"`
+	PublishAt          *time.Time `json:"publish_at"`
+	ExpireAt           *time.Time `json:"expire_at"`
+	Tags               []Tag      `json:"tags"`
+	GambarBerita       []Galeri   `json:"gambar_berita"`
+	CreatedAt          time.Time  `json:"created_at"`
+	DeletedAt          *time.Time `json:"deleted_at,omitempty"`
+}
+
+// IsVisible memeriksa apakah berita tampil untuk publik pada waktu now, yaitu sudah
+// diterbitkan, sudah melewati publish_at dan belum melewati expire_at
+func (b Berita) IsVisible(now time.Time) bool {
+	if b.Status != BeritaStatusPublished {
+		return false
+	}
+	if b.PublishAt != nil && b.PublishAt.After(now) {
+		return false
+	}
+	if b.ExpireAt != nil && !b.ExpireAt.After(now) {
+		return false
+	}
+	return true
+}
+
+// CoverPhoto mengembalikan foto sampul berita, atau foto pertama jika belum ada yang ditandai sampul
+func (b Berita) CoverPhoto() (Galeri, bool) {
+	for _, photo := range b.GambarBerita {
+		if photo.IsCover && photo.Gambar != "" {
+			return photo, true
+		}
+	}
+	for _, photo := range b.GambarBerita {
+		if photo.Gambar != "" {
+			return photo, true
+		}
+	}
+	return Galeri{}, false
+}
+
+type BeritaFilter struct {
+	Statuses []string
+	// VisibleAt, jika diisi, membatasi hasil pada berita yang berada di dalam jendela
+	// publish_at dan expire_at pada waktu tersebut
+	VisibleAt time.Time
+	// Kategori dicocokkan dengan slug atau nama kategori, Tag dengan slug tag
+	Kategori  string
+	Tag       string
+	From      time.Time
+	To        time.Time
+	SortBy    string
+	SortOrder string
+	// Trashed menampilkan berita di tempat sampah, diurutkan dari yang terakhir dihapus.
+	// DeletedBefore, jika diisi, membatasi hasil pada berita yang dihapus sebelum waktu tersebut.
+	Trashed       bool
+	DeletedBefore time.Time
+	Limit         int
+	Offset        int
 }
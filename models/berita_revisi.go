@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// BeritaRevisi adalah salinan isi berita sebelum diubah. Versi bertambah setiap kali berita
+// diupdate, sedangkan IdAdmin dan Username mencatat admin yang melakukan perubahan tersebut.
+type BeritaRevisi struct {
+	IdRevisi           string
+	IdBerita           string
+	Versi              int
+	JudulBerita        string
+	IdKategori         string
+	Kategori           string
+	TanggalPelaksanaan string
+	Deskripsi          string
+	PublishAt          *time.Time
+	ExpireAt           *time.Time
+	IdAdmin            string
+	Username           string
+	CreatedAt          time.Time
+}
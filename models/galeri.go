@@ -1,7 +1,16 @@
 package models
 
+import "time"
+
 type Galeri struct {
-	IdGaleri string
-	IdBerita string
-	Gambar   string
-}
\ No newline at end of file
+	IdGaleri  string        `json:"id_galeri"`
+	IdBerita  string        `json:"id_berita,omitempty"`
+	IdAlbum   string        `json:"id_album,omitempty"`
+	Gambar    string        `json:"gambar"`
+	Varian    ImageVariants `json:"varian_gambar"`
+	Urutan    int           `json:"urutan"`
+	Caption   string        `json:"caption"`
+	AltText   string        `json:"alt_text"`
+	IsCover   bool          `json:"is_cover"`
+	DeletedAt *time.Time    `json:"deleted_at,omitempty"`
+}
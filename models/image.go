@@ -0,0 +1,66 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Ukuran sisi terpanjang (piksel) untuk setiap varian gambar upload
+const (
+	ImageSizeThumbnail = 320
+	ImageSizeMedium    = 800
+	ImageSizeLarge     = 1600
+)
+
+// ImageVariants menyimpan nama file varian sebuah gambar upload. Disimpan sebagai JSON
+// di database; field WebP kosong jika encoder WebP tidak tersedia saat upload.
+type ImageVariants struct {
+	Thumbnail     string `json:"thumbnail,omitempty"`
+	Medium        string `json:"medium,omitempty"`
+	Large         string `json:"large,omitempty"`
+	ThumbnailWebp string `json:"thumbnail_webp,omitempty"`
+	MediumWebp    string `json:"medium_webp,omitempty"`
+	LargeWebp     string `json:"large_webp,omitempty"`
+}
+
+// Filenames mengembalikan seluruh nama file varian yang terisi
+func (v ImageVariants) Filenames() []string {
+	var filenames []string
+	for _, filename := range []string{v.Thumbnail, v.Medium, v.Large, v.ThumbnailWebp, v.MediumWebp, v.LargeWebp} {
+		if filename != "" {
+			filenames = append(filenames, filename)
+		}
+	}
+	return filenames
+}
+
+// Value implements driver.Valuer.
+func (v ImageVariants) Value() (driver.Value, error) {
+	if v == (ImageVariants{}) {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner.
+func (v *ImageVariants) Scan(src interface{}) error {
+	*v = ImageVariants{}
+
+	switch data := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(data, v)
+	case string:
+		return json.Unmarshal([]byte(data), v)
+	default:
+		return fmt.Errorf("tipe %T tidak dapat dibaca sebagai varian gambar", src)
+	}
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// KategoriBerita adalah kategori berita yang dikelola admin. JumlahBerita hanya diisi saat
+// listing dan berisi jumlah berita yang sedang tayang untuk publik.
+type KategoriBerita struct {
+	IdKategori   string
+	Nama         string
+	Slug         string
+	Urutan       int
+	JumlahBerita int
+	CreatedAt    time.Time
+}
+
+// Tag adalah label bebas pada berita, dibuat otomatis saat pertama kali dipakai
+type Tag struct {
+	IdTag        string
+	Nama         string
+	Slug         string
+	JumlahBerita int
+	CreatedAt    time.Time
+}
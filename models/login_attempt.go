@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type LoginAttempt struct {
+	IdLoginAttempt string
+	Username       string
+	IpAddress      string
+	Success        bool
+	CreatedAt      time.Time
+}
@@ -0,0 +1,7 @@
+package models
+
+type RecoveryCode struct {
+	IdRecoveryCode string
+	IdAdmin        string
+	CodeHash       string
+}
@@ -0,0 +1,14 @@
+package models
+
+const (
+	SearchTypeBerita = "berita"
+	SearchTypeAparat = "aparat"
+)
+
+type SearchResult struct {
+	Type    string
+	Id      string
+	Title   string
+	Content string
+	Score   float64
+}
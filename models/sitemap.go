@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// SitemapEntry adalah halaman dinamis di sitemap. Key berisi slug berita atau id aparat,
+// LastMod kosong jika waktu perubahan terakhirnya tidak diketahui.
+type SitemapEntry struct {
+	Key     string
+	LastMod *time.Time
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// TrashFilter adalah filter listing aparat dan foto di tempat sampah. Before, jika diisi,
+// membatasi hasil pada data yang dihapus sebelum waktu tersebut. Limit 0 berarti tanpa batas.
+type TrashFilter struct {
+	IdBerita string
+	IdAlbum  string
+	Before   time.Time
+	Limit    int
+	Offset   int
+}
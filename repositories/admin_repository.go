@@ -8,11 +8,20 @@ import (
 	"github.com/syrlramadhan/desa-sukamaju-api/models"
 )
 
+var ErrAdminNotFound = errors.New("username tidak ditemukan")
+
 type AdminRepository interface {
 	GetAdmin(ctx context.Context, tx *sql.Tx, username string) (models.Admin, error)
 	GetAdminById(ctx context.Context, tx *sql.Tx, idAdmin string) (models.Admin, error)
 	UpdateUsernameAdmin(ctx context.Context, tx *sql.Tx, oldUsername, newUsername string) error
 	UpdatePasswordAdmin(ctx context.Context, tx *sql.Tx, idAdmin, newPassword string) error
+	AddAdmin(ctx context.Context, tx *sql.Tx, admin models.Admin) (models.Admin, error)
+	GetAllAdmin(ctx context.Context, tx *sql.Tx) ([]models.Admin, error)
+	UpdateAdmin(ctx context.Context, tx *sql.Tx, admin models.Admin) error
+	DeleteAdmin(ctx context.Context, tx *sql.Tx, idAdmin string) error
+	IsUsernameExists(ctx context.Context, tx *sql.Tx, username string) (bool, error)
+	CountActiveSuperadmin(ctx context.Context, tx *sql.Tx) (int, error)
+	UpdateTotpAdmin(ctx context.Context, tx *sql.Tx, admin models.Admin) error
 }
 
 type AdminRepositoryImpl struct {
@@ -25,17 +34,22 @@ func NewAdminRepository() AdminRepository {
 // GetAdmin implements AdminRepository.
 func (a *AdminRepositoryImpl) GetAdmin(ctx context.Context, tx *sql.Tx, username string) (models.Admin, error) {
 	var admin models.Admin
-	query := "SELECT id_admin, username, password FROM admin WHERE username = ?"
+	query := "SELECT id_admin, username, password, role, status, COALESCE(totp_secret, ''), totp_enabled, totp_last_step FROM admin WHERE username = ?"
 
 	err := tx.QueryRowContext(ctx, query, username).Scan(
 		&admin.IdAdmin,
 		&admin.Username,
 		&admin.Password,
+		&admin.Role,
+		&admin.Status,
+		&admin.TotpSecret,
+		&admin.TotpEnabled,
+		&admin.TotpLastStep,
 	)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return models.Admin{}, errors.New("username tidak ditemukan")
+			return models.Admin{}, ErrAdminNotFound
 		}
 		return models.Admin{}, err
 	}
@@ -46,12 +60,17 @@ func (a *AdminRepositoryImpl) GetAdmin(ctx context.Context, tx *sql.Tx, username
 // GetAdminById implements AdminRepository.
 func (a *AdminRepositoryImpl) GetAdminById(ctx context.Context, tx *sql.Tx, idAdmin string) (models.Admin, error) {
 	var admin models.Admin
-	query := "SELECT id_admin, username, password FROM admin WHERE id_admin = ?"
+	query := "SELECT id_admin, username, password, role, status, COALESCE(totp_secret, ''), totp_enabled, totp_last_step FROM admin WHERE id_admin = ?"
 
 	err := tx.QueryRowContext(ctx, query, idAdmin).Scan(
 		&admin.IdAdmin,
 		&admin.Username,
 		&admin.Password,
+		&admin.Role,
+		&admin.Status,
+		&admin.TotpSecret,
+		&admin.TotpEnabled,
+		&admin.TotpLastStep,
 	)
 
 	if err != nil {
@@ -104,3 +123,84 @@ func (a *AdminRepositoryImpl) UpdatePasswordAdmin(ctx context.Context, tx *sql.T
 
 	return nil
 }
+
+// AddAdmin implements AdminRepository.
+func (a *AdminRepositoryImpl) AddAdmin(ctx context.Context, tx *sql.Tx, admin models.Admin) (models.Admin, error) {
+	query := "INSERT INTO admin (id_admin, username, password, role, status) VALUES (?, ?, ?, ?, ?)"
+
+	_, err := tx.ExecContext(ctx, query, admin.IdAdmin, admin.Username, admin.Password, admin.Role, admin.Status)
+	if err != nil {
+		return models.Admin{}, err
+	}
+
+	return admin, nil
+}
+
+// GetAllAdmin implements AdminRepository.
+func (a *AdminRepositoryImpl) GetAllAdmin(ctx context.Context, tx *sql.Tx) ([]models.Admin, error) {
+	query := "SELECT id_admin, username, role, status FROM admin ORDER BY username"
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var admins []models.Admin
+	for rows.Next() {
+		var admin models.Admin
+		if err := rows.Scan(&admin.IdAdmin, &admin.Username, &admin.Role, &admin.Status); err != nil {
+			return nil, err
+		}
+		admins = append(admins, admin)
+	}
+
+	return admins, rows.Err()
+}
+
+// UpdateAdmin implements AdminRepository.
+func (a *AdminRepositoryImpl) UpdateAdmin(ctx context.Context, tx *sql.Tx, admin models.Admin) error {
+	query := "UPDATE admin SET role = ?, status = ? WHERE id_admin = ?"
+
+	_, err := tx.ExecContext(ctx, query, admin.Role, admin.Status, admin.IdAdmin)
+	return err
+}
+
+// DeleteAdmin implements AdminRepository.
+func (a *AdminRepositoryImpl) DeleteAdmin(ctx context.Context, tx *sql.Tx, idAdmin string) error {
+	query := "DELETE FROM admin WHERE id_admin = ?"
+
+	_, err := tx.ExecContext(ctx, query, idAdmin)
+	return err
+}
+
+// IsUsernameExists implements AdminRepository.
+func (a *AdminRepositoryImpl) IsUsernameExists(ctx context.Context, tx *sql.Tx, username string) (bool, error) {
+	query := "SELECT COUNT(*) FROM admin WHERE username = ?"
+
+	var count int
+	if err := tx.QueryRowContext(ctx, query, username).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// CountActiveSuperadmin implements AdminRepository.
+// Baris superadmin aktif dikunci sampai transaksi selesai, sehingga dua permintaan yang
+// menurunkan superadmin berbeda secara bersamaan tidak sama-sama melihat masih ada dua superadmin.
+func (a *AdminRepositoryImpl) CountActiveSuperadmin(ctx context.Context, tx *sql.Tx) (int, error) {
+	query := "SELECT COUNT(*) FROM admin WHERE role = ? AND status = ? FOR UPDATE"
+
+	var count int
+	err := tx.QueryRowContext(ctx, query, models.RoleSuperadmin, models.AdminStatusAktif).Scan(&count)
+	return count, err
+}
+
+// UpdateTotpAdmin implements AdminRepository.
+func (a *AdminRepositoryImpl) UpdateTotpAdmin(ctx context.Context, tx *sql.Tx, admin models.Admin) error {
+	query := "UPDATE admin SET totp_secret = NULLIF(?, ''), totp_enabled = ?, totp_last_step = ? WHERE id_admin = ?"
+
+	_, err := tx.ExecContext(ctx, query, admin.TotpSecret, admin.TotpEnabled, admin.TotpLastStep, admin.IdAdmin)
+	return err
+}
@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+)
+
+type AlbumRepository interface {
+	AddAlbum(ctx context.Context, tx *sql.Tx, album models.Album) error
+	GetAllAlbum(ctx context.Context, tx *sql.Tx, limit, offset int) ([]models.Album, int, error)
+	GetAlbumById(ctx context.Context, tx *sql.Tx, idAlbum string) (models.Album, error)
+	UpdateAlbum(ctx context.Context, tx *sql.Tx, album models.Album) error
+	DeleteAlbum(ctx context.Context, tx *sql.Tx, idAlbum string) error
+	GetPhotosByAlbumId(ctx context.Context, tx *sql.Tx, idAlbum string) ([]models.Galeri, error)
+	SetCoverPhotoAlbum(ctx context.Context, tx *sql.Tx, idAlbum, idGaleri string) error
+}
+
+type albumRepositoryImpl struct{}
+
+func NewAlbumRepository() AlbumRepository {
+	return &albumRepositoryImpl{}
+}
+
+// AddAlbum implements AlbumRepository.
+func (a *albumRepositoryImpl) AddAlbum(ctx context.Context, tx *sql.Tx, album models.Album) error {
+	query := "INSERT INTO album (id_album, judul, deskripsi, created_at, updated_at) VALUES (?, ?, ?, ?, ?)"
+	_, err := tx.ExecContext(ctx, query, album.IdAlbum, album.Judul, album.Deskripsi, album.CreatedAt, album.UpdatedAt)
+	return err
+}
+
+// GetAllAlbum implements AlbumRepository.
+// Foto tidak ikut dimuat, hanya sampul dan jumlah foto untuk kartu listing.
+func (a *albumRepositoryImpl) GetAllAlbum(ctx context.Context, tx *sql.Tx, limit, offset int) ([]models.Album, int, error) {
+	var total int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM album").Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT
+			a.id_album,
+			a.judul,
+			a.deskripsi,
+			(SELECT COUNT(*) FROM galeri g WHERE g.id_album = a.id_album AND g.deleted_at IS NULL) as jumlah_foto,
+			a.created_at,
+			a.updated_at
+		FROM album a
+		ORDER BY a.created_at DESC, a.id_album
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := tx.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var albumList []models.Album
+	var idAlbumList []string
+	for rows.Next() {
+		var album models.Album
+		if err := rows.Scan(&album.IdAlbum, &album.Judul, &album.Deskripsi, &album.JumlahFoto, &album.CreatedAt, &album.UpdatedAt); err != nil {
+			return nil, 0, err
+		}
+		albumList = append(albumList, album)
+		idAlbumList = append(idAlbumList, album.IdAlbum)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	rows.Close()
+
+	coverMap, err := a.getCoversByAlbumIds(ctx, tx, idAlbumList)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range albumList {
+		if cover, ok := coverMap[albumList[i].IdAlbum]; ok {
+			albumList[i].Cover = &cover
+		}
+	}
+
+	return albumList, total, nil
+}
+
+func (a *albumRepositoryImpl) getCoversByAlbumIds(ctx context.Context, tx *sql.Tx, idAlbum []string) (map[string]models.Galeri, error) {
+	coverMap := make(map[string]models.Galeri)
+	if len(idAlbum) == 0 {
+		return coverMap, nil
+	}
+
+	args := make([]interface{}, len(idAlbum))
+	for i, id := range idAlbum {
+		args[i] = id
+	}
+
+	query := "SELECT " + galeriColumns + " FROM galeri WHERE is_cover = 1 AND deleted_at IS NULL AND id_album IN (?" + strings.Repeat(",?", len(idAlbum)-1) + ")"
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		photo, err := scanGaleri(rows)
+		if err != nil {
+			return nil, err
+		}
+		coverMap[photo.IdAlbum] = photo
+	}
+
+	return coverMap, rows.Err()
+}
+
+// GetAlbumById implements AlbumRepository.
+func (a *albumRepositoryImpl) GetAlbumById(ctx context.Context, tx *sql.Tx, idAlbum string) (models.Album, error) {
+	query := "SELECT id_album, judul, deskripsi, created_at, updated_at FROM album WHERE id_album = ?"
+
+	var album models.Album
+	err := tx.QueryRowContext(ctx, query, idAlbum).Scan(&album.IdAlbum, &album.Judul, &album.Deskripsi, &album.CreatedAt, &album.UpdatedAt)
+	if err != nil {
+		return models.Album{}, err
+	}
+
+	album.Foto, err = a.GetPhotosByAlbumId(ctx, tx, idAlbum)
+	if err != nil {
+		return models.Album{}, err
+	}
+
+	album.JumlahFoto = len(album.Foto)
+	for i := range album.Foto {
+		if album.Foto[i].IsCover {
+			album.Cover = &album.Foto[i]
+			break
+		}
+	}
+
+	return album, nil
+}
+
+// UpdateAlbum implements AlbumRepository.
+func (a *albumRepositoryImpl) UpdateAlbum(ctx context.Context, tx *sql.Tx, album models.Album) error {
+	query := "UPDATE album SET judul = ?, deskripsi = ?, updated_at = ? WHERE id_album = ?"
+	_, err := tx.ExecContext(ctx, query, album.Judul, album.Deskripsi, album.UpdatedAt, album.IdAlbum)
+	return err
+}
+
+// DeleteAlbum implements AlbumRepository.
+// Foto album, termasuk yang ada di tempat sampah, ikut terhapus karena foreign key galeri
+// memakai ON DELETE CASCADE.
+func (a *albumRepositoryImpl) DeleteAlbum(ctx context.Context, tx *sql.Tx, idAlbum string) error {
+	query := "DELETE FROM album WHERE id_album = ?"
+	_, err := tx.ExecContext(ctx, query, idAlbum)
+	return err
+}
+
+// GetPhotosByAlbumId implements AlbumRepository.
+// Foto di tempat sampah tidak ikut dikembalikan.
+func (a *albumRepositoryImpl) GetPhotosByAlbumId(ctx context.Context, tx *sql.Tx, idAlbum string) ([]models.Galeri, error) {
+	query := "SELECT " + galeriColumns + " FROM galeri WHERE id_album = ? AND deleted_at IS NULL ORDER BY urutan, id_galeri"
+	rows, err := tx.QueryContext(ctx, query, idAlbum)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanGaleriRows(rows)
+}
+
+// SetCoverPhotoAlbum implements AlbumRepository.
+// Foto lain pada album yang sama otomatis tidak lagi menjadi sampul.
+func (a *albumRepositoryImpl) SetCoverPhotoAlbum(ctx context.Context, tx *sql.Tx, idAlbum, idGaleri string) error {
+	query := "UPDATE galeri SET is_cover = (id_galeri = ?) WHERE id_album = ?"
+	_, err := tx.ExecContext(ctx, query, idGaleri, idAlbum)
+	return err
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/syrlramadhan/desa-sukamaju-api/models"
 )
@@ -14,22 +15,33 @@ type AparatRepository interface {
 	GetAllAparat(ctx context.Context, tx *sql.Tx) ([]models.Aparat, error)
 	GetAparatById(ctx context.Context, tx *sql.Tx, idAparat string) (models.Aparat, error)
 	UpdateAparat(ctx context.Context, tx *sql.Tx, aparat models.Aparat) error
-	DeleteAparat(ctx context.Context, tx *sql.Tx, idAparat string) error
+	TrashAparat(ctx context.Context, tx *sql.Tx, idAparat []string, deletedAt time.Time) error
+	GetDeletedAparat(ctx context.Context, tx *sql.Tx, filter models.TrashFilter) ([]models.Aparat, int, error)
+	GetDeletedAparatById(ctx context.Context, tx *sql.Tx, idAparat string) (models.Aparat, error)
+	RestoreAparat(ctx context.Context, tx *sql.Tx, idAparat string) error
 	BulkDeleteAparat(ctx context.Context, tx *sql.Tx, idAparat []string) error
 }
 
 type aparatRepositoryImpl struct {
 }
 
+const aparatColumns = "id_aparat, nama, jabatan, no_telepon, email, status, periode_mulai, periode_selesai, foto, varian_foto, deleted_at"
+
+func scanAparat(row rowScanner) (models.Aparat, error) {
+	var aparat models.Aparat
+	err := row.Scan(&aparat.IdAparat, &aparat.Nama, &aparat.Jabatan, &aparat.NoTelepon, &aparat.Email, &aparat.Status, &aparat.PeriodeMulai, &aparat.PeriodeSelesai, &aparat.Foto, &aparat.VarianFoto, &aparat.DeletedAt)
+	return aparat, err
+}
+
 func NewAparatRepository() AparatRepository {
 	return &aparatRepositoryImpl{}
 }
 
 // AddAparat implements AparatRepository.
 func (a *aparatRepositoryImpl) AddAparat(ctx context.Context, tx *sql.Tx, aparat models.Aparat) (models.Aparat, error) {
-	query := "INSERT INTO aparat (id_aparat, nama, jabatan, no_telepon, email, status, periode_mulai, periode_selesai, foto) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	query := "INSERT INTO aparat (id_aparat, nama, jabatan, no_telepon, email, status, periode_mulai, periode_selesai, foto, varian_foto) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
 
-	_, err := tx.ExecContext(ctx, query, aparat.IdAparat, aparat.Nama, aparat.Jabatan, aparat.NoTelepon, aparat.Email, aparat.Status, aparat.PeriodeMulai, aparat.PeriodeSelesai, aparat.Foto)
+	_, err := tx.ExecContext(ctx, query, aparat.IdAparat, aparat.Nama, aparat.Jabatan, aparat.NoTelepon, aparat.Email, aparat.Status, aparat.PeriodeMulai, aparat.PeriodeSelesai, aparat.Foto, aparat.VarianFoto)
 	if err != nil {
 		return models.Aparat{}, err
 	}
@@ -38,8 +50,9 @@ func (a *aparatRepositoryImpl) AddAparat(ctx context.Context, tx *sql.Tx, aparat
 }
 
 // GetAllAparat implements AparatRepository.
+// Aparat di tempat sampah tidak ikut dikembalikan.
 func (a *aparatRepositoryImpl) GetAllAparat(ctx context.Context, tx *sql.Tx) ([]models.Aparat, error) {
-	query := "SELECT id_aparat, nama, jabatan, no_telepon, email, status, periode_mulai, periode_selesai, foto FROM aparat"
+	query := "SELECT " + aparatColumns + " FROM aparat WHERE deleted_at IS NULL"
 
 	rows, err := tx.QueryContext(ctx, query)
 	if err != nil {
@@ -49,8 +62,8 @@ func (a *aparatRepositoryImpl) GetAllAparat(ctx context.Context, tx *sql.Tx) ([]
 
 	var aparats []models.Aparat
 	for rows.Next() {
-		var aparat models.Aparat
-		if err := rows.Scan(&aparat.IdAparat, &aparat.Nama, &aparat.Jabatan, &aparat.NoTelepon, &aparat.Email, &aparat.Status, &aparat.PeriodeMulai, &aparat.PeriodeSelesai, &aparat.Foto); err != nil {
+		aparat, err := scanAparat(rows)
+		if err != nil {
 			return nil, err
 		}
 		aparats = append(aparats, aparat)
@@ -60,11 +73,11 @@ func (a *aparatRepositoryImpl) GetAllAparat(ctx context.Context, tx *sql.Tx) ([]
 }
 
 // GetAparatById implements AparatRepository.
+// Aparat di tempat sampah dianggap tidak ada.
 func (a *aparatRepositoryImpl) GetAparatById(ctx context.Context, tx *sql.Tx, idAparat string) (models.Aparat, error) {
-	query := "SELECT id_aparat, nama, jabatan, no_telepon, email, status, periode_mulai, periode_selesai, foto FROM aparat WHERE id_aparat = ?"
+	query := "SELECT " + aparatColumns + " FROM aparat WHERE id_aparat = ? AND deleted_at IS NULL"
 
-	var aparat models.Aparat
-	err := tx.QueryRowContext(ctx, query, idAparat).Scan(&aparat.IdAparat, &aparat.Nama, &aparat.Jabatan, &aparat.NoTelepon, &aparat.Email, &aparat.Status, &aparat.PeriodeMulai, &aparat.PeriodeSelesai, &aparat.Foto)
+	aparat, err := scanAparat(tx.QueryRowContext(ctx, query, idAparat))
 	if err != nil {
 		return models.Aparat{}, err
 	}
@@ -74,21 +87,94 @@ func (a *aparatRepositoryImpl) GetAparatById(ctx context.Context, tx *sql.Tx, id
 
 // UpdateAparat implements AparatRepository.
 func (a *aparatRepositoryImpl) UpdateAparat(ctx context.Context, tx *sql.Tx, aparat models.Aparat) error {
-	query := "UPDATE aparat SET nama = ?, jabatan = ?, no_telepon = ?, email = ?, status = ?, periode_mulai = ?, periode_selesai = ?, foto = ? WHERE id_aparat = ?"
+	query := "UPDATE aparat SET nama = ?, jabatan = ?, no_telepon = ?, email = ?, status = ?, periode_mulai = ?, periode_selesai = ?, foto = ?, varian_foto = ? WHERE id_aparat = ?"
 
-	_, err := tx.ExecContext(ctx, query, aparat.Nama, aparat.Jabatan, aparat.NoTelepon, aparat.Email, aparat.Status, aparat.PeriodeMulai, aparat.PeriodeSelesai, aparat.Foto, aparat.IdAparat)
+	_, err := tx.ExecContext(ctx, query, aparat.Nama, aparat.Jabatan, aparat.NoTelepon, aparat.Email, aparat.Status, aparat.PeriodeMulai, aparat.PeriodeSelesai, aparat.Foto, aparat.VarianFoto, aparat.IdAparat)
 	return err
 }
 
-// DeleteAparat implements AparatRepository.
-func (a *aparatRepositoryImpl) DeleteAparat(ctx context.Context, tx *sql.Tx, idAparat string) error {
-	query := "DELETE FROM aparat WHERE id_aparat = ?"
+// TrashAparat implements AparatRepository.
+// Aparat dipindahkan ke tempat sampah, foto di storage tetap disimpan sampai aparat dihapus permanen.
+func (a *aparatRepositoryImpl) TrashAparat(ctx context.Context, tx *sql.Tx, idAparat []string, deletedAt time.Time) error {
+	if len(idAparat) == 0 {
+		return nil
+	}
+
+	args := []interface{}{deletedAt}
+	for _, id := range idAparat {
+		args = append(args, id)
+	}
+
+	query := "UPDATE aparat SET deleted_at = ? WHERE deleted_at IS NULL AND id_aparat IN (?" + strings.Repeat(",?", len(idAparat)-1) + ")"
+	_, err := tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+// GetDeletedAparat implements AparatRepository.
+// Aparat diurutkan dari yang terakhir dihapus.
+func (a *aparatRepositoryImpl) GetDeletedAparat(ctx context.Context, tx *sql.Tx, filter models.TrashFilter) ([]models.Aparat, int, error) {
+	where := " WHERE deleted_at IS NOT NULL"
+	var args []interface{}
+
+	if !filter.Before.IsZero() {
+		where += " AND deleted_at < ?"
+		args = append(args, filter.Before)
+	}
+
+	var total int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM aparat"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := "SELECT " + aparatColumns + " FROM aparat" + where + " ORDER BY deleted_at DESC, id_aparat"
+	if filter.Limit > 0 {
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, filter.Limit, filter.Offset)
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var aparats []models.Aparat
+	for rows.Next() {
+		aparat, err := scanAparat(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		aparats = append(aparats, aparat)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return aparats, total, nil
+}
+
+// GetDeletedAparatById implements AparatRepository.
+func (a *aparatRepositoryImpl) GetDeletedAparatById(ctx context.Context, tx *sql.Tx, idAparat string) (models.Aparat, error) {
+	query := "SELECT " + aparatColumns + " FROM aparat WHERE id_aparat = ? AND deleted_at IS NOT NULL"
+
+	aparat, err := scanAparat(tx.QueryRowContext(ctx, query, idAparat))
+	if err != nil {
+		return models.Aparat{}, err
+	}
+
+	return aparat, nil
+}
 
+// RestoreAparat implements AparatRepository.
+func (a *aparatRepositoryImpl) RestoreAparat(ctx context.Context, tx *sql.Tx, idAparat string) error {
+	query := "UPDATE aparat SET deleted_at = NULL WHERE id_aparat = ?"
 	_, err := tx.ExecContext(ctx, query, idAparat)
 	return err
 }
 
 // BulkDeleteAparat implements AparatRepository.
+// Menghapus aparat secara permanen, dipakai saat tempat sampah dikosongkan.
 func (a *aparatRepositoryImpl) BulkDeleteAparat(ctx context.Context, tx *sql.Tx, idAparat []string) error {
 	if len(idAparat) == 0 {
 		return nil // Tidak ada yang perlu dihapus
@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+)
+
+type AuditRepository interface {
+	AddAudit(ctx context.Context, tx *sql.Tx, audit models.AuditLog) error
+	GetAllAudit(ctx context.Context, tx *sql.Tx, filter models.AuditFilter) ([]models.AuditLog, int, error)
+	GetLastAuditTimes(ctx context.Context, tx *sql.Tx, entity string, entityIds []string) (map[string]time.Time, error)
+}
+
+type auditRepositoryImpl struct {
+}
+
+func NewAuditRepository() AuditRepository {
+	return &auditRepositoryImpl{}
+}
+
+// AddAudit implements AuditRepository.
+func (a *auditRepositoryImpl) AddAudit(ctx context.Context, tx *sql.Tx, audit models.AuditLog) error {
+	query := "INSERT INTO audit_log (id_audit, id_admin, username, action, entity, entity_id, data_before, data_after, created_at) VALUES (?, NULLIF(?, ''), ?, ?, ?, ?, NULLIF(?, ''), NULLIF(?, ''), ?)"
+
+	_, err := tx.ExecContext(ctx, query, audit.IdAudit, audit.IdAdmin, audit.Username, audit.Action, audit.Entity, audit.EntityId, audit.Before, audit.After, audit.CreatedAt)
+	return err
+}
+
+// GetAllAudit implements AuditRepository.
+func (a *auditRepositoryImpl) GetAllAudit(ctx context.Context, tx *sql.Tx, filter models.AuditFilter) ([]models.AuditLog, int, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.IdAdmin != "" {
+		conditions = append(conditions, "id_admin = ?")
+		args = append(args, filter.IdAdmin)
+	}
+	if filter.Action != "" {
+		conditions = append(conditions, "action = ?")
+		args = append(args, filter.Action)
+	}
+	if filter.Entity != "" {
+		conditions = append(conditions, "entity = ?")
+		args = append(args, filter.Entity)
+	}
+	if filter.EntityId != "" {
+		conditions = append(conditions, "entity_id = ?")
+		args = append(args, filter.EntityId)
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, filter.To)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM audit_log"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT id_audit, COALESCE(id_admin, ''), username, action, entity, entity_id,
+			COALESCE(data_before, ''), COALESCE(data_after, ''), created_at
+		FROM audit_log` + where + `
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := tx.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var audits []models.AuditLog
+	for rows.Next() {
+		var audit models.AuditLog
+		err := rows.Scan(&audit.IdAudit, &audit.IdAdmin, &audit.Username, &audit.Action, &audit.Entity, &audit.EntityId, &audit.Before, &audit.After, &audit.CreatedAt)
+		if err != nil {
+			return nil, 0, err
+		}
+		audits = append(audits, audit)
+	}
+
+	return audits, total, rows.Err()
+}
+
+// GetLastAuditTimes implements AuditRepository.
+// Mengembalikan waktu audit terakhir setiap entity, entity tanpa audit tidak ada di map.
+func (a *auditRepositoryImpl) GetLastAuditTimes(ctx context.Context, tx *sql.Tx, entity string, entityIds []string) (map[string]time.Time, error) {
+	lastAudit := make(map[string]time.Time)
+	if len(entityIds) == 0 {
+		return lastAudit, nil
+	}
+
+	query := "SELECT entity_id, MAX(created_at) FROM audit_log WHERE entity = ? AND entity_id IN (?" + strings.Repeat(",?", len(entityIds)-1) + ") GROUP BY entity_id"
+
+	args := []interface{}{entity}
+	for _, id := range entityIds {
+		args = append(args, id)
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entityId string
+		var createdAt time.Time
+		if err := rows.Scan(&entityId, &createdAt); err != nil {
+			return nil, err
+		}
+		lastAudit[entityId] = createdAt
+	}
+
+	return lastAudit, rows.Err()
+}
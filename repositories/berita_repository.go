@@ -4,20 +4,36 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/syrlramadhan/desa-sukamaju-api/models"
 )
 
 type BeritaRepository interface {
 	AddBerita(ctx context.Context, tx *sql.Tx, berita models.Berita, galeriList []models.Galeri) error
-	AddPhoto(ctx context.Context, tx *sql.Tx, photo models.Galeri) error
-	GetAllBerita(ctx context.Context, tx *sql.Tx) ([]models.Berita, error)
+	GetAllBerita(ctx context.Context, tx *sql.Tx, filter models.BeritaFilter) ([]models.Berita, int, error)
+	GetAllPhotos(ctx context.Context, tx *sql.Tx) ([]models.Galeri, error)
 	GetBeritaById(ctx context.Context, tx *sql.Tx, idBerita string) (models.Berita, error)
+	GetDeletedBeritaById(ctx context.Context, tx *sql.Tx, idBerita string) (models.Berita, error)
 	UpdateBerita(ctx context.Context, tx *sql.Tx, berita models.Berita) error
+	UpdateStatusBerita(ctx context.Context, tx *sql.Tx, idBerita, status string) error
+	GetDueBeritaIds(ctx context.Context, tx *sql.Tx, now time.Time) ([]string, error)
+	GetNextScheduleTime(ctx context.Context, tx *sql.Tx, now time.Time) (time.Time, error)
+	GetBeritaIdBySlug(ctx context.Context, tx *sql.Tx, slug string) (string, error)
+	IsSlugTaken(ctx context.Context, tx *sql.Tx, slug, idBerita string) (bool, error)
+	AddSlugHistory(ctx context.Context, tx *sql.Tx, slug, idBerita string, createdAt time.Time) error
+	DeleteSlugHistory(ctx context.Context, tx *sql.Tx, slug string) error
+	UpdateSlugBerita(ctx context.Context, tx *sql.Tx, idBerita, slug string) error
+	SetBeritaTags(ctx context.Context, tx *sql.Tx, idBerita string, idTags []string) error
+	GetBeritaWithoutSlug(ctx context.Context, tx *sql.Tx) ([]models.Berita, error)
+	UpdateDeskripsiHTMLBerita(ctx context.Context, tx *sql.Tx, idBerita, deskripsiHTML, deskripsiText string) error
+	GetBeritaWithoutDeskripsiHTML(ctx context.Context, tx *sql.Tx) ([]models.Berita, error)
+	TrashBerita(ctx context.Context, tx *sql.Tx, idBerita string, deletedAt time.Time) error
+	RestoreBerita(ctx context.Context, tx *sql.Tx, idBerita string) error
 	DeleteBerita(ctx context.Context, tx *sql.Tx, idBerita string) error
-	DeletePhotoByFilename(ctx context.Context, tx *sql.Tx, filename string) error
-	BulkDeletePhoto(ctx context.Context, tx *sql.Tx, filenames []string) error
 	GetPhotosByBeritaId(ctx context.Context, tx *sql.Tx, idBerita string) ([]models.Galeri, error)
+	SetCoverPhoto(ctx context.Context, tx *sql.Tx, idBerita, idGaleri string) error
 	DeletePhotosByBeritaId(ctx context.Context, tx *sql.Tx, idBerita string) error
 }
 
@@ -30,19 +46,16 @@ func NewBeritaRepository() BeritaRepository {
 // AddBerita implements BeritaRepository.
 func (b *beritaRepositoryImpl) AddBerita(ctx context.Context, tx *sql.Tx, berita models.Berita, galeriList []models.Galeri) error {
 	// Insert berita
-	query := "INSERT INTO berita (id_berita, judul_berita, kategori, tanggal_pelaksanaan, deskripsi) VALUES (?, ?, ?, ?, ?)"
+	query := "INSERT INTO berita (id_berita, judul_berita, slug, id_kategori, tanggal_pelaksanaan, deskripsi, deskripsi_html, deskripsi_text, status, publish_at, expire_at, created_at) VALUES (?, ?, ?, NULLIF(?, ''), ?, ?, ?, ?, ?, ?, ?, ?)"
 
-	_, err := tx.ExecContext(ctx, query, berita.IdBerita, berita.JudulBerita, berita.Kategori, berita.TanggalPelaksanaan, berita.Deskripsi)
+	_, err := tx.ExecContext(ctx, query, berita.IdBerita, berita.JudulBerita, berita.Slug, berita.IdKategori, berita.TanggalPelaksanaan, berita.Deskripsi, berita.DeskripsiHTML, berita.DeskripsiText, berita.Status, berita.PublishAt, berita.ExpireAt, berita.CreatedAt)
 	if err != nil {
 		return err
 	}
 
 	// Insert multiple galeri
-	queryGaleri := "INSERT INTO galeri (id_galeri, id_berita, gambar) VALUES (?, ?, ?)"
-
 	for _, galeri := range galeriList {
-		_, err = tx.ExecContext(ctx, queryGaleri, galeri.IdGaleri, galeri.IdBerita, galeri.Gambar)
-		if err != nil {
+		if err = insertGaleri(ctx, tx, galeri); err != nil {
 			return err
 		}
 	}
@@ -50,14 +63,31 @@ func (b *beritaRepositoryImpl) AddBerita(ctx context.Context, tx *sql.Tx, berita
 	return nil
 }
 
-// AddPhoto implements BeritaRepository.
-func (b *beritaRepositoryImpl) AddPhoto(ctx context.Context, tx *sql.Tx, photo models.Galeri) error {
-	query := "INSERT INTO galeri (id_galeri, id_berita, gambar) VALUES (?, ?, ?)"
-	_, err := tx.ExecContext(ctx, query, photo.IdGaleri, photo.IdBerita, photo.Gambar)
+// SetCoverPhoto implements BeritaRepository.
+// Foto lain pada berita yang sama otomatis tidak lagi menjadi sampul.
+func (b *beritaRepositoryImpl) SetCoverPhoto(ctx context.Context, tx *sql.Tx, idBerita, idGaleri string) error {
+	query := "UPDATE galeri SET is_cover = (id_galeri = ?) WHERE id_berita = ?"
+	_, err := tx.ExecContext(ctx, query, idGaleri, idBerita)
+	return err
+}
+
+// TrashBerita implements BeritaRepository.
+// Berita dipindahkan ke tempat sampah beserta fotonya, slug tetap dipakai sampai berita dihapus permanen.
+func (b *beritaRepositoryImpl) TrashBerita(ctx context.Context, tx *sql.Tx, idBerita string, deletedAt time.Time) error {
+	query := "UPDATE berita SET deleted_at = ? WHERE id_berita = ? AND deleted_at IS NULL"
+	_, err := tx.ExecContext(ctx, query, deletedAt, idBerita)
+	return err
+}
+
+// RestoreBerita implements BeritaRepository.
+func (b *beritaRepositoryImpl) RestoreBerita(ctx context.Context, tx *sql.Tx, idBerita string) error {
+	query := "UPDATE berita SET deleted_at = NULL WHERE id_berita = ?"
+	_, err := tx.ExecContext(ctx, query, idBerita)
 	return err
 }
 
 // DeleteBerita implements BeritaRepository.
+// Menghapus berita secara permanen, dipakai saat tempat sampah dikosongkan.
 func (b *beritaRepositoryImpl) DeleteBerita(ctx context.Context, tx *sql.Tx, idBerita string) error {
 	// Hapus galeri terlebih dahulu (foreign key constraint)
 	queryDeleteGaleri := "DELETE FROM galeri WHERE id_berita = ?"
@@ -76,38 +106,10 @@ func (b *beritaRepositoryImpl) DeleteBerita(ctx context.Context, tx *sql.Tx, idB
 	return nil
 }
 
-// DeletePhotoByFilename implements BeritaRepository.
-func (b *beritaRepositoryImpl) DeletePhotoByFilename(ctx context.Context, tx *sql.Tx, filename string) error {
-	query := "DELETE FROM galeri WHERE gambar = ?"
-	_, err := tx.ExecContext(ctx, query, filename)
-	return err
-}
-
-// BulkDeletePhoto implements BeritaRepository.
-func (b *beritaRepositoryImpl) BulkDeletePhoto(ctx context.Context, tx *sql.Tx, filenames []string) error {
-	if len(filenames) == 0 {
-		return nil
-	}
-
-	// Buat placeholder untuk query IN
-	placeholders := ""
-	args := make([]interface{}, len(filenames))
-	for i, filename := range filenames {
-		if i > 0 {
-			placeholders += ","
-		}
-		placeholders += "?"
-		args[i] = filename
-	}
-
-	query := fmt.Sprintf("DELETE FROM galeri WHERE gambar IN (%s)", placeholders)
-	_, err := tx.ExecContext(ctx, query, args...)
-	return err
-}
-
 // GetPhotosByBeritaId implements BeritaRepository.
+// Foto di tempat sampah tidak ikut dikembalikan.
 func (b *beritaRepositoryImpl) GetPhotosByBeritaId(ctx context.Context, tx *sql.Tx, idBerita string) ([]models.Galeri, error) {
-	query := "SELECT id_galeri, id_berita, gambar FROM galeri WHERE id_berita = ?"
+	query := "SELECT " + galeriColumns + " FROM galeri WHERE id_berita = ? AND deleted_at IS NULL ORDER BY urutan, id_galeri"
 	rows, err := tx.QueryContext(ctx, query, idBerita)
 	if err != nil {
 		return nil, err
@@ -116,8 +118,7 @@ func (b *beritaRepositoryImpl) GetPhotosByBeritaId(ctx context.Context, tx *sql.
 
 	var photos []models.Galeri
 	for rows.Next() {
-		var photo models.Galeri
-		err := rows.Scan(&photo.IdGaleri, &photo.IdBerita, &photo.Gambar)
+		photo, err := scanGaleri(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -135,98 +136,281 @@ func (b *beritaRepositoryImpl) DeletePhotosByBeritaId(ctx context.Context, tx *s
 }
 
 // GetAllBerita implements BeritaRepository.
-func (b *beritaRepositoryImpl) GetAllBerita(ctx context.Context, tx *sql.Tx) ([]models.Berita, error) {
+func (b *beritaRepositoryImpl) GetAllBerita(ctx context.Context, tx *sql.Tx, filter models.BeritaFilter) ([]models.Berita, int, error) {
+	conditions := []string{"b.deleted_at IS NULL"}
+	var args []interface{}
+
+	if filter.Trashed {
+		conditions[0] = "b.deleted_at IS NOT NULL"
+	}
+	if !filter.DeletedBefore.IsZero() {
+		conditions = append(conditions, "b.deleted_at < ?")
+		args = append(args, filter.DeletedBefore)
+	}
+	if len(filter.Statuses) > 0 {
+		conditions = append(conditions, "b.status IN (?"+strings.Repeat(",?", len(filter.Statuses)-1)+")")
+		for _, status := range filter.Statuses {
+			args = append(args, status)
+		}
+	}
+	if !filter.VisibleAt.IsZero() {
+		conditions = append(conditions, "(b.publish_at IS NULL OR b.publish_at <= ?) AND (b.expire_at IS NULL OR b.expire_at > ?)")
+		args = append(args, filter.VisibleAt, filter.VisibleAt)
+	}
+	if filter.Kategori != "" {
+		conditions = append(conditions, "(k.slug = ? OR k.nama = ?)")
+		args = append(args, filter.Kategori, filter.Kategori)
+	}
+	if filter.Tag != "" {
+		conditions = append(conditions, "b.id_berita IN (SELECT bt.id_berita FROM berita_tag bt JOIN tag t ON t.id_tag = bt.id_tag WHERE t.slug = ?)")
+		args = append(args, filter.Tag)
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "b.tanggal_pelaksanaan >= ?")
+		args = append(args, filter.From.Format("2006-01-02"))
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "b.tanggal_pelaksanaan <= ?")
+		args = append(args, filter.To.Format("2006-01-02"))
+	}
+
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	from := " FROM berita b LEFT JOIN kategori_berita k ON k.id_kategori = b.id_kategori"
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*)"+from+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	// Kolom dan arah urutan sudah divalidasi di service, di sini hanya dipetakan ke whitelist
+	sortColumn := "b.created_at"
+	switch filter.SortBy {
+	case models.BeritaSortTanggalPelaksanaan:
+		sortColumn = "b.tanggal_pelaksanaan"
+	case models.BeritaSortPublishedAt:
+		sortColumn = "COALESCE(b.publish_at, b.created_at)"
+	}
+	sortOrder := "DESC"
+	if filter.SortOrder == models.SortOrderAsc {
+		sortOrder = "ASC"
+	}
+	if filter.Trashed {
+		sortColumn, sortOrder = "b.deleted_at", "DESC"
+	}
+
 	query := `
 		SELECT 
 			b.id_berita, 
 			b.judul_berita, 
-			b.kategori, 
-			b.tanggal_pelaksanaan, 
+			COALESCE(b.slug, '') as slug,
+			COALESCE(b.id_kategori, '') as id_kategori,
+			COALESCE(k.nama, '') as kategori, 
+			COALESCE(k.slug, '') as kategori_slug,
+			DATE_FORMAT(b.tanggal_pelaksanaan, '%Y-%m-%d') as tanggal_pelaksanaan, 
 			b.deskripsi,
-			COALESCE(g.id_galeri, '') as id_galeri,
-			COALESCE(g.gambar, '') as gambar
-		FROM berita b
-		LEFT JOIN galeri g ON b.id_berita = g.id_berita
-		ORDER BY b.id_berita, g.id_galeri
+			COALESCE(b.deskripsi_html, '') as deskripsi_html,
+			b.status,
+			b.publish_at,
+			b.expire_at,
+			b.created_at,
+			b.deleted_at` + from + where + `
+		ORDER BY ` + sortColumn + ` ` + sortOrder + `, b.id_berita
+		LIMIT ? OFFSET ?
 	`
 
-	rows, err := tx.QueryContext(ctx, query)
+	rows, err := tx.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer rows.Close()
 
-	// Map untuk menyimpan berita berdasarkan ID
-	beritaMap := make(map[string]*models.Berita)
-	var beritaOrder []string // Untuk menjaga urutan
-
+	var beritaList []models.Berita
+	var idBeritaList []string
 	for rows.Next() {
 		var berita models.Berita
-		var galeri models.Galeri
-
 		err := rows.Scan(
 			&berita.IdBerita,
 			&berita.JudulBerita,
+			&berita.Slug,
+			&berita.IdKategori,
 			&berita.Kategori,
+			&berita.KategoriSlug,
 			&berita.TanggalPelaksanaan,
 			&berita.Deskripsi,
-			&galeri.IdGaleri,
-			&galeri.Gambar,
+			&berita.DeskripsiHTML,
+			&berita.Status,
+			&berita.PublishAt,
+			&berita.ExpireAt,
+			&berita.CreatedAt,
+			&berita.DeletedAt,
 		)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		beritaList = append(beritaList, berita)
+		idBeritaList = append(idBeritaList, berita.IdBerita)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	rows.Close()
+
+	// Ambil galeri hanya untuk berita di halaman ini
+	photoMap, err := b.getPhotosByBeritaIds(ctx, tx, idBeritaList)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tagMap, err := b.getTagsByBeritaIds(ctx, tx, idBeritaList)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range beritaList {
+		beritaList[i].GambarBerita = photoMap[beritaList[i].IdBerita]
+		beritaList[i].Tags = tagMap[beritaList[i].IdBerita]
+	}
+
+	return beritaList, total, nil
+}
+
+func (b *beritaRepositoryImpl) getPhotosByBeritaIds(ctx context.Context, tx *sql.Tx, idBerita []string) (map[string][]models.Galeri, error) {
+	photoMap := make(map[string][]models.Galeri)
+	if len(idBerita) == 0 {
+		return photoMap, nil
+	}
+
+	placeholders := strings.Repeat("?,", len(idBerita))
+	placeholders = placeholders[:len(placeholders)-1]
+
+	args := make([]interface{}, len(idBerita))
+	for i, id := range idBerita {
+		args[i] = id
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM galeri WHERE id_berita IN (%s) AND deleted_at IS NULL ORDER BY urutan, id_galeri", galeriColumns, placeholders)
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		photo, err := scanGaleri(rows)
 		if err != nil {
 			return nil, err
 		}
+		photoMap[photo.IdBerita] = append(photoMap[photo.IdBerita], photo)
+	}
+
+	return photoMap, rows.Err()
+}
+
+func (b *beritaRepositoryImpl) getTagsByBeritaIds(ctx context.Context, tx *sql.Tx, idBerita []string) (map[string][]models.Tag, error) {
+	tagMap := make(map[string][]models.Tag)
+	if len(idBerita) == 0 {
+		return tagMap, nil
+	}
+
+	args := make([]interface{}, len(idBerita))
+	for i, id := range idBerita {
+		args[i] = id
+	}
+
+	query := `
+		SELECT bt.id_berita, t.id_tag, t.nama, t.slug, t.created_at
+		FROM berita_tag bt
+		JOIN tag t ON t.id_tag = bt.id_tag
+		WHERE bt.id_berita IN (?` + strings.Repeat(",?", len(idBerita)-1) + `)
+		ORDER BY t.nama
+	`
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-		// Cek apakah berita sudah ada di map
-		if existingBerita, exists := beritaMap[berita.IdBerita]; exists {
-			// Jika berita sudah ada, tambahkan galeri ke list
-			if galeri.IdGaleri != "" { // Hanya tambahkan jika ada galeri
-				galeri.IdBerita = berita.IdBerita
-				existingBerita.GambarBerita = append(existingBerita.GambarBerita, galeri)
-			}
-		} else {
-			// Jika berita belum ada, buat entry baru
-			newBerita := berita
-			if galeri.IdGaleri != "" { // Hanya tambahkan jika ada galeri
-				galeri.IdBerita = berita.IdBerita
-				newBerita.GambarBerita = []models.Galeri{galeri}
-			} else {
-				newBerita.GambarBerita = []models.Galeri{} // Empty slice jika tidak ada galeri
-			}
-
-			beritaMap[berita.IdBerita] = &newBerita
-			beritaOrder = append(beritaOrder, berita.IdBerita)
+	for rows.Next() {
+		var idBerita string
+		var tag models.Tag
+		if err := rows.Scan(&idBerita, &tag.IdTag, &tag.Nama, &tag.Slug, &tag.CreatedAt); err != nil {
+			return nil, err
 		}
+		tagMap[idBerita] = append(tagMap[idBerita], tag)
 	}
 
-	if err = rows.Err(); err != nil {
+	return tagMap, rows.Err()
+}
+
+// GetAllPhotos implements BeritaRepository.
+func (b *beritaRepositoryImpl) GetAllPhotos(ctx context.Context, tx *sql.Tx) ([]models.Galeri, error) {
+	query := "SELECT " + galeriColumns + " FROM galeri"
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	// Convert map ke slice dengan urutan yang konsisten
-	var beritaList []models.Berita
-	for _, idBerita := range beritaOrder {
-		beritaList = append(beritaList, *beritaMap[idBerita])
+	var photos []models.Galeri
+	for rows.Next() {
+		photo, err := scanGaleri(rows)
+		if err != nil {
+			return nil, err
+		}
+		photos = append(photos, photo)
 	}
 
-	return beritaList, nil
+	return photos, rows.Err()
 }
 
 // GetBeritaById implements BeritaRepository.
+// Berita di tempat sampah dianggap tidak ada.
 func (b *beritaRepositoryImpl) GetBeritaById(ctx context.Context, tx *sql.Tx, idBerita string) (models.Berita, error) {
+	return b.getBerita(ctx, tx, idBerita, false)
+}
+
+// GetDeletedBeritaById implements BeritaRepository.
+func (b *beritaRepositoryImpl) GetDeletedBeritaById(ctx context.Context, tx *sql.Tx, idBerita string) (models.Berita, error) {
+	return b.getBerita(ctx, tx, idBerita, true)
+}
+
+func (b *beritaRepositoryImpl) getBerita(ctx context.Context, tx *sql.Tx, idBerita string, trashed bool) (models.Berita, error) {
+	deletedCondition := "b.deleted_at IS NULL"
+	if trashed {
+		deletedCondition = "b.deleted_at IS NOT NULL"
+	}
+
 	query := `
 		SELECT 
 			b.id_berita, 
 			b.judul_berita, 
-			b.kategori, 
-			b.tanggal_pelaksanaan, 
+			COALESCE(b.slug, '') as slug,
+			COALESCE(b.id_kategori, '') as id_kategori,
+			COALESCE(k.nama, '') as kategori, 
+			COALESCE(k.slug, '') as kategori_slug,
+			DATE_FORMAT(b.tanggal_pelaksanaan, '%Y-%m-%d') as tanggal_pelaksanaan, 
 			b.deskripsi,
+			COALESCE(b.deskripsi_html, '') as deskripsi_html,
+			b.status,
+			b.publish_at,
+			b.expire_at,
+			b.created_at,
+			b.deleted_at,
 			COALESCE(g.id_galeri, '') as id_galeri,
-			COALESCE(g.gambar, '') as gambar
+			COALESCE(g.gambar, '') as gambar,
+			g.varian_gambar,
+			COALESCE(g.urutan, 0) as urutan,
+			COALESCE(g.caption, '') as caption,
+			COALESCE(g.alt_text, '') as alt_text,
+			COALESCE(g.is_cover, 0) as is_cover
 		FROM berita b
-		LEFT JOIN galeri g ON b.id_berita = g.id_berita
-		WHERE b.id_berita = ?
-		ORDER BY g.id_galeri
+		LEFT JOIN kategori_berita k ON k.id_kategori = b.id_kategori
+		LEFT JOIN galeri g ON b.id_berita = g.id_berita AND g.deleted_at IS NULL
+		WHERE b.id_berita = ? AND ` + deletedCondition + `
+		ORDER BY g.urutan, g.id_galeri
 	`
 
 	rows, err := tx.QueryContext(ctx, query, idBerita)
@@ -245,11 +429,25 @@ func (b *beritaRepositoryImpl) GetBeritaById(ctx context.Context, tx *sql.Tx, id
 		err := rows.Scan(
 			&berita.IdBerita,
 			&berita.JudulBerita,
+			&berita.Slug,
+			&berita.IdKategori,
 			&berita.Kategori,
+			&berita.KategoriSlug,
 			&berita.TanggalPelaksanaan,
 			&berita.Deskripsi,
+			&berita.DeskripsiHTML,
+			&berita.Status,
+			&berita.PublishAt,
+			&berita.ExpireAt,
+			&berita.CreatedAt,
+			&berita.DeletedAt,
 			&galeri.IdGaleri,
 			&galeri.Gambar,
+			&galeri.Varian,
+			&galeri.Urutan,
+			&galeri.Caption,
+			&galeri.AltText,
+			&galeri.IsCover,
 		)
 		if err != nil {
 			return models.Berita{}, err
@@ -279,12 +477,204 @@ func (b *beritaRepositoryImpl) GetBeritaById(ctx context.Context, tx *sql.Tx, id
 	// Set galeri ke berita
 	berita.GambarBerita = galeriList
 
+	tagMap, err := b.getTagsByBeritaIds(ctx, tx, []string{berita.IdBerita})
+	if err != nil {
+		return models.Berita{}, err
+	}
+	berita.Tags = tagMap[berita.IdBerita]
+
 	return berita, nil
 }
 
 // UpdateBerita implements BeritaRepository.
 func (b *beritaRepositoryImpl) UpdateBerita(ctx context.Context, tx *sql.Tx, berita models.Berita) error {
-	query := "UPDATE berita SET judul_berita = ?, kategori = ?, tanggal_pelaksanaan = ?, deskripsi = ? WHERE id_berita = ?"
-	_, err := tx.ExecContext(ctx, query, berita.JudulBerita, berita.Kategori, berita.TanggalPelaksanaan, berita.Deskripsi, berita.IdBerita)
+	query := "UPDATE berita SET judul_berita = ?, slug = ?, id_kategori = NULLIF(?, ''), tanggal_pelaksanaan = ?, deskripsi = ?, deskripsi_html = ?, deskripsi_text = ?, publish_at = ?, expire_at = ? WHERE id_berita = ?"
+	_, err := tx.ExecContext(ctx, query, berita.JudulBerita, berita.Slug, berita.IdKategori, berita.TanggalPelaksanaan, berita.Deskripsi, berita.DeskripsiHTML, berita.DeskripsiText, berita.PublishAt, berita.ExpireAt, berita.IdBerita)
+	return err
+}
+
+// UpdateStatusBerita implements BeritaRepository.
+func (b *beritaRepositoryImpl) UpdateStatusBerita(ctx context.Context, tx *sql.Tx, idBerita, status string) error {
+	query := "UPDATE berita SET status = ? WHERE id_berita = ?"
+	_, err := tx.ExecContext(ctx, query, status, idBerita)
+	return err
+}
+
+// GetDueBeritaIds implements BeritaRepository.
+// Mengembalikan berita terjadwal yang sudah waktunya terbit dan berita terbit yang sudah kedaluwarsa.
+func (b *beritaRepositoryImpl) GetDueBeritaIds(ctx context.Context, tx *sql.Tx, now time.Time) ([]string, error) {
+	query := `
+		SELECT id_berita FROM berita WHERE status = ? AND publish_at <= ? AND deleted_at IS NULL
+		UNION
+		SELECT id_berita FROM berita WHERE status = ? AND expire_at <= ? AND deleted_at IS NULL
+	`
+
+	rows, err := tx.QueryContext(ctx, query, models.BeritaStatusScheduled, now, models.BeritaStatusPublished, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var idBeritaList []string
+	for rows.Next() {
+		var idBerita string
+		if err := rows.Scan(&idBerita); err != nil {
+			return nil, err
+		}
+		idBeritaList = append(idBeritaList, idBerita)
+	}
+
+	return idBeritaList, rows.Err()
+}
+
+// GetNextScheduleTime implements BeritaRepository.
+// Mengembalikan waktu perubahan status terjadwal berikutnya setelah now, atau waktu nol jika tidak ada.
+func (b *beritaRepositoryImpl) GetNextScheduleTime(ctx context.Context, tx *sql.Tx, now time.Time) (time.Time, error) {
+	query := `
+		SELECT MIN(next_at) FROM (
+			SELECT MIN(publish_at) AS next_at FROM berita WHERE status = ? AND publish_at > ? AND deleted_at IS NULL
+			UNION ALL
+			SELECT MIN(expire_at) AS next_at FROM berita WHERE status = ? AND expire_at > ? AND deleted_at IS NULL
+		) AS schedule
+	`
+
+	var next sql.NullTime
+	err := tx.QueryRowContext(ctx, query, models.BeritaStatusScheduled, now, models.BeritaStatusPublished, now).Scan(&next)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return next.Time, nil
+}
+
+// GetBeritaIdBySlug implements BeritaRepository.
+// Slug dicari di slug aktif berita terlebih dahulu, lalu di riwayat slug lama.
+func (b *beritaRepositoryImpl) GetBeritaIdBySlug(ctx context.Context, tx *sql.Tx, slug string) (string, error) {
+	query := `
+		SELECT id_berita FROM (
+			SELECT id_berita, 0 AS priority FROM berita WHERE slug = ?
+			UNION ALL
+			SELECT id_berita, 1 AS priority FROM berita_slug_history WHERE slug = ?
+		) AS found
+		ORDER BY priority
+		LIMIT 1
+	`
+
+	var idBerita string
+	err := tx.QueryRowContext(ctx, query, slug, slug).Scan(&idBerita)
+	if err != nil {
+		return "", err
+	}
+
+	return idBerita, nil
+}
+
+// IsSlugTaken implements BeritaRepository.
+// Slug dianggap terpakai jika menjadi slug aktif atau slug lama milik berita lain.
+func (b *beritaRepositoryImpl) IsSlugTaken(ctx context.Context, tx *sql.Tx, slug, idBerita string) (bool, error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM berita WHERE slug = ? AND id_berita <> ?)
+			OR EXISTS (SELECT 1 FROM berita_slug_history WHERE slug = ? AND id_berita <> ?)
+	`
+
+	var taken bool
+	err := tx.QueryRowContext(ctx, query, slug, idBerita, slug, idBerita).Scan(&taken)
+	if err != nil {
+		return false, err
+	}
+
+	return taken, nil
+}
+
+// AddSlugHistory implements BeritaRepository.
+func (b *beritaRepositoryImpl) AddSlugHistory(ctx context.Context, tx *sql.Tx, slug, idBerita string, createdAt time.Time) error {
+	query := "INSERT INTO berita_slug_history (slug, id_berita, created_at) VALUES (?, ?, ?)"
+	_, err := tx.ExecContext(ctx, query, slug, idBerita, createdAt)
 	return err
-}
\ No newline at end of file
+}
+
+// DeleteSlugHistory implements BeritaRepository.
+func (b *beritaRepositoryImpl) DeleteSlugHistory(ctx context.Context, tx *sql.Tx, slug string) error {
+	query := "DELETE FROM berita_slug_history WHERE slug = ?"
+	_, err := tx.ExecContext(ctx, query, slug)
+	return err
+}
+
+// UpdateSlugBerita implements BeritaRepository.
+func (b *beritaRepositoryImpl) UpdateSlugBerita(ctx context.Context, tx *sql.Tx, idBerita, slug string) error {
+	query := "UPDATE berita SET slug = ? WHERE id_berita = ?"
+	_, err := tx.ExecContext(ctx, query, slug, idBerita)
+	return err
+}
+
+// GetBeritaWithoutSlug implements BeritaRepository.
+// Hanya mengisi id dan judul, digunakan untuk mengisi slug berita lama.
+func (b *beritaRepositoryImpl) GetBeritaWithoutSlug(ctx context.Context, tx *sql.Tx) ([]models.Berita, error) {
+	query := "SELECT id_berita, judul_berita FROM berita WHERE slug IS NULL ORDER BY created_at, id_berita"
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var beritaList []models.Berita
+	for rows.Next() {
+		var berita models.Berita
+		if err := rows.Scan(&berita.IdBerita, &berita.JudulBerita); err != nil {
+			return nil, err
+		}
+		beritaList = append(beritaList, berita)
+	}
+
+	return beritaList, rows.Err()
+}
+
+// UpdateDeskripsiHTMLBerita implements BeritaRepository.
+func (b *beritaRepositoryImpl) UpdateDeskripsiHTMLBerita(ctx context.Context, tx *sql.Tx, idBerita, deskripsiHTML, deskripsiText string) error {
+	query := "UPDATE berita SET deskripsi_html = ?, deskripsi_text = ? WHERE id_berita = ?"
+	_, err := tx.ExecContext(ctx, query, deskripsiHTML, deskripsiText, idBerita)
+	return err
+}
+
+// GetBeritaWithoutDeskripsiHTML implements BeritaRepository.
+// Hanya mengisi id dan deskripsi, digunakan untuk merender deskripsi berita lama
+// yang belum punya HTML atau teks pencarian.
+func (b *beritaRepositoryImpl) GetBeritaWithoutDeskripsiHTML(ctx context.Context, tx *sql.Tx) ([]models.Berita, error) {
+	query := "SELECT id_berita, deskripsi FROM berita WHERE deskripsi_html IS NULL OR deskripsi_text IS NULL ORDER BY created_at, id_berita"
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var beritaList []models.Berita
+	for rows.Next() {
+		var berita models.Berita
+		if err := rows.Scan(&berita.IdBerita, &berita.Deskripsi); err != nil {
+			return nil, err
+		}
+		beritaList = append(beritaList, berita)
+	}
+
+	return beritaList, rows.Err()
+}
+
+// SetBeritaTags implements BeritaRepository.
+// Mengganti seluruh tag berita dengan daftar tag yang diberikan.
+func (b *beritaRepositoryImpl) SetBeritaTags(ctx context.Context, tx *sql.Tx, idBerita string, idTags []string) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM berita_tag WHERE id_berita = ?", idBerita)
+	if err != nil {
+		return err
+	}
+
+	for _, idTag := range idTags {
+		_, err = tx.ExecContext(ctx, "INSERT INTO berita_tag (id_berita, id_tag) VALUES (?, ?)", idBerita, idTag)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+)
+
+type BeritaRevisiRepository interface {
+	AddRevisi(ctx context.Context, tx *sql.Tx, revisi models.BeritaRevisi) error
+	GetRevisiByBeritaId(ctx context.Context, tx *sql.Tx, idBerita string) ([]models.BeritaRevisi, error)
+	GetRevisi(ctx context.Context, tx *sql.Tx, idBerita string, versi int) (models.BeritaRevisi, error)
+	GetLastVersi(ctx context.Context, tx *sql.Tx, idBerita string) (int, error)
+}
+
+type beritaRevisiRepositoryImpl struct{}
+
+func NewBeritaRevisiRepository() BeritaRevisiRepository {
+	return &beritaRevisiRepositoryImpl{}
+}
+
+const beritaRevisiColumns = `
+	id_revisi,
+	id_berita,
+	versi,
+	judul_berita,
+	COALESCE(id_kategori, ''),
+	kategori,
+	DATE_FORMAT(tanggal_pelaksanaan, '%Y-%m-%d') as tanggal_pelaksanaan,
+	deskripsi,
+	publish_at,
+	expire_at,
+	COALESCE(id_admin, ''),
+	username,
+	created_at
+`
+
+// AddRevisi implements BeritaRevisiRepository.
+func (b *beritaRevisiRepositoryImpl) AddRevisi(ctx context.Context, tx *sql.Tx, revisi models.BeritaRevisi) error {
+	query := "INSERT INTO berita_revisi (id_revisi, id_berita, versi, judul_berita, id_kategori, kategori, tanggal_pelaksanaan, deskripsi, publish_at, expire_at, id_admin, username, created_at) VALUES (?, ?, ?, ?, NULLIF(?, ''), ?, ?, ?, ?, ?, NULLIF(?, ''), ?, ?)"
+
+	_, err := tx.ExecContext(ctx, query, revisi.IdRevisi, revisi.IdBerita, revisi.Versi, revisi.JudulBerita, revisi.IdKategori, revisi.Kategori, revisi.TanggalPelaksanaan, revisi.Deskripsi, revisi.PublishAt, revisi.ExpireAt, revisi.IdAdmin, revisi.Username, revisi.CreatedAt)
+	return err
+}
+
+// GetRevisiByBeritaId implements BeritaRevisiRepository.
+// Revisi diurutkan dari versi terbaru.
+func (b *beritaRevisiRepositoryImpl) GetRevisiByBeritaId(ctx context.Context, tx *sql.Tx, idBerita string) ([]models.BeritaRevisi, error) {
+	query := "SELECT " + beritaRevisiColumns + " FROM berita_revisi WHERE id_berita = ? ORDER BY versi DESC"
+
+	rows, err := tx.QueryContext(ctx, query, idBerita)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisiList []models.BeritaRevisi
+	for rows.Next() {
+		revisi, err := scanBeritaRevisi(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisiList = append(revisiList, revisi)
+	}
+
+	return revisiList, rows.Err()
+}
+
+// GetRevisi implements BeritaRevisiRepository.
+func (b *beritaRevisiRepositoryImpl) GetRevisi(ctx context.Context, tx *sql.Tx, idBerita string, versi int) (models.BeritaRevisi, error) {
+	query := "SELECT " + beritaRevisiColumns + " FROM berita_revisi WHERE id_berita = ? AND versi = ?"
+
+	return scanBeritaRevisi(tx.QueryRowContext(ctx, query, idBerita, versi))
+}
+
+// GetLastVersi implements BeritaRevisiRepository.
+// Mengembalikan 0 jika berita belum memiliki revisi.
+func (b *beritaRevisiRepositoryImpl) GetLastVersi(ctx context.Context, tx *sql.Tx, idBerita string) (int, error) {
+	query := "SELECT COALESCE(MAX(versi), 0) FROM berita_revisi WHERE id_berita = ?"
+
+	var versi int
+	err := tx.QueryRowContext(ctx, query, idBerita).Scan(&versi)
+	return versi, err
+}
+
+// rowScanner dipenuhi oleh *sql.Row dan *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanBeritaRevisi(row rowScanner) (models.BeritaRevisi, error) {
+	var revisi models.BeritaRevisi
+	err := row.Scan(
+		&revisi.IdRevisi,
+		&revisi.IdBerita,
+		&revisi.Versi,
+		&revisi.JudulBerita,
+		&revisi.IdKategori,
+		&revisi.Kategori,
+		&revisi.TanggalPelaksanaan,
+		&revisi.Deskripsi,
+		&revisi.PublishAt,
+		&revisi.ExpireAt,
+		&revisi.IdAdmin,
+		&revisi.Username,
+		&revisi.CreatedAt,
+	)
+	if err != nil {
+		return models.BeritaRevisi{}, err
+	}
+
+	return revisi, nil
+}
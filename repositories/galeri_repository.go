@@ -0,0 +1,198 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+)
+
+// GaleriRepository menangani foto galeri tanpa memandang pemiliknya, baik berita maupun album
+type GaleriRepository interface {
+	AddPhoto(ctx context.Context, tx *sql.Tx, photo models.Galeri) error
+	GetPhotoByFilename(ctx context.Context, tx *sql.Tx, filename string) (models.Galeri, error)
+	UpdatePhotoInfo(ctx context.Context, tx *sql.Tx, photo models.Galeri) error
+	UpdatePhotoUrutan(ctx context.Context, tx *sql.Tx, idGaleri string, urutan int) error
+	TrashPhotos(ctx context.Context, tx *sql.Tx, filenames []string, deletedAt time.Time) error
+	GetDeletedPhotos(ctx context.Context, tx *sql.Tx, filter models.TrashFilter) ([]models.Galeri, int, error)
+	GetDeletedPhotoByFilename(ctx context.Context, tx *sql.Tx, filename string) (models.Galeri, error)
+	RestorePhoto(ctx context.Context, tx *sql.Tx, photo models.Galeri) error
+	BulkDeletePhoto(ctx context.Context, tx *sql.Tx, filenames []string) error
+}
+
+type galeriRepositoryImpl struct{}
+
+func NewGaleriRepository() GaleriRepository {
+	return &galeriRepositoryImpl{}
+}
+
+// galeriColumns adalah kolom galeri dengan urutan yang sama seperti dibaca scanGaleri. Foto
+// dimiliki oleh berita atau album, kolom pemilik yang lain bernilai NULL.
+const galeriColumns = "id_galeri, COALESCE(id_berita, ''), COALESCE(id_album, ''), gambar, varian_gambar, urutan, caption, alt_text, is_cover, deleted_at"
+
+func scanGaleri(row rowScanner) (models.Galeri, error) {
+	var photo models.Galeri
+	err := row.Scan(&photo.IdGaleri, &photo.IdBerita, &photo.IdAlbum, &photo.Gambar, &photo.Varian, &photo.Urutan, &photo.Caption, &photo.AltText, &photo.IsCover, &photo.DeletedAt)
+	return photo, err
+}
+
+func scanGaleriRows(rows *sql.Rows) ([]models.Galeri, error) {
+	defer rows.Close()
+
+	var photos []models.Galeri
+	for rows.Next() {
+		photo, err := scanGaleri(rows)
+		if err != nil {
+			return nil, err
+		}
+		photos = append(photos, photo)
+	}
+
+	return photos, rows.Err()
+}
+
+func insertGaleri(ctx context.Context, tx *sql.Tx, photo models.Galeri) error {
+	query := "INSERT INTO galeri (id_galeri, id_berita, id_album, gambar, varian_gambar, urutan, caption, alt_text, is_cover) VALUES (?, NULLIF(?, ''), NULLIF(?, ''), ?, ?, ?, ?, ?, ?)"
+	_, err := tx.ExecContext(ctx, query, photo.IdGaleri, photo.IdBerita, photo.IdAlbum, photo.Gambar, photo.Varian, photo.Urutan, photo.Caption, photo.AltText, photo.IsCover)
+	return err
+}
+
+// AddPhoto implements GaleriRepository.
+func (g *galeriRepositoryImpl) AddPhoto(ctx context.Context, tx *sql.Tx, photo models.Galeri) error {
+	return insertGaleri(ctx, tx, photo)
+}
+
+// GetPhotoByFilename implements GaleriRepository.
+// Foto di tempat sampah dianggap tidak ada.
+func (g *galeriRepositoryImpl) GetPhotoByFilename(ctx context.Context, tx *sql.Tx, filename string) (models.Galeri, error) {
+	query := "SELECT " + galeriColumns + " FROM galeri WHERE gambar = ? AND deleted_at IS NULL"
+
+	photo, err := scanGaleri(tx.QueryRowContext(ctx, query, filename))
+	if err != nil {
+		return models.Galeri{}, err
+	}
+
+	return photo, nil
+}
+
+// UpdatePhotoInfo implements GaleriRepository.
+func (g *galeriRepositoryImpl) UpdatePhotoInfo(ctx context.Context, tx *sql.Tx, photo models.Galeri) error {
+	query := "UPDATE galeri SET caption = ?, alt_text = ? WHERE id_galeri = ?"
+	_, err := tx.ExecContext(ctx, query, photo.Caption, photo.AltText, photo.IdGaleri)
+	return err
+}
+
+// UpdatePhotoUrutan implements GaleriRepository.
+func (g *galeriRepositoryImpl) UpdatePhotoUrutan(ctx context.Context, tx *sql.Tx, idGaleri string, urutan int) error {
+	query := "UPDATE galeri SET urutan = ? WHERE id_galeri = ?"
+	_, err := tx.ExecContext(ctx, query, urutan, idGaleri)
+	return err
+}
+
+// TrashPhotos implements GaleriRepository.
+// Foto dipindahkan ke tempat sampah dan tidak lagi menjadi sampul, file di storage tetap disimpan.
+func (g *galeriRepositoryImpl) TrashPhotos(ctx context.Context, tx *sql.Tx, filenames []string, deletedAt time.Time) error {
+	if len(filenames) == 0 {
+		return nil
+	}
+
+	args := []interface{}{deletedAt}
+	for _, filename := range filenames {
+		args = append(args, filename)
+	}
+
+	query := "UPDATE galeri SET deleted_at = ?, is_cover = 0 WHERE deleted_at IS NULL AND gambar IN (?" + strings.Repeat(",?", len(filenames)-1) + ")"
+	_, err := tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+// GetDeletedPhotos implements GaleriRepository.
+// Foto diurutkan dari yang terakhir dihapus.
+func (g *galeriRepositoryImpl) GetDeletedPhotos(ctx context.Context, tx *sql.Tx, filter models.TrashFilter) ([]models.Galeri, int, error) {
+	conditions := []string{"deleted_at IS NOT NULL"}
+	var args []interface{}
+
+	if filter.IdBerita != "" {
+		conditions = append(conditions, "id_berita = ?")
+		args = append(args, filter.IdBerita)
+	}
+	if filter.IdAlbum != "" {
+		conditions = append(conditions, "id_album = ?")
+		args = append(args, filter.IdAlbum)
+	}
+	if !filter.Before.IsZero() {
+		conditions = append(conditions, "deleted_at < ?")
+		args = append(args, filter.Before)
+	}
+
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM galeri"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := "SELECT " + galeriColumns + " FROM galeri" + where + " ORDER BY deleted_at DESC, id_galeri"
+	if filter.Limit > 0 {
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, filter.Limit, filter.Offset)
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	photos, err := scanGaleriRows(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return photos, total, nil
+}
+
+// GetDeletedPhotoByFilename implements GaleriRepository.
+func (g *galeriRepositoryImpl) GetDeletedPhotoByFilename(ctx context.Context, tx *sql.Tx, filename string) (models.Galeri, error) {
+	query := "SELECT " + galeriColumns + " FROM galeri WHERE gambar = ? AND deleted_at IS NOT NULL"
+
+	photo, err := scanGaleri(tx.QueryRowContext(ctx, query, filename))
+	if err != nil {
+		return models.Galeri{}, err
+	}
+
+	return photo, nil
+}
+
+// RestorePhoto implements GaleriRepository.
+// Urutan dan status sampul diisi ulang karena posisi lama foto mungkin sudah ditempati foto lain.
+func (g *galeriRepositoryImpl) RestorePhoto(ctx context.Context, tx *sql.Tx, photo models.Galeri) error {
+	query := "UPDATE galeri SET deleted_at = NULL, urutan = ?, is_cover = ? WHERE id_galeri = ?"
+	_, err := tx.ExecContext(ctx, query, photo.Urutan, photo.IsCover, photo.IdGaleri)
+	return err
+}
+
+// BulkDeletePhoto implements GaleriRepository.
+// Menghapus foto secara permanen, dipakai saat tempat sampah dikosongkan.
+func (g *galeriRepositoryImpl) BulkDeletePhoto(ctx context.Context, tx *sql.Tx, filenames []string) error {
+	if len(filenames) == 0 {
+		return nil
+	}
+
+	// Buat placeholder untuk query IN
+	placeholders := ""
+	args := make([]interface{}, len(filenames))
+	for i, filename := range filenames {
+		if i > 0 {
+			placeholders += ","
+		}
+		placeholders += "?"
+		args[i] = filename
+	}
+
+	query := fmt.Sprintf("DELETE FROM galeri WHERE gambar IN (%s)", placeholders)
+	_, err := tx.ExecContext(ctx, query, args...)
+	return err
+}
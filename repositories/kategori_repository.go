@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+)
+
+type KategoriRepository interface {
+	AddKategori(ctx context.Context, tx *sql.Tx, kategori models.KategoriBerita) error
+	GetAllKategori(ctx context.Context, tx *sql.Tx, now time.Time) ([]models.KategoriBerita, error)
+	GetKategoriById(ctx context.Context, tx *sql.Tx, idKategori string) (models.KategoriBerita, error)
+	GetKategoriBySlugOrNama(ctx context.Context, tx *sql.Tx, value string) (models.KategoriBerita, error)
+	UpdateKategori(ctx context.Context, tx *sql.Tx, kategori models.KategoriBerita) error
+	DeleteKategori(ctx context.Context, tx *sql.Tx, idKategori string) error
+	CountBeritaByKategori(ctx context.Context, tx *sql.Tx, idKategori string) (int, error)
+	IsNamaTaken(ctx context.Context, tx *sql.Tx, nama, idKategori string) (bool, error)
+	IsSlugTaken(ctx context.Context, tx *sql.Tx, slug, idKategori string) (bool, error)
+	GetKategoriWithoutSlug(ctx context.Context, tx *sql.Tx) ([]models.KategoriBerita, error)
+	UpdateSlugKategori(ctx context.Context, tx *sql.Tx, idKategori, slug string) error
+}
+
+type kategoriRepositoryImpl struct{}
+
+func NewKategoriRepository() KategoriRepository {
+	return &kategoriRepositoryImpl{}
+}
+
+// AddKategori implements KategoriRepository.
+func (k *kategoriRepositoryImpl) AddKategori(ctx context.Context, tx *sql.Tx, kategori models.KategoriBerita) error {
+	query := "INSERT INTO kategori_berita (id_kategori, nama, slug, urutan, created_at) VALUES (?, ?, ?, ?, ?)"
+	_, err := tx.ExecContext(ctx, query, kategori.IdKategori, kategori.Nama, kategori.Slug, kategori.Urutan, kategori.CreatedAt)
+	return err
+}
+
+// GetAllKategori implements KategoriRepository.
+// Kategori diurutkan sesuai urutan tampil, jumlah berita hanya menghitung berita yang sedang tayang.
+func (k *kategoriRepositoryImpl) GetAllKategori(ctx context.Context, tx *sql.Tx, now time.Time) ([]models.KategoriBerita, error) {
+	query := `
+		SELECT k.id_kategori, k.nama, k.slug, k.urutan, k.created_at,
+			(SELECT COUNT(*) FROM berita WHERE id_kategori = k.id_kategori AND ` + beritaVisibleCondition + `) AS jumlah_berita
+		FROM kategori_berita k
+		ORDER BY k.urutan, k.nama
+	`
+
+	rows, err := tx.QueryContext(ctx, query, now, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var kategoriList []models.KategoriBerita
+	for rows.Next() {
+		var kategori models.KategoriBerita
+		err := rows.Scan(&kategori.IdKategori, &kategori.Nama, &kategori.Slug, &kategori.Urutan, &kategori.CreatedAt, &kategori.JumlahBerita)
+		if err != nil {
+			return nil, err
+		}
+		kategoriList = append(kategoriList, kategori)
+	}
+
+	return kategoriList, rows.Err()
+}
+
+// GetKategoriById implements KategoriRepository.
+func (k *kategoriRepositoryImpl) GetKategoriById(ctx context.Context, tx *sql.Tx, idKategori string) (models.KategoriBerita, error) {
+	query := "SELECT id_kategori, nama, slug, urutan, created_at FROM kategori_berita WHERE id_kategori = ?"
+
+	var kategori models.KategoriBerita
+	err := tx.QueryRowContext(ctx, query, idKategori).Scan(&kategori.IdKategori, &kategori.Nama, &kategori.Slug, &kategori.Urutan, &kategori.CreatedAt)
+	if err != nil {
+		return models.KategoriBerita{}, err
+	}
+
+	return kategori, nil
+}
+
+// GetKategoriBySlugOrNama implements KategoriRepository.
+// Nama dibandingkan tanpa membedakan huruf besar/kecil sesuai collation tabel.
+func (k *kategoriRepositoryImpl) GetKategoriBySlugOrNama(ctx context.Context, tx *sql.Tx, value string) (models.KategoriBerita, error) {
+	query := "SELECT id_kategori, nama, slug, urutan, created_at FROM kategori_berita WHERE slug = ? OR nama = ? ORDER BY slug = ? DESC LIMIT 1"
+
+	var kategori models.KategoriBerita
+	err := tx.QueryRowContext(ctx, query, value, value, value).Scan(&kategori.IdKategori, &kategori.Nama, &kategori.Slug, &kategori.Urutan, &kategori.CreatedAt)
+	if err != nil {
+		return models.KategoriBerita{}, err
+	}
+
+	return kategori, nil
+}
+
+// UpdateKategori implements KategoriRepository.
+func (k *kategoriRepositoryImpl) UpdateKategori(ctx context.Context, tx *sql.Tx, kategori models.KategoriBerita) error {
+	query := "UPDATE kategori_berita SET nama = ?, slug = ?, urutan = ? WHERE id_kategori = ?"
+	_, err := tx.ExecContext(ctx, query, kategori.Nama, kategori.Slug, kategori.Urutan, kategori.IdKategori)
+	return err
+}
+
+// DeleteKategori implements KategoriRepository.
+func (k *kategoriRepositoryImpl) DeleteKategori(ctx context.Context, tx *sql.Tx, idKategori string) error {
+	query := "DELETE FROM kategori_berita WHERE id_kategori = ?"
+	_, err := tx.ExecContext(ctx, query, idKategori)
+	return err
+}
+
+// CountBeritaByKategori implements KategoriRepository.
+// Menghitung seluruh berita dengan kategori tersebut, termasuk draft, arsip dan berita di tempat sampah.
+func (k *kategoriRepositoryImpl) CountBeritaByKategori(ctx context.Context, tx *sql.Tx, idKategori string) (int, error) {
+	query := "SELECT COUNT(*) FROM berita WHERE id_kategori = ?"
+
+	var total int
+	err := tx.QueryRowContext(ctx, query, idKategori).Scan(&total)
+	return total, err
+}
+
+// IsNamaTaken implements KategoriRepository.
+func (k *kategoriRepositoryImpl) IsNamaTaken(ctx context.Context, tx *sql.Tx, nama, idKategori string) (bool, error) {
+	query := "SELECT EXISTS (SELECT 1 FROM kategori_berita WHERE nama = ? AND id_kategori <> ?)"
+
+	var taken bool
+	err := tx.QueryRowContext(ctx, query, nama, idKategori).Scan(&taken)
+	return taken, err
+}
+
+// IsSlugTaken implements KategoriRepository.
+func (k *kategoriRepositoryImpl) IsSlugTaken(ctx context.Context, tx *sql.Tx, slug, idKategori string) (bool, error) {
+	query := "SELECT EXISTS (SELECT 1 FROM kategori_berita WHERE slug = ? AND id_kategori <> ?)"
+
+	var taken bool
+	err := tx.QueryRowContext(ctx, query, slug, idKategori).Scan(&taken)
+	return taken, err
+}
+
+// GetKategoriWithoutSlug implements KategoriRepository.
+// Hanya mengisi id dan nama, digunakan untuk mengisi slug kategori hasil migrasi.
+func (k *kategoriRepositoryImpl) GetKategoriWithoutSlug(ctx context.Context, tx *sql.Tx) ([]models.KategoriBerita, error) {
+	query := "SELECT id_kategori, nama FROM kategori_berita WHERE slug IS NULL ORDER BY created_at, nama"
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var kategoriList []models.KategoriBerita
+	for rows.Next() {
+		var kategori models.KategoriBerita
+		if err := rows.Scan(&kategori.IdKategori, &kategori.Nama); err != nil {
+			return nil, err
+		}
+		kategoriList = append(kategoriList, kategori)
+	}
+
+	return kategoriList, rows.Err()
+}
+
+// UpdateSlugKategori implements KategoriRepository.
+func (k *kategoriRepositoryImpl) UpdateSlugKategori(ctx context.Context, tx *sql.Tx, idKategori, slug string) error {
+	query := "UPDATE kategori_berita SET slug = ? WHERE id_kategori = ?"
+	_, err := tx.ExecContext(ctx, query, slug, idKategori)
+	return err
+}
@@ -10,6 +10,8 @@ import (
 type KontenRepository interface {
 	GetKontak(ctx context.Context, tx *sql.Tx, kontak models.Kontak) (models.Kontak, error)
 	UpdateKontak(ctx context.Context, tx *sql.Tx, kontak models.Kontak) error
+	AddKontak(ctx context.Context, tx *sql.Tx, kontak models.Kontak) error
+	IsKontakExists(ctx context.Context, tx *sql.Tx, idKontak string) (bool, error)
 }
 
 type kontenRepositoryImpl struct {
@@ -43,3 +45,24 @@ func (k *kontenRepositoryImpl) UpdateKontak(ctx context.Context, tx *sql.Tx, kon
 	
 	return err
 }
+
+// AddKontak implements KontenRepository.
+func (k *kontenRepositoryImpl) AddKontak(ctx context.Context, tx *sql.Tx, kontak models.Kontak) error {
+	query := "INSERT INTO kontak (id_kontak, email, telepon, facebook, instagram, youtube) VALUES (?, ?, ?, ?, ?, ?)"
+
+	_, err := tx.ExecContext(ctx, query, kontak.IdKontak, kontak.Email, kontak.Telepon, kontak.Facebook, kontak.Instagram, kontak.Youtube)
+
+	return err
+}
+
+// IsKontakExists implements KontenRepository.
+func (k *kontenRepositoryImpl) IsKontakExists(ctx context.Context, tx *sql.Tx, idKontak string) (bool, error) {
+	query := "SELECT COUNT(*) FROM kontak WHERE id_kontak = ?"
+
+	var count int
+	if err := tx.QueryRowContext(ctx, query, idKontak).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
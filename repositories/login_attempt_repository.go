@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+)
+
+// ErrLoginAttemptConflict dikembalikan jika percobaan login lain untuk username atau IP yang sama
+// sedang berjalan bersamaan dan transaksi ini dibatalkan MySQL karena deadlock atau menunggu terlalu lama
+var ErrLoginAttemptConflict = errors.New("percobaan login lain sedang diproses")
+
+// Nomor error MySQL "Lock wait timeout exceeded" dan "Deadlock found when trying to get lock"
+const (
+	mysqlErrLockWaitTimeout = 1205
+	mysqlErrLockDeadlock    = 1213
+)
+
+type LoginAttemptRepository interface {
+	AddLoginAttempt(ctx context.Context, tx *sql.Tx, attempt models.LoginAttempt) error
+	CountFailedByUsername(ctx context.Context, tx *sql.Tx, username string, since time.Time) (int, time.Time, error)
+	CountFailedByIp(ctx context.Context, tx *sql.Tx, ipAddress string, since time.Time) (int, time.Time, error)
+	GetRecentFailedAttempts(ctx context.Context, tx *sql.Tx, username string, limit int) ([]models.LoginAttempt, error)
+}
+
+type loginAttemptRepositoryImpl struct {
+}
+
+func NewLoginAttemptRepository() LoginAttemptRepository {
+	return &loginAttemptRepositoryImpl{}
+}
+
+// AddLoginAttempt implements LoginAttemptRepository.
+func (l *loginAttemptRepositoryImpl) AddLoginAttempt(ctx context.Context, tx *sql.Tx, attempt models.LoginAttempt) error {
+	query := "INSERT INTO login_attempt (id_login_attempt, username, ip_address, success, created_at) VALUES (?, ?, ?, ?, ?)"
+
+	_, err := tx.ExecContext(ctx, query, attempt.IdLoginAttempt, attempt.Username, attempt.IpAddress, attempt.Success, attempt.CreatedAt)
+	return loginAttemptError(err)
+}
+
+// CountFailedByUsername implements LoginAttemptRepository.
+// Percobaan gagal yang terjadi sebelum login berhasil terakhir tidak ikut dihitung.
+// Baris yang dihitung dikunci sampai transaksi selesai, sehingga login lain untuk username
+// yang sama menunggu hingga percobaan ini tercatat.
+func (l *loginAttemptRepositoryImpl) CountFailedByUsername(ctx context.Context, tx *sql.Tx, username string, since time.Time) (int, time.Time, error) {
+	query := `
+		SELECT COUNT(*), MAX(created_at)
+		FROM login_attempt
+		WHERE username = ? AND success = 0 AND created_at > GREATEST(?, COALESCE(
+			(SELECT MAX(created_at) FROM login_attempt WHERE username = ? AND success = 1 FOR UPDATE), ?))
+		FOR UPDATE
+	`
+
+	return scanFailedCount(tx.QueryRowContext(ctx, query, username, since, username, since))
+}
+
+// CountFailedByIp implements LoginAttemptRepository.
+// Seperti CountFailedByUsername, baris yang dihitung dikunci sampai transaksi selesai.
+func (l *loginAttemptRepositoryImpl) CountFailedByIp(ctx context.Context, tx *sql.Tx, ipAddress string, since time.Time) (int, time.Time, error) {
+	query := "SELECT COUNT(*), MAX(created_at) FROM login_attempt WHERE ip_address = ? AND success = 0 AND created_at > ? FOR UPDATE"
+
+	return scanFailedCount(tx.QueryRowContext(ctx, query, ipAddress, since))
+}
+
+// GetRecentFailedAttempts implements LoginAttemptRepository.
+func (l *loginAttemptRepositoryImpl) GetRecentFailedAttempts(ctx context.Context, tx *sql.Tx, username string, limit int) ([]models.LoginAttempt, error) {
+	query := "SELECT id_login_attempt, username, ip_address, success, created_at FROM login_attempt WHERE success = 0"
+	var args []interface{}
+
+	if username != "" {
+		query += " AND username = ?"
+		args = append(args, username)
+	}
+
+	query += " ORDER BY created_at DESC LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts []models.LoginAttempt
+	for rows.Next() {
+		var attempt models.LoginAttempt
+		if err := rows.Scan(&attempt.IdLoginAttempt, &attempt.Username, &attempt.IpAddress, &attempt.Success, &attempt.CreatedAt); err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+
+	return attempts, rows.Err()
+}
+
+func scanFailedCount(row *sql.Row) (int, time.Time, error) {
+	var count int
+	var lastFailed sql.NullTime
+	if err := row.Scan(&count, &lastFailed); err != nil {
+		return 0, time.Time{}, loginAttemptError(err)
+	}
+
+	return count, lastFailed.Time, nil
+}
+
+// loginAttemptError mengubah error kunci baris dari MySQL menjadi ErrLoginAttemptConflict
+func loginAttemptError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && (mysqlErr.Number == mysqlErrLockDeadlock || mysqlErr.Number == mysqlErrLockWaitTimeout) {
+		return ErrLoginAttemptConflict
+	}
+
+	return err
+}
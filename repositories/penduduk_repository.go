@@ -10,6 +10,7 @@ import (
 type PendudukRepository interface {
 	GetPenduduk(ctx context.Context, tx *sql.Tx, penduduk models.Penduduk) (models.Penduduk, error)
 	UpdatePenduduk(ctx context.Context, tx *sql.Tx, penduduk models.Penduduk) error
+	AddPenduduk(ctx context.Context, tx *sql.Tx, penduduk models.Penduduk) error
 }
 
 type pendudukRepositoryImpl struct {
@@ -34,4 +35,11 @@ func (p *pendudukRepositoryImpl) UpdatePenduduk(ctx context.Context, tx *sql.Tx,
 	query := "UPDATE penduduk SET total_penduduk = ?, total_kepala_keluarga = ? WHERE id_penduduk = ?"
 	_, err := tx.ExecContext(ctx, query, penduduk.TotalPenduduk, penduduk.TotalKepalaKeluarga, penduduk.IdPenduduk)
 	return err
-}
\ No newline at end of file
+}
+
+// AddPenduduk implements PendudukRepository.
+func (p *pendudukRepositoryImpl) AddPenduduk(ctx context.Context, tx *sql.Tx, penduduk models.Penduduk) error {
+	query := "INSERT INTO penduduk (id_penduduk, total_penduduk, total_kepala_keluarga) VALUES (?, ?, ?)"
+	_, err := tx.ExecContext(ctx, query, penduduk.IdPenduduk, penduduk.TotalPenduduk, penduduk.TotalKepalaKeluarga)
+	return err
+}
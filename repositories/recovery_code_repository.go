@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+)
+
+type RecoveryCodeRepository interface {
+	AddRecoveryCode(ctx context.Context, tx *sql.Tx, code models.RecoveryCode) error
+	GetRecoveryCodesByAdminId(ctx context.Context, tx *sql.Tx, idAdmin string) ([]models.RecoveryCode, error)
+	DeleteRecoveryCode(ctx context.Context, tx *sql.Tx, idRecoveryCode string) error
+	DeleteRecoveryCodesByAdminId(ctx context.Context, tx *sql.Tx, idAdmin string) error
+}
+
+type recoveryCodeRepositoryImpl struct {
+}
+
+func NewRecoveryCodeRepository() RecoveryCodeRepository {
+	return &recoveryCodeRepositoryImpl{}
+}
+
+// AddRecoveryCode implements RecoveryCodeRepository.
+func (r *recoveryCodeRepositoryImpl) AddRecoveryCode(ctx context.Context, tx *sql.Tx, code models.RecoveryCode) error {
+	query := "INSERT INTO admin_recovery_code (id_recovery_code, id_admin, code_hash) VALUES (?, ?, ?)"
+
+	_, err := tx.ExecContext(ctx, query, code.IdRecoveryCode, code.IdAdmin, code.CodeHash)
+	return err
+}
+
+// GetRecoveryCodesByAdminId implements RecoveryCodeRepository.
+func (r *recoveryCodeRepositoryImpl) GetRecoveryCodesByAdminId(ctx context.Context, tx *sql.Tx, idAdmin string) ([]models.RecoveryCode, error) {
+	query := "SELECT id_recovery_code, id_admin, code_hash FROM admin_recovery_code WHERE id_admin = ?"
+
+	rows, err := tx.QueryContext(ctx, query, idAdmin)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var codes []models.RecoveryCode
+	for rows.Next() {
+		var code models.RecoveryCode
+		if err := rows.Scan(&code.IdRecoveryCode, &code.IdAdmin, &code.CodeHash); err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+
+	return codes, rows.Err()
+}
+
+// DeleteRecoveryCode implements RecoveryCodeRepository.
+func (r *recoveryCodeRepositoryImpl) DeleteRecoveryCode(ctx context.Context, tx *sql.Tx, idRecoveryCode string) error {
+	query := "DELETE FROM admin_recovery_code WHERE id_recovery_code = ?"
+
+	_, err := tx.ExecContext(ctx, query, idRecoveryCode)
+	return err
+}
+
+// DeleteRecoveryCodesByAdminId implements RecoveryCodeRepository.
+func (r *recoveryCodeRepositoryImpl) DeleteRecoveryCodesByAdminId(ctx context.Context, tx *sql.Tx, idAdmin string) error {
+	query := "DELETE FROM admin_recovery_code WHERE id_admin = ?"
+
+	_, err := tx.ExecContext(ctx, query, idAdmin)
+	return err
+}
@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+)
+
+// ErrFulltextUnavailable dikembalikan jika index FULLTEXT belum dibuat, misalnya migrasi belum dijalankan
+var ErrFulltextUnavailable = errors.New("index fulltext tidak tersedia")
+
+// beritaVisibleCondition membatasi pencarian pada berita yang sedang tampil untuk publik,
+// dengan dua placeholder waktu sekarang untuk publish_at dan expire_at
+const beritaVisibleCondition = "status = 'published' AND (publish_at IS NULL OR publish_at <= ?) AND (expire_at IS NULL OR expire_at > ?) AND deleted_at IS NULL"
+
+// Nomor error MySQL "Can't find FULLTEXT index matching the column list"
+const mysqlErrNoFulltextIndex = 1191
+
+type SearchRepository interface {
+	SearchFulltext(ctx context.Context, tx *sql.Tx, keyword string, now time.Time, limit, offset int) ([]models.SearchResult, int, error)
+	SearchLike(ctx context.Context, tx *sql.Tx, keyword string, now time.Time, limit, offset int) ([]models.SearchResult, int, error)
+}
+
+type searchRepositoryImpl struct {
+}
+
+func NewSearchRepository() SearchRepository {
+	return &searchRepositoryImpl{}
+}
+
+// SearchFulltext implements SearchRepository.
+func (s *searchRepositoryImpl) SearchFulltext(ctx context.Context, tx *sql.Tx, keyword string, now time.Time, limit, offset int) ([]models.SearchResult, int, error) {
+	union := `
+		SELECT 'berita' AS type, id_berita AS id, judul_berita AS title, COALESCE(deskripsi_text, '') AS content,
+			MATCH(judul_berita, deskripsi_text) AGAINST (? IN NATURAL LANGUAGE MODE) AS score
+		FROM berita
+		WHERE ` + beritaVisibleCondition + ` AND MATCH(judul_berita, deskripsi_text) AGAINST (? IN NATURAL LANGUAGE MODE)
+		UNION ALL
+		SELECT 'aparat' AS type, id_aparat AS id, nama AS title, jabatan AS content,
+			MATCH(nama, jabatan) AGAINST (? IN NATURAL LANGUAGE MODE) AS score
+		FROM aparat
+		WHERE deleted_at IS NULL AND MATCH(nama, jabatan) AGAINST (? IN NATURAL LANGUAGE MODE)
+	`
+	args := []interface{}{keyword, now, now, keyword, keyword, keyword}
+
+	results, total, err := s.search(ctx, tx, union, args, limit, offset)
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrNoFulltextIndex {
+		return nil, 0, ErrFulltextUnavailable
+	}
+
+	return results, total, err
+}
+
+// SearchLike implements SearchRepository.
+func (s *searchRepositoryImpl) SearchLike(ctx context.Context, tx *sql.Tx, keyword string, now time.Time, limit, offset int) ([]models.SearchResult, int, error) {
+	// Kecocokan pada judul atau nama diberi bobot lebih tinggi dari kecocokan pada isi
+	union := `
+		SELECT 'berita' AS type, id_berita AS id, judul_berita AS title, COALESCE(deskripsi_text, '') AS content,
+			(judul_berita LIKE ?) * 2 + COALESCE(deskripsi_text LIKE ?, 0) AS score
+		FROM berita
+		WHERE ` + beritaVisibleCondition + ` AND (judul_berita LIKE ? OR deskripsi_text LIKE ?)
+		UNION ALL
+		SELECT 'aparat' AS type, id_aparat AS id, nama AS title, jabatan AS content,
+			(nama LIKE ?) * 2 + (jabatan LIKE ?) AS score
+		FROM aparat
+		WHERE deleted_at IS NULL AND (nama LIKE ? OR jabatan LIKE ?)
+	`
+
+	pattern := "%" + escapeLike(keyword) + "%"
+	args := []interface{}{pattern, pattern, now, now, pattern, pattern, pattern, pattern, pattern, pattern}
+
+	return s.search(ctx, tx, union, args, limit, offset)
+}
+
+func (s *searchRepositoryImpl) search(ctx context.Context, tx *sql.Tx, union string, args []interface{}, limit, offset int) ([]models.SearchResult, int, error) {
+	var total int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM ("+union+") AS hasil", args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := "SELECT type, id, title, content, score FROM (" + union + ") AS hasil ORDER BY score DESC, title LIMIT ? OFFSET ?"
+
+	rows, err := tx.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var results []models.SearchResult
+	for rows.Next() {
+		var result models.SearchResult
+		if err := rows.Scan(&result.Type, &result.Id, &result.Title, &result.Content, &result.Score); err != nil {
+			return nil, 0, err
+		}
+		results = append(results, result)
+	}
+
+	return results, total, rows.Err()
+}
+
+// escapeLike meng-escape karakter wildcard LIKE agar input pengguna dicari apa adanya
+func escapeLike(keyword string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(keyword)
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+)
+
+var ErrSessionNotFound = errors.New("sesi tidak ditemukan")
+
+type SessionRepository interface {
+	AddSession(ctx context.Context, tx *sql.Tx, session models.AdminSession) error
+	GetSessionById(ctx context.Context, tx *sql.Tx, idSession string) (models.AdminSession, error)
+	GetSessionByRefreshToken(ctx context.Context, tx *sql.Tx, refreshTokenHash string) (models.AdminSession, error)
+	RotateSession(ctx context.Context, tx *sql.Tx, session models.AdminSession) error
+	DeleteSession(ctx context.Context, tx *sql.Tx, idSession string) error
+	DeleteSessionsByAdminId(ctx context.Context, tx *sql.Tx, idAdmin string) error
+}
+
+type sessionRepositoryImpl struct {
+}
+
+func NewSessionRepository() SessionRepository {
+	return &sessionRepositoryImpl{}
+}
+
+// AddSession implements SessionRepository.
+func (s *sessionRepositoryImpl) AddSession(ctx context.Context, tx *sql.Tx, session models.AdminSession) error {
+	query := "INSERT INTO admin_session (id_session, id_admin, refresh_token_hash, expires_at, created_at) VALUES (?, ?, ?, ?, ?)"
+
+	_, err := tx.ExecContext(ctx, query, session.IdSession, session.IdAdmin, session.RefreshTokenHash, session.ExpiresAt, session.CreatedAt)
+	return err
+}
+
+// GetSessionById implements SessionRepository.
+func (s *sessionRepositoryImpl) GetSessionById(ctx context.Context, tx *sql.Tx, idSession string) (models.AdminSession, error) {
+	query := "SELECT id_session, id_admin, refresh_token_hash, expires_at, created_at FROM admin_session WHERE id_session = ?"
+
+	return scanSession(tx.QueryRowContext(ctx, query, idSession))
+}
+
+// GetSessionByRefreshToken implements SessionRepository.
+func (s *sessionRepositoryImpl) GetSessionByRefreshToken(ctx context.Context, tx *sql.Tx, refreshTokenHash string) (models.AdminSession, error) {
+	query := "SELECT id_session, id_admin, refresh_token_hash, expires_at, created_at FROM admin_session WHERE refresh_token_hash = ? FOR UPDATE"
+
+	return scanSession(tx.QueryRowContext(ctx, query, refreshTokenHash))
+}
+
+// RotateSession implements SessionRepository.
+func (s *sessionRepositoryImpl) RotateSession(ctx context.Context, tx *sql.Tx, session models.AdminSession) error {
+	query := "UPDATE admin_session SET refresh_token_hash = ?, expires_at = ? WHERE id_session = ?"
+
+	_, err := tx.ExecContext(ctx, query, session.RefreshTokenHash, session.ExpiresAt, session.IdSession)
+	return err
+}
+
+// DeleteSession implements SessionRepository.
+func (s *sessionRepositoryImpl) DeleteSession(ctx context.Context, tx *sql.Tx, idSession string) error {
+	query := "DELETE FROM admin_session WHERE id_session = ?"
+
+	_, err := tx.ExecContext(ctx, query, idSession)
+	return err
+}
+
+// DeleteSessionsByAdminId implements SessionRepository.
+func (s *sessionRepositoryImpl) DeleteSessionsByAdminId(ctx context.Context, tx *sql.Tx, idAdmin string) error {
+	query := "DELETE FROM admin_session WHERE id_admin = ?"
+
+	_, err := tx.ExecContext(ctx, query, idAdmin)
+	return err
+}
+
+func scanSession(row *sql.Row) (models.AdminSession, error) {
+	var session models.AdminSession
+	err := row.Scan(&session.IdSession, &session.IdAdmin, &session.RefreshTokenHash, &session.ExpiresAt, &session.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.AdminSession{}, ErrSessionNotFound
+		}
+		return models.AdminSession{}, err
+	}
+
+	return session, nil
+}
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+)
+
+type SitemapRepository interface {
+	CountBerita(ctx context.Context, tx *sql.Tx, now time.Time) (int, error)
+	GetBeritaEntries(ctx context.Context, tx *sql.Tx, now time.Time, limit, offset int) ([]models.SitemapEntry, error)
+	GetAparatEntries(ctx context.Context, tx *sql.Tx) ([]models.SitemapEntry, error)
+	GetBeritaLastModified(ctx context.Context, tx *sql.Tx, now time.Time) (time.Time, error)
+	GetEntityLastModified(ctx context.Context, tx *sql.Tx, entity string) (time.Time, error)
+}
+
+type sitemapRepositoryImpl struct{}
+
+func NewSitemapRepository() SitemapRepository {
+	return &sitemapRepositoryImpl{}
+}
+
+// CountBerita implements SitemapRepository.
+func (s *sitemapRepositoryImpl) CountBerita(ctx context.Context, tx *sql.Tx, now time.Time) (int, error) {
+	query := "SELECT COUNT(*) FROM berita WHERE " + beritaVisibleCondition + " AND slug IS NOT NULL"
+
+	var total int
+	err := tx.QueryRowContext(ctx, query, now, now).Scan(&total)
+	return total, err
+}
+
+// GetBeritaEntries implements SitemapRepository.
+// Waktu perubahan terakhir berita diambil dari audit log karena tabel berita tidak menyimpan
+// waktu update, dengan waktu terbit sebagai batas bawahnya.
+func (s *sitemapRepositoryImpl) GetBeritaEntries(ctx context.Context, tx *sql.Tx, now time.Time, limit, offset int) ([]models.SitemapEntry, error) {
+	query := `
+		SELECT b.slug, COALESCE(b.publish_at, b.created_at), MAX(a.created_at)
+		FROM berita b
+		LEFT JOIN audit_log a ON a.entity = ? AND a.entity_id = b.id_berita
+		WHERE ` + beritaVisibleCondition + ` AND b.slug IS NOT NULL
+		GROUP BY b.id_berita
+		ORDER BY b.created_at, b.id_berita
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := tx.QueryContext(ctx, query, models.AuditEntityBerita, now, now, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.SitemapEntry
+	for rows.Next() {
+		var slug string
+		var publishedAt time.Time
+		var auditedAt sql.NullTime
+		if err := rows.Scan(&slug, &publishedAt, &auditedAt); err != nil {
+			return nil, err
+		}
+
+		lastMod := latestTime(publishedAt, auditedAt.Time)
+		entries = append(entries, models.SitemapEntry{Key: slug, LastMod: &lastMod})
+	}
+
+	return entries, rows.Err()
+}
+
+// GetAparatEntries implements SitemapRepository.
+func (s *sitemapRepositoryImpl) GetAparatEntries(ctx context.Context, tx *sql.Tx) ([]models.SitemapEntry, error) {
+	query := `
+		SELECT ap.id_aparat, MAX(a.created_at)
+		FROM aparat ap
+		LEFT JOIN audit_log a ON a.entity = ? AND a.entity_id = ap.id_aparat
+		WHERE ap.deleted_at IS NULL
+		GROUP BY ap.id_aparat
+		ORDER BY ap.id_aparat
+	`
+
+	rows, err := tx.QueryContext(ctx, query, models.AuditEntityAparat)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.SitemapEntry
+	for rows.Next() {
+		var entry models.SitemapEntry
+		var auditedAt sql.NullTime
+		if err := rows.Scan(&entry.Key, &auditedAt); err != nil {
+			return nil, err
+		}
+
+		// Aparat yang dibuat sebelum audit log ada tidak memiliki waktu perubahan
+		if auditedAt.Valid {
+			entry.LastMod = &auditedAt.Time
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// GetBeritaLastModified implements SitemapRepository.
+// Mengembalikan waktu perubahan terakhir dari seluruh berita yang sedang tayang.
+func (s *sitemapRepositoryImpl) GetBeritaLastModified(ctx context.Context, tx *sql.Tx, now time.Time) (time.Time, error) {
+	query := `
+		SELECT MAX(COALESCE(b.publish_at, b.created_at)), MAX(a.created_at)
+		FROM berita b
+		LEFT JOIN audit_log a ON a.entity = ? AND a.entity_id = b.id_berita
+		WHERE ` + beritaVisibleCondition
+
+	var publishedAt, auditedAt sql.NullTime
+	err := tx.QueryRowContext(ctx, query, models.AuditEntityBerita, now, now).Scan(&publishedAt, &auditedAt)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return latestTime(publishedAt.Time, auditedAt.Time), nil
+}
+
+// GetEntityLastModified implements SitemapRepository.
+// Mengembalikan waktu audit terakhir untuk entity, atau waktu nol jika belum pernah diubah.
+func (s *sitemapRepositoryImpl) GetEntityLastModified(ctx context.Context, tx *sql.Tx, entity string) (time.Time, error) {
+	query := "SELECT MAX(created_at) FROM audit_log WHERE entity = ?"
+
+	var lastModified sql.NullTime
+	err := tx.QueryRowContext(ctx, query, entity).Scan(&lastModified)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return lastModified.Time, nil
+}
+
+func latestTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
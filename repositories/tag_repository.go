@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+)
+
+type TagRepository interface {
+	AddTag(ctx context.Context, tx *sql.Tx, tag models.Tag) error
+	GetAllTag(ctx context.Context, tx *sql.Tx, now time.Time) ([]models.Tag, error)
+	GetTagBySlug(ctx context.Context, tx *sql.Tx, slug string) (models.Tag, error)
+}
+
+type tagRepositoryImpl struct{}
+
+func NewTagRepository() TagRepository {
+	return &tagRepositoryImpl{}
+}
+
+// AddTag implements TagRepository.
+func (t *tagRepositoryImpl) AddTag(ctx context.Context, tx *sql.Tx, tag models.Tag) error {
+	query := "INSERT INTO tag (id_tag, nama, slug, created_at) VALUES (?, ?, ?, ?)"
+	_, err := tx.ExecContext(ctx, query, tag.IdTag, tag.Nama, tag.Slug, tag.CreatedAt)
+	return err
+}
+
+// GetAllTag implements TagRepository.
+// Hanya tag yang dipakai berita yang sedang tayang yang dikembalikan, terbanyak lebih dulu.
+func (t *tagRepositoryImpl) GetAllTag(ctx context.Context, tx *sql.Tx, now time.Time) ([]models.Tag, error) {
+	query := `
+		SELECT t.id_tag, t.nama, t.slug, t.created_at, COUNT(*) AS jumlah_berita
+		FROM tag t
+		JOIN berita_tag bt ON bt.id_tag = t.id_tag
+		JOIN berita b ON b.id_berita = bt.id_berita
+		WHERE ` + beritaVisibleCondition + `
+		GROUP BY t.id_tag
+		ORDER BY jumlah_berita DESC, t.nama
+	`
+
+	rows, err := tx.QueryContext(ctx, query, now, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []models.Tag
+	for rows.Next() {
+		var tag models.Tag
+		if err := rows.Scan(&tag.IdTag, &tag.Nama, &tag.Slug, &tag.CreatedAt, &tag.JumlahBerita); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// GetTagBySlug implements TagRepository.
+func (t *tagRepositoryImpl) GetTagBySlug(ctx context.Context, tx *sql.Tx, slug string) (models.Tag, error) {
+	query := "SELECT id_tag, nama, slug, created_at FROM tag WHERE slug = ?"
+
+	var tag models.Tag
+	err := tx.QueryRowContext(ctx, query, slug).Scan(&tag.IdTag, &tag.Nama, &tag.Slug, &tag.CreatedAt)
+	if err != nil {
+		return models.Tag{}, err
+	}
+
+	return tag, nil
+}
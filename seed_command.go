@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+	"github.com/syrlramadhan/desa-sukamaju-api/repositories"
+)
+
+// runSeed membuat baris kontak dan penduduk awal. Kedua tabel hanya pernah di-update
+// lewat api, sehingga barisnya harus sudah ada. Baris yang sudah ada tidak diubah.
+func runSeed(db *sql.DB, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	idKontak := flags.String("id-kontak", "1", "id baris kontak yang dipakai frontend")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	kontenRepo := repositories.NewKontenRepository()
+	pendudukRepo := repositories.NewPendudukRepository()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	exists, err := kontenRepo.IsKontakExists(ctx, tx, *idKontak)
+	if err != nil {
+		return fmt.Errorf("gagal memeriksa kontak: %v", err)
+	}
+
+	if exists {
+		fmt.Printf("kontak dengan id %s sudah ada, dilewati\n", *idKontak)
+	} else {
+		err = kontenRepo.AddKontak(ctx, tx, models.Kontak{IdKontak: *idKontak})
+		if err != nil {
+			return fmt.Errorf("gagal menambahkan kontak: %v", err)
+		}
+		fmt.Printf("kontak dengan id %s berhasil dibuat\n", *idKontak)
+	}
+
+	_, err = pendudukRepo.GetPenduduk(ctx, tx, models.Penduduk{})
+	switch {
+	case err == nil:
+		fmt.Println("data penduduk sudah ada, dilewati")
+	case err == sql.ErrNoRows:
+		err = pendudukRepo.AddPenduduk(ctx, tx, models.Penduduk{
+			IdPenduduk:          uuid.New().String(),
+			TotalPenduduk:       "0",
+			TotalKepalaKeluarga: "0",
+		})
+		if err != nil {
+			return fmt.Errorf("gagal menambahkan data penduduk: %v", err)
+		}
+		fmt.Println("data penduduk berhasil dibuat")
+	default:
+		return fmt.Errorf("gagal mendapatkan data penduduk: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return nil
+}
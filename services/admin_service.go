@@ -3,30 +3,76 @@ package services
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/syrlramadhan/desa-sukamaju-api/dto"
 	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
 	"github.com/syrlramadhan/desa-sukamaju-api/repositories"
 )
 
 type AdminService interface {
 	GetAdminById(ctx context.Context, idAdmin string) (dto.AdminResponse, int, error)
-	LoginAdmin(ctx context.Context, loginReq dto.LoginRequest) (string, int, error)
+	LoginAdmin(ctx context.Context, loginReq dto.LoginRequest, ipAddress string) (dto.TokenResponse, int, error)
+	RefreshToken(ctx context.Context, refreshReq dto.RefreshTokenRequest) (dto.TokenResponse, int, error)
+	Logout(ctx context.Context) (int, error)
+	LogoutAll(ctx context.Context) (int, error)
+	ValidateSession(ctx context.Context, claims *helpers.Claims) (int, error)
+	GetFailedLoginAttempts(ctx context.Context, username string, limit int) ([]dto.LoginAttemptResponse, int, error)
+	LoginTwoFactor(ctx context.Context, loginReq dto.TwoFactorLoginRequest, ipAddress string) (dto.TokenResponse, int, error)
+	SetupTwoFactor(ctx context.Context) (dto.TwoFactorSetupResponse, int, error)
+	EnableTwoFactor(ctx context.Context, codeReq dto.TwoFactorCodeRequest) (dto.RecoveryCodesResponse, int, error)
+	DisableTwoFactor(ctx context.Context, disableReq dto.DisableTwoFactorRequest) (int, error)
 	UpdateUsernameAdmin(ctx context.Context, idAdmin string, usernameReq dto.UpdateUsernameRequest) (int, error)
 	UpdatePasswordAdmin(ctx context.Context, username string, passReq dto.UpdatePasswordRequest) (int, error)
+	CreateAdmin(ctx context.Context, adminReq dto.CreateAdminRequest) (dto.AdminResponse, int, error)
+	GetAllAdmin(ctx context.Context) ([]dto.AdminResponse, int, error)
+	UpdateAdmin(ctx context.Context, idAdmin string, adminReq dto.UpdateAdminRequest) (dto.AdminResponse, int, error)
+	DeleteAdmin(ctx context.Context, idAdmin string) (int, error)
 }
 
+// Batas percobaan login. Setelah beberapa kegagalan, login berikutnya harus menunggu jeda
+// yang terus berlipat, dan setelah batas maksimum akun/IP dikunci sementara.
+const (
+	loginAttemptWindow      = 15 * time.Minute
+	loginLockoutDuration    = 15 * time.Minute
+	loginMaxDelay           = time.Minute
+	usernameDelayThreshold  = 3
+	usernameLockoutAttempts = 10
+	ipDelayThreshold        = 10
+	ipLockoutAttempts       = 30
+)
+
+// recoveryCodeCount adalah jumlah kode pemulihan yang diberikan saat 2FA diaktifkan
+const recoveryCodeCount = 10
+
+// dummyPasswordHash dipakai ketika username tidak ditemukan, agar waktu respons
+// tidak membocorkan apakah username terdaftar atau tidak.
+var dummyPasswordHash, _ = helpers.HashPassword("desa-sukamaju-dummy-password")
+
 type AdminServiceImpl struct {
-	AdminRepository repositories.AdminRepository
-	DB              *sql.DB
+	AdminRepository        repositories.AdminRepository
+	SessionRepository      repositories.SessionRepository
+	LoginAttemptRepository repositories.LoginAttemptRepository
+	RecoveryCodeRepository repositories.RecoveryCodeRepository
+	AuditRepository        repositories.AuditRepository
+	DB                     *sql.DB
 }
 
-func NewAdminService(adminRepository repositories.AdminRepository, db *sql.DB) AdminService {
+func NewAdminService(adminRepository repositories.AdminRepository, sessionRepository repositories.SessionRepository, loginAttemptRepository repositories.LoginAttemptRepository, recoveryCodeRepository repositories.RecoveryCodeRepository, auditRepository repositories.AuditRepository, db *sql.DB) AdminService {
 	return &AdminServiceImpl{
-		AdminRepository: adminRepository,
-		DB:              db,
+		AdminRepository:        adminRepository,
+		SessionRepository:      sessionRepository,
+		LoginAttemptRepository: loginAttemptRepository,
+		RecoveryCodeRepository: recoveryCodeRepository,
+		AuditRepository:        auditRepository,
+		DB:                     db,
 	}
 }
 
@@ -43,10 +89,7 @@ func (a *AdminServiceImpl) GetAdminById(ctx context.Context, idAdmin string) (dt
 		return dto.AdminResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan admin: %v", err)
 	}
 
-	adminResponse := dto.AdminResponse{
-		IdAdmin:  admin.IdAdmin,
-		Username: admin.Username,
-	}
+	adminResponse := helpers.ConvertAdminToResponseDTO(admin)
 
 	if err := tx.Commit(); err != nil {
 		return dto.AdminResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
@@ -55,40 +98,353 @@ func (a *AdminServiceImpl) GetAdminById(ctx context.Context, idAdmin string) (dt
 	return adminResponse, http.StatusOK, nil
 }
 
-
 // LoginAdmin implements AdminService.
-func (a *AdminServiceImpl) LoginAdmin(ctx context.Context, loginReq dto.LoginRequest) (string, int, error) {
+func (a *AdminServiceImpl) LoginAdmin(ctx context.Context, loginReq dto.LoginRequest, ipAddress string) (dto.TokenResponse, int, error) {
+	if loginReq.Username == "" {
+		return dto.TokenResponse{}, http.StatusBadRequest, fmt.Errorf("username tidak boleh kosong")
+	} else if loginReq.Password == "" {
+		return dto.TokenResponse{}, http.StatusBadRequest, fmt.Errorf("password tidak boleh kosong")
+	}
+
 	tx, err := a.DB.Begin()
 	if err != nil {
-		return "", http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+		return dto.TokenResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
 	defer tx.Rollback()
 
-	if loginReq.Username == "" {
-		return "", http.StatusBadRequest, fmt.Errorf("username tidak boleh kosong")
-	} else if loginReq.Password == "" {
-		return "", http.StatusBadRequest, fmt.Errorf("password tidak boleh kosong")
+	// Pemeriksaan batas dan pencatatan kegagalan berada di transaksi yang sama, sehingga
+	// percobaan login bersamaan tidak bisa lolos sebelum kegagalan lainnya tercatat
+	if code, err := a.checkLoginThrottle(ctx, tx, loginReq.Username, ipAddress); err != nil {
+		return dto.TokenResponse{}, code, err
 	}
 
 	admin, err := a.AdminRepository.GetAdmin(ctx, tx, loginReq.Username)
+	if err != nil && err != repositories.ErrAdminNotFound {
+		return dto.TokenResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan admin: %v", err)
+	}
+
+	passwordHash := admin.Password
+	if err == repositories.ErrAdminNotFound {
+		passwordHash = dummyPasswordHash
+	}
+
+	if !helpers.VerifyPassword(passwordHash, loginReq.Password) || err == repositories.ErrAdminNotFound {
+		if code, err := a.recordFailedLogin(ctx, tx, loginReq.Username, ipAddress); err != nil {
+			return dto.TokenResponse{}, code, err
+		}
+		return dto.TokenResponse{}, http.StatusBadRequest, fmt.Errorf("username atau password salah")
+	}
+
+	if admin.Status != models.AdminStatusAktif {
+		return dto.TokenResponse{}, http.StatusForbidden, fmt.Errorf("akun admin dinonaktifkan")
+	}
+
+	// Admin dengan 2FA aktif hanya mendapat token tantangan, token akses diberikan
+	// setelah kode TOTP diverifikasi melalui LoginTwoFactor.
+	if admin.TotpEnabled {
+		challengeToken, err := helpers.GenerateChallengeJWT(admin.IdAdmin, admin.Username)
+		if err != nil {
+			return dto.TokenResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal menghasilkan token tantangan: %v", err)
+		}
+
+		return dto.TokenResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    challengeToken,
+			ExpiresIn:         int(helpers.ChallengeTokenTTL.Seconds()),
+		}, http.StatusOK, nil
+	}
+
+	tokenResponse, err := a.completeLogin(ctx, tx, admin, ipAddress)
+	if err != nil {
+		return dto.TokenResponse{}, http.StatusInternalServerError, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dto.TokenResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return tokenResponse, http.StatusOK, nil
+}
+
+// GetFailedLoginAttempts implements AdminService.
+func (a *AdminServiceImpl) GetFailedLoginAttempts(ctx context.Context, username string, limit int) ([]dto.LoginAttemptResponse, int, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	attempts, err := a.LoginAttemptRepository.GetRecentFailedAttempts(ctx, tx, username, limit)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan data percobaan login: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	var attemptResponse []dto.LoginAttemptResponse
+	for _, attempt := range attempts {
+		attemptResponse = append(attemptResponse, dto.LoginAttemptResponse{
+			IdLoginAttempt: attempt.IdLoginAttempt,
+			Username:       attempt.Username,
+			IpAddress:      attempt.IpAddress,
+			CreatedAt:      attempt.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return attemptResponse, http.StatusOK, nil
+}
+
+// checkLoginThrottle menolak login jika username atau IP sedang dalam masa jeda atau terkunci.
+// Percobaan gagal yang dihitung tetap dikunci sampai tx selesai, sehingga login lain untuk username
+// atau IP yang sama menunggu hasil percobaan ini sebelum diperiksa.
+func (a *AdminServiceImpl) checkLoginThrottle(ctx context.Context, tx *sql.Tx, username, ipAddress string) (int, error) {
+	now := time.Now()
+	since := now.Add(-loginAttemptWindow)
+
+	failedUsername, lastUsername, err := a.LoginAttemptRepository.CountFailedByUsername(ctx, tx, username, since)
+	if err != nil {
+		return loginAttemptErrorCode(err), fmt.Errorf("gagal memeriksa percobaan login: %v", err)
+	}
+
+	failedIp, lastIp, err := a.LoginAttemptRepository.CountFailedByIp(ctx, tx, ipAddress, since)
+	if err != nil {
+		return loginAttemptErrorCode(err), fmt.Errorf("gagal memeriksa percobaan login: %v", err)
+	}
+
+	wait := max(
+		loginWaitDuration(failedUsername, lastUsername, usernameDelayThreshold, usernameLockoutAttempts, now),
+		loginWaitDuration(failedIp, lastIp, ipDelayThreshold, ipLockoutAttempts, now),
+	)
+	if wait > 0 {
+		return http.StatusTooManyRequests, fmt.Errorf("terlalu banyak percobaan login gagal, coba lagi dalam %d detik", int(wait.Seconds())+1)
+	}
+
+	return http.StatusOK, nil
+}
+
+// loginWaitDuration menghitung sisa waktu tunggu berdasarkan jumlah kegagalan: jeda 1, 2, 4, ...
+// detik (maksimal loginMaxDelay) setelah delayThreshold, dan terkunci setelah lockoutAttempts.
+func loginWaitDuration(failed int, lastFailed time.Time, delayThreshold, lockoutAttempts int, now time.Time) time.Duration {
+	if failed < delayThreshold {
+		return 0
+	}
+
+	delay := loginLockoutDuration
+	if failed < lockoutAttempts {
+		delay = min(time.Second<<(failed-delayThreshold), loginMaxDelay)
+	}
+
+	return lastFailed.Add(delay).Sub(now)
+}
+
+// recordFailedLogin menyimpan percobaan login gagal lalu mengkomit tx, sehingga kunci dari
+// checkLoginThrottle baru dilepas setelah kegagalan ini ikut terhitung.
+func (a *AdminServiceImpl) recordFailedLogin(ctx context.Context, tx *sql.Tx, username, ipAddress string) (int, error) {
+	err := a.LoginAttemptRepository.AddLoginAttempt(ctx, tx, models.LoginAttempt{
+		IdLoginAttempt: uuid.New().String(),
+		Username:       username,
+		IpAddress:      ipAddress,
+		Success:        false,
+		CreatedAt:      time.Now(),
+	})
+	if err != nil {
+		return loginAttemptErrorCode(err), fmt.Errorf("gagal mencatat percobaan login: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return http.StatusOK, nil
+}
+
+// loginAttemptErrorCode menjawab 429 jika percobaan login dibatalkan karena bertabrakan dengan
+// percobaan lain yang berjalan bersamaan, dan 500 untuk error lainnya
+func loginAttemptErrorCode(err error) int {
+	if errors.Is(err, repositories.ErrLoginAttemptConflict) {
+		return http.StatusTooManyRequests
+	}
+
+	return http.StatusInternalServerError
+}
+
+// RefreshToken implements AdminService.
+func (a *AdminServiceImpl) RefreshToken(ctx context.Context, refreshReq dto.RefreshTokenRequest) (dto.TokenResponse, int, error) {
+	if refreshReq.RefreshToken == "" {
+		return dto.TokenResponse{}, http.StatusBadRequest, fmt.Errorf("refresh token tidak boleh kosong")
+	}
+
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return dto.TokenResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	session, err := a.SessionRepository.GetSessionByRefreshToken(ctx, tx, helpers.HashToken(refreshReq.RefreshToken))
+	if err != nil {
+		if err == repositories.ErrSessionNotFound {
+			return dto.TokenResponse{}, http.StatusUnauthorized, fmt.Errorf("refresh token tidak valid")
+		}
+		return dto.TokenResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan sesi: %v", err)
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		return dto.TokenResponse{}, http.StatusUnauthorized, fmt.Errorf("refresh token sudah kedaluwarsa")
+	}
+
+	admin, err := a.AdminRepository.GetAdminById(ctx, tx, session.IdAdmin)
+	if err != nil {
+		return dto.TokenResponse{}, http.StatusUnauthorized, fmt.Errorf("gagal mendapatkan admin: %v", err)
+	}
+
+	if admin.Status != models.AdminStatusAktif {
+		return dto.TokenResponse{}, http.StatusForbidden, fmt.Errorf("akun admin dinonaktifkan")
+	}
+
+	// Refresh token dirotasi setiap kali dipakai, sehingga token lama tidak bisa digunakan lagi
+	refreshToken, err := helpers.GenerateRefreshToken()
+	if err != nil {
+		return dto.TokenResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal menghasilkan refresh token: %v", err)
+	}
+
+	session.RefreshTokenHash = helpers.HashToken(refreshToken)
+	session.ExpiresAt = time.Now().Add(helpers.RefreshTokenTTL)
+
+	err = a.SessionRepository.RotateSession(ctx, tx, session)
+	if err != nil {
+		return dto.TokenResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memperbarui sesi: %v", err)
+	}
+
+	accessToken, err := helpers.GenerateJWT(admin.IdAdmin, admin.Username, admin.Role, session.IdSession)
+	if err != nil {
+		return dto.TokenResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal menghasilkan token: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dto.TokenResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return dto.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(helpers.AccessTokenTTL.Seconds()),
+	}, http.StatusOK, nil
+}
+
+// Logout implements AdminService.
+func (a *AdminServiceImpl) Logout(ctx context.Context) (int, error) {
+	claims, ok := helpers.ClaimsFromContext(ctx)
+	if !ok {
+		return http.StatusUnauthorized, fmt.Errorf("admin belum login")
+	}
+
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	err = a.SessionRepository.DeleteSession(ctx, tx, claims.IdSession)
 	if err != nil {
-		return "", http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan admin: %v", err)
+		return http.StatusInternalServerError, fmt.Errorf("gagal menghapus sesi: %v", err)
 	}
 
-	if !helpers.VerifyPassword(admin.Password, loginReq.Password) {
-		return "", http.StatusBadRequest, fmt.Errorf("username atau password salah")
+	if err := tx.Commit(); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
 	}
 
-	token, err := helpers.GenerateJWT(admin.IdAdmin, admin.Username)
+	return http.StatusOK, nil
+}
+
+// LogoutAll implements AdminService.
+func (a *AdminServiceImpl) LogoutAll(ctx context.Context) (int, error) {
+	claims, ok := helpers.ClaimsFromContext(ctx)
+	if !ok {
+		return http.StatusUnauthorized, fmt.Errorf("admin belum login")
+	}
+
+	tx, err := a.DB.Begin()
 	if err != nil {
-		return "", http.StatusBadRequest, fmt.Errorf("gagal menghasilkan token: %v", err)
+		return http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	err = a.SessionRepository.DeleteSessionsByAdminId(ctx, tx, claims.Id)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal menghapus sesi: %v", err)
 	}
 
 	if err := tx.Commit(); err != nil {
-		return "", http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
 	}
 
-	return token, http.StatusOK, nil
+	return http.StatusOK, nil
+}
+
+// ValidateSession implements AdminService.
+func (a *AdminServiceImpl) ValidateSession(ctx context.Context, claims *helpers.Claims) (int, error) {
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	session, err := a.SessionRepository.GetSessionById(ctx, tx, claims.IdSession)
+	if err != nil {
+		if err == repositories.ErrSessionNotFound {
+			return http.StatusUnauthorized, fmt.Errorf("sesi sudah berakhir, silakan login kembali")
+		}
+		return http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan sesi: %v", err)
+	}
+
+	if session.IdAdmin != claims.Id || time.Now().After(session.ExpiresAt) {
+		return http.StatusUnauthorized, fmt.Errorf("sesi sudah berakhir, silakan login kembali")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return http.StatusOK, nil
+}
+
+// createSession membuat sesi baru beserta pasangan access token dan refresh token
+func (a *AdminServiceImpl) createSession(ctx context.Context, tx *sql.Tx, admin models.Admin) (dto.TokenResponse, error) {
+	refreshToken, err := helpers.GenerateRefreshToken()
+	if err != nil {
+		return dto.TokenResponse{}, fmt.Errorf("gagal menghasilkan refresh token: %v", err)
+	}
+
+	now := time.Now()
+	session := models.AdminSession{
+		IdSession:        uuid.New().String(),
+		IdAdmin:          admin.IdAdmin,
+		RefreshTokenHash: helpers.HashToken(refreshToken),
+		ExpiresAt:        now.Add(helpers.RefreshTokenTTL),
+		CreatedAt:        now,
+	}
+
+	err = a.SessionRepository.AddSession(ctx, tx, session)
+	if err != nil {
+		return dto.TokenResponse{}, fmt.Errorf("gagal membuat sesi: %v", err)
+	}
+
+	accessToken, err := helpers.GenerateJWT(admin.IdAdmin, admin.Username, admin.Role, session.IdSession)
+	if err != nil {
+		return dto.TokenResponse{}, fmt.Errorf("gagal menghasilkan token: %v", err)
+	}
+
+	return dto.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(helpers.AccessTokenTTL.Seconds()),
+	}, nil
 }
 
 // UpdatePasswordAdmin implements AdminService.
@@ -134,6 +490,17 @@ func (a *AdminServiceImpl) UpdatePasswordAdmin(ctx context.Context, username str
 		return http.StatusInternalServerError, fmt.Errorf("gagal memperbarui password: %v", err)
 	}
 
+	// Password tidak pernah disimpan di audit log, cukup dicatat bahwa password diubah
+	err = recordAudit(ctx, tx, a.AuditRepository, models.AuditActionUpdate, models.AuditEntityAdmin, admin.IdAdmin, nil, nil)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	err = a.SessionRepository.DeleteSessionsByAdminId(ctx, tx, admin.IdAdmin)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mencabut sesi admin: %v", err)
+	}
+
 	if err := tx.Commit(); err != nil {
 		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
 	}
@@ -162,14 +529,550 @@ func (a *AdminServiceImpl) UpdateUsernameAdmin(ctx context.Context, oldUsername
 		return http.StatusBadRequest, fmt.Errorf("username tidak boleh sama dengan username lama")
 	}
 
+	exists, err := a.AdminRepository.IsUsernameExists(ctx, tx, usernameReq.Username)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memeriksa username: %v", err)
+	}
+
+	if exists {
+		return http.StatusConflict, fmt.Errorf("username %s sudah digunakan", usernameReq.Username)
+	}
+
 	err = a.AdminRepository.UpdateUsernameAdmin(ctx, tx, oldUsername, usernameReq.Username)
 	if err != nil {
 		return http.StatusInternalServerError, fmt.Errorf("gagal memperbarui username: %v", err)
 	}
 
+	before := helpers.ConvertAdminToResponseDTO(admin)
+	after := before
+	after.Username = usernameReq.Username
+	err = recordAudit(ctx, tx, a.AuditRepository, models.AuditActionUpdate, models.AuditEntityAdmin, admin.IdAdmin, before, after)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	err = a.SessionRepository.DeleteSessionsByAdminId(ctx, tx, admin.IdAdmin)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mencabut sesi admin: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return http.StatusOK, nil
+}
+
+// CreateAdmin implements AdminService.
+func (a *AdminServiceImpl) CreateAdmin(ctx context.Context, adminReq dto.CreateAdminRequest) (dto.AdminResponse, int, error) {
+	if adminReq.Username == "" {
+		return dto.AdminResponse{}, http.StatusBadRequest, fmt.Errorf("username tidak boleh kosong")
+	}
+
+	if adminReq.Password == "" {
+		return dto.AdminResponse{}, http.StatusBadRequest, fmt.Errorf("password tidak boleh kosong")
+	}
+
+	if adminReq.Password != adminReq.KonfirmasiPassword {
+		return dto.AdminResponse{}, http.StatusBadRequest, fmt.Errorf("password dan konfirmasi password tidak cocok")
+	}
+
+	if !models.IsValidRole(adminReq.Role) {
+		return dto.AdminResponse{}, http.StatusBadRequest, fmt.Errorf("role %s tidak valid", adminReq.Role)
+	}
+
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return dto.AdminResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	exists, err := a.AdminRepository.IsUsernameExists(ctx, tx, adminReq.Username)
+	if err != nil {
+		return dto.AdminResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memeriksa username: %v", err)
+	}
+
+	if exists {
+		return dto.AdminResponse{}, http.StatusConflict, fmt.Errorf("username %s sudah digunakan", adminReq.Username)
+	}
+
+	hashedPassword, err := helpers.HashPassword(adminReq.Password)
+	if err != nil {
+		return dto.AdminResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal hash password: %v", err)
+	}
+
+	admin, err := a.AdminRepository.AddAdmin(ctx, tx, models.Admin{
+		IdAdmin:  uuid.New().String(),
+		Username: adminReq.Username,
+		Password: hashedPassword,
+		Role:     adminReq.Role,
+		Status:   models.AdminStatusAktif,
+	})
+	if err != nil {
+		return dto.AdminResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal menambahkan admin: %v", err)
+	}
+
+	err = recordAudit(ctx, tx, a.AuditRepository, models.AuditActionCreate, models.AuditEntityAdmin, admin.IdAdmin, nil, helpers.ConvertAdminToResponseDTO(admin))
+	if err != nil {
+		return dto.AdminResponse{}, http.StatusInternalServerError, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dto.AdminResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return helpers.ConvertAdminToResponseDTO(admin), http.StatusOK, nil
+}
+
+// GetAllAdmin implements AdminService.
+func (a *AdminServiceImpl) GetAllAdmin(ctx context.Context) ([]dto.AdminResponse, int, error) {
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	admins, err := a.AdminRepository.GetAllAdmin(ctx, tx)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan data admin: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return helpers.ConvertAdminToListResDTO(admins), http.StatusOK, nil
+}
+
+// UpdateAdmin implements AdminService.
+func (a *AdminServiceImpl) UpdateAdmin(ctx context.Context, idAdmin string, adminReq dto.UpdateAdminRequest) (dto.AdminResponse, int, error) {
+	if !models.IsValidRole(adminReq.Role) {
+		return dto.AdminResponse{}, http.StatusBadRequest, fmt.Errorf("role %s tidak valid", adminReq.Role)
+	}
+
+	if adminReq.Status != models.AdminStatusAktif && adminReq.Status != models.AdminStatusNonaktif {
+		return dto.AdminResponse{}, http.StatusBadRequest, fmt.Errorf("status %s tidak valid", adminReq.Status)
+	}
+
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return dto.AdminResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	admin, err := a.AdminRepository.GetAdminById(ctx, tx, idAdmin)
+	if err != nil {
+		return dto.AdminResponse{}, http.StatusNotFound, fmt.Errorf("gagal mendapatkan admin: %v", err)
+	}
+
+	demoted := admin.Role == models.RoleSuperadmin && admin.Status == models.AdminStatusAktif &&
+		(adminReq.Role != models.RoleSuperadmin || adminReq.Status != models.AdminStatusAktif)
+	if demoted {
+		if code, err := a.ensureOtherSuperadmin(ctx, tx, idAdmin); err != nil {
+			return dto.AdminResponse{}, code, err
+		}
+	}
+
+	before := helpers.ConvertAdminToResponseDTO(admin)
+
+	admin.Role = adminReq.Role
+	admin.Status = adminReq.Status
+
+	err = a.AdminRepository.UpdateAdmin(ctx, tx, admin)
+	if err != nil {
+		return dto.AdminResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memperbarui admin: %v", err)
+	}
+
+	err = recordAudit(ctx, tx, a.AuditRepository, models.AuditActionUpdate, models.AuditEntityAdmin, admin.IdAdmin, before, helpers.ConvertAdminToResponseDTO(admin))
+	if err != nil {
+		return dto.AdminResponse{}, http.StatusInternalServerError, err
+	}
+
+	// Role dan status tersimpan di dalam token, sehingga sesi lama harus dicabut
+	err = a.SessionRepository.DeleteSessionsByAdminId(ctx, tx, admin.IdAdmin)
+	if err != nil {
+		return dto.AdminResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mencabut sesi admin: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dto.AdminResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return helpers.ConvertAdminToResponseDTO(admin), http.StatusOK, nil
+}
+
+// DeleteAdmin implements AdminService.
+func (a *AdminServiceImpl) DeleteAdmin(ctx context.Context, idAdmin string) (int, error) {
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	admin, err := a.AdminRepository.GetAdminById(ctx, tx, idAdmin)
+	if err != nil {
+		return http.StatusNotFound, fmt.Errorf("gagal mendapatkan admin: %v", err)
+	}
+
+	if admin.Role == models.RoleSuperadmin && admin.Status == models.AdminStatusAktif {
+		if code, err := a.ensureOtherSuperadmin(ctx, tx, idAdmin); err != nil {
+			return code, err
+		}
+	}
+
+	err = a.SessionRepository.DeleteSessionsByAdminId(ctx, tx, idAdmin)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mencabut sesi admin: %v", err)
+	}
+
+	err = a.AdminRepository.DeleteAdmin(ctx, tx, idAdmin)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal menghapus admin: %v", err)
+	}
+
+	err = recordAudit(ctx, tx, a.AuditRepository, models.AuditActionDelete, models.AuditEntityAdmin, idAdmin, helpers.ConvertAdminToResponseDTO(admin), nil)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return http.StatusOK, nil
+}
+
+// ensureOtherSuperadmin mencegah admin yang sedang login atau superadmin aktif terakhir
+// kehilangan akses superadmin, agar sistem tidak terkunci tanpa pengelola.
+func (a *AdminServiceImpl) ensureOtherSuperadmin(ctx context.Context, tx *sql.Tx, idAdmin string) (int, error) {
+	if claims, ok := helpers.ClaimsFromContext(ctx); ok && claims.Id == idAdmin {
+		return http.StatusBadRequest, fmt.Errorf("tidak dapat menurunkan, menonaktifkan, atau menghapus akun sendiri")
+	}
+
+	count, err := a.AdminRepository.CountActiveSuperadmin(ctx, tx)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal menghitung superadmin: %v", err)
+	}
+
+	if count <= 1 {
+		return http.StatusBadRequest, fmt.Errorf("minimal harus ada satu superadmin aktif")
+	}
+
+	return http.StatusOK, nil
+}
+
+// LoginTwoFactor implements AdminService.
+func (a *AdminServiceImpl) LoginTwoFactor(ctx context.Context, loginReq dto.TwoFactorLoginRequest, ipAddress string) (dto.TokenResponse, int, error) {
+	if loginReq.Code == "" && loginReq.RecoveryCode == "" {
+		return dto.TokenResponse{}, http.StatusBadRequest, fmt.Errorf("kode verifikasi tidak boleh kosong")
+	}
+
+	claims, err := helpers.ValidateJWT(loginReq.ChallengeToken)
+	if err != nil || claims.Purpose != helpers.TokenPurposeTwoFactor {
+		return dto.TokenResponse{}, http.StatusUnauthorized, fmt.Errorf("token tantangan tidak valid atau sudah kedaluwarsa")
+	}
+
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return dto.TokenResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	if code, err := a.checkLoginThrottle(ctx, tx, claims.Username, ipAddress); err != nil {
+		return dto.TokenResponse{}, code, err
+	}
+
+	admin, err := a.AdminRepository.GetAdminById(ctx, tx, claims.Id)
+	if err != nil {
+		return dto.TokenResponse{}, http.StatusUnauthorized, fmt.Errorf("gagal mendapatkan admin: %v", err)
+	}
+
+	if admin.Status != models.AdminStatusAktif || !admin.TotpEnabled {
+		return dto.TokenResponse{}, http.StatusUnauthorized, fmt.Errorf("token tantangan tidak valid atau sudah kedaluwarsa")
+	}
+
+	valid, err := a.verifySecondFactor(ctx, tx, &admin, loginReq.Code, loginReq.RecoveryCode)
+	if err != nil {
+		return dto.TokenResponse{}, http.StatusInternalServerError, err
+	}
+
+	if !valid {
+		if code, err := a.recordFailedLogin(ctx, tx, admin.Username, ipAddress); err != nil {
+			return dto.TokenResponse{}, code, err
+		}
+		return dto.TokenResponse{}, http.StatusBadRequest, fmt.Errorf("kode verifikasi salah")
+	}
+
+	tokenResponse, err := a.completeLogin(ctx, tx, admin, ipAddress)
+	if err != nil {
+		return dto.TokenResponse{}, http.StatusInternalServerError, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dto.TokenResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return tokenResponse, http.StatusOK, nil
+}
+
+// SetupTwoFactor implements AdminService.
+func (a *AdminServiceImpl) SetupTwoFactor(ctx context.Context) (dto.TwoFactorSetupResponse, int, error) {
+	claims, ok := helpers.ClaimsFromContext(ctx)
+	if !ok {
+		return dto.TwoFactorSetupResponse{}, http.StatusUnauthorized, fmt.Errorf("admin belum login")
+	}
+
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return dto.TwoFactorSetupResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	admin, err := a.AdminRepository.GetAdminById(ctx, tx, claims.Id)
+	if err != nil {
+		return dto.TwoFactorSetupResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan admin: %v", err)
+	}
+
+	if admin.TotpEnabled {
+		return dto.TwoFactorSetupResponse{}, http.StatusBadRequest, fmt.Errorf("verifikasi dua langkah sudah aktif")
+	}
+
+	secret, err := helpers.GenerateTOTPSecret()
+	if err != nil {
+		return dto.TwoFactorSetupResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal membuat secret TOTP: %v", err)
+	}
+
+	// Secret disimpan dalam keadaan belum aktif sampai admin membuktikan authenticator sudah terpasang
+	admin.TotpSecret = secret
+	admin.TotpLastStep = 0
+
+	err = a.AdminRepository.UpdateTotpAdmin(ctx, tx, admin)
+	if err != nil {
+		return dto.TwoFactorSetupResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal menyimpan secret TOTP: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dto.TwoFactorSetupResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	issuer := os.Getenv("TOTP_ISSUER")
+	if issuer == "" {
+		issuer = "Desa Sukamaju"
+	}
+
+	return dto.TwoFactorSetupResponse{
+		Secret:     secret,
+		OtpauthURI: helpers.TOTPProvisioningURI(issuer, admin.Username, secret),
+	}, http.StatusOK, nil
+}
+
+// EnableTwoFactor implements AdminService.
+func (a *AdminServiceImpl) EnableTwoFactor(ctx context.Context, codeReq dto.TwoFactorCodeRequest) (dto.RecoveryCodesResponse, int, error) {
+	claims, ok := helpers.ClaimsFromContext(ctx)
+	if !ok {
+		return dto.RecoveryCodesResponse{}, http.StatusUnauthorized, fmt.Errorf("admin belum login")
+	}
+
+	if codeReq.Code == "" {
+		return dto.RecoveryCodesResponse{}, http.StatusBadRequest, fmt.Errorf("kode verifikasi tidak boleh kosong")
+	}
+
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return dto.RecoveryCodesResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	admin, err := a.AdminRepository.GetAdminById(ctx, tx, claims.Id)
+	if err != nil {
+		return dto.RecoveryCodesResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan admin: %v", err)
+	}
+
+	if admin.TotpEnabled {
+		return dto.RecoveryCodesResponse{}, http.StatusBadRequest, fmt.Errorf("verifikasi dua langkah sudah aktif")
+	}
+
+	if admin.TotpSecret == "" {
+		return dto.RecoveryCodesResponse{}, http.StatusBadRequest, fmt.Errorf("lakukan setup verifikasi dua langkah terlebih dahulu")
+	}
+
+	step, valid := helpers.ValidateTOTP(admin.TotpSecret, codeReq.Code, time.Now())
+	if !valid {
+		return dto.RecoveryCodesResponse{}, http.StatusBadRequest, fmt.Errorf("kode verifikasi salah")
+	}
+
+	admin.TotpEnabled = true
+	admin.TotpLastStep = step
+
+	err = a.AdminRepository.UpdateTotpAdmin(ctx, tx, admin)
+	if err != nil {
+		return dto.RecoveryCodesResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengaktifkan verifikasi dua langkah: %v", err)
+	}
+
+	err = recordAudit(ctx, tx, a.AuditRepository, models.AuditActionUpdate, models.AuditEntityAdmin, admin.IdAdmin,
+		map[string]bool{"totp_enabled": false}, map[string]bool{"totp_enabled": true})
+	if err != nil {
+		return dto.RecoveryCodesResponse{}, http.StatusInternalServerError, err
+	}
+
+	err = a.RecoveryCodeRepository.DeleteRecoveryCodesByAdminId(ctx, tx, admin.IdAdmin)
+	if err != nil {
+		return dto.RecoveryCodesResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal menghapus kode pemulihan lama: %v", err)
+	}
+
+	var recoveryCodes []string
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := helpers.GenerateRecoveryCode()
+		if err != nil {
+			return dto.RecoveryCodesResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal membuat kode pemulihan: %v", err)
+		}
+
+		codeHash, err := helpers.HashPassword(code)
+		if err != nil {
+			return dto.RecoveryCodesResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal hash kode pemulihan: %v", err)
+		}
+
+		err = a.RecoveryCodeRepository.AddRecoveryCode(ctx, tx, models.RecoveryCode{
+			IdRecoveryCode: uuid.New().String(),
+			IdAdmin:        admin.IdAdmin,
+			CodeHash:       codeHash,
+		})
+		if err != nil {
+			return dto.RecoveryCodesResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal menyimpan kode pemulihan: %v", err)
+		}
+
+		recoveryCodes = append(recoveryCodes, code)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dto.RecoveryCodesResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return dto.RecoveryCodesResponse{RecoveryCodes: recoveryCodes}, http.StatusOK, nil
+}
+
+// DisableTwoFactor implements AdminService.
+func (a *AdminServiceImpl) DisableTwoFactor(ctx context.Context, disableReq dto.DisableTwoFactorRequest) (int, error) {
+	claims, ok := helpers.ClaimsFromContext(ctx)
+	if !ok {
+		return http.StatusUnauthorized, fmt.Errorf("admin belum login")
+	}
+
+	if disableReq.Password == "" {
+		return http.StatusBadRequest, fmt.Errorf("password tidak boleh kosong")
+	}
+
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	admin, err := a.AdminRepository.GetAdminById(ctx, tx, claims.Id)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan admin: %v", err)
+	}
+
+	if !admin.TotpEnabled {
+		return http.StatusBadRequest, fmt.Errorf("verifikasi dua langkah belum aktif")
+	}
+
+	if !helpers.VerifyPassword(admin.Password, disableReq.Password) {
+		return http.StatusBadRequest, fmt.Errorf("password salah")
+	}
+
+	valid, err := a.verifySecondFactor(ctx, tx, &admin, disableReq.Code, disableReq.RecoveryCode)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if !valid {
+		return http.StatusBadRequest, fmt.Errorf("kode verifikasi salah")
+	}
+
+	admin.TotpSecret = ""
+	admin.TotpEnabled = false
+	admin.TotpLastStep = 0
+
+	err = a.AdminRepository.UpdateTotpAdmin(ctx, tx, admin)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal menonaktifkan verifikasi dua langkah: %v", err)
+	}
+
+	err = recordAudit(ctx, tx, a.AuditRepository, models.AuditActionUpdate, models.AuditEntityAdmin, admin.IdAdmin,
+		map[string]bool{"totp_enabled": true}, map[string]bool{"totp_enabled": false})
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	err = a.RecoveryCodeRepository.DeleteRecoveryCodesByAdminId(ctx, tx, admin.IdAdmin)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal menghapus kode pemulihan: %v", err)
+	}
+
 	if err := tx.Commit(); err != nil {
 		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
 	}
 
 	return http.StatusOK, nil
 }
+
+// verifySecondFactor memeriksa kode TOTP atau kode pemulihan. Kode TOTP yang sudah pernah
+// dipakai ditolak, dan kode pemulihan langsung dihapus setelah digunakan.
+func (a *AdminServiceImpl) verifySecondFactor(ctx context.Context, tx *sql.Tx, admin *models.Admin, code, recoveryCode string) (bool, error) {
+	if code != "" {
+		step, valid := helpers.ValidateTOTP(admin.TotpSecret, code, time.Now())
+		if !valid || step <= admin.TotpLastStep {
+			return false, nil
+		}
+
+		admin.TotpLastStep = step
+		if err := a.AdminRepository.UpdateTotpAdmin(ctx, tx, *admin); err != nil {
+			return false, fmt.Errorf("gagal memperbarui status TOTP: %v", err)
+		}
+
+		return true, nil
+	}
+
+	if recoveryCode == "" {
+		return false, nil
+	}
+
+	codes, err := a.RecoveryCodeRepository.GetRecoveryCodesByAdminId(ctx, tx, admin.IdAdmin)
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan kode pemulihan: %v", err)
+	}
+
+	normalized := strings.ToUpper(strings.TrimSpace(recoveryCode))
+	for _, stored := range codes {
+		if helpers.VerifyPassword(stored.CodeHash, normalized) {
+			if err := a.RecoveryCodeRepository.DeleteRecoveryCode(ctx, tx, stored.IdRecoveryCode); err != nil {
+				return false, fmt.Errorf("gagal menghapus kode pemulihan: %v", err)
+			}
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// completeLogin membuat sesi baru dan mencatat login yang berhasil
+func (a *AdminServiceImpl) completeLogin(ctx context.Context, tx *sql.Tx, admin models.Admin, ipAddress string) (dto.TokenResponse, error) {
+	tokenResponse, err := a.createSession(ctx, tx, admin)
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+
+	err = a.LoginAttemptRepository.AddLoginAttempt(ctx, tx, models.LoginAttempt{
+		IdLoginAttempt: uuid.New().String(),
+		Username:       admin.Username,
+		IpAddress:      ipAddress,
+		Success:        true,
+		CreatedAt:      time.Now(),
+	})
+	if err != nil {
+		return dto.TokenResponse{}, fmt.Errorf("gagal mencatat percobaan login: %v", err)
+	}
+
+	return tokenResponse, nil
+}
@@ -0,0 +1,468 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/syrlramadhan/desa-sukamaju-api/dto"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+	"github.com/syrlramadhan/desa-sukamaju-api/repositories"
+	"github.com/syrlramadhan/desa-sukamaju-api/storage"
+)
+
+// maxAlbumJudulLength adalah panjang maksimal judul album, sesuai kolom di database
+const maxAlbumJudulLength = 255
+
+type AlbumService interface {
+	CreateAlbum(ctx context.Context, albumReq dto.AlbumRequest) (dto.AlbumResponse, int, error)
+	GetAllAlbum(ctx context.Context, albumQuery dto.AlbumQuery) ([]dto.AlbumResponse, dto.PaginationMeta, int, error)
+	GetAlbumById(ctx context.Context, idAlbum string) (dto.AlbumResponse, int, error)
+	UpdateAlbum(ctx context.Context, idAlbum string, albumReq dto.AlbumRequest) (dto.AlbumResponse, int, error)
+	DeleteAlbum(ctx context.Context, idAlbum string) (int, error)
+	CreatePhotoAlbum(ctx context.Context, r *http.Request, idAlbum string) (int, error)
+	UpdatePhotoAlbum(ctx context.Context, filename string, photoReq dto.GaleriPhotoRequest) (int, error)
+	UpdateUrutanPhotoAlbum(ctx context.Context, idAlbum string, urutanReq dto.GaleriUrutanRequest) (int, error)
+	SetCoverPhotoAlbum(ctx context.Context, idAlbum string, coverReq dto.GaleriCoverRequest) (int, error)
+	DeletePhotoAlbumByFilename(ctx context.Context, filename string) (int, error)
+}
+
+type albumServiceImpl struct {
+	repo       repositories.AlbumRepository
+	galeriRepo repositories.GaleriRepository
+	auditRepo  repositories.AuditRepository
+	storage    storage.Storage
+	DB         *sql.DB
+}
+
+func NewAlbumService(repo repositories.AlbumRepository, galeriRepo repositories.GaleriRepository, auditRepo repositories.AuditRepository, fileStorage storage.Storage, db *sql.DB) AlbumService {
+	return &albumServiceImpl{
+		repo:       repo,
+		galeriRepo: galeriRepo,
+		auditRepo:  auditRepo,
+		storage:    fileStorage,
+		DB:         db,
+	}
+}
+
+// CreateAlbum implements AlbumService.
+// Album dibuat tanpa foto, foto ditambahkan lewat CreatePhotoAlbum.
+func (a *albumServiceImpl) CreateAlbum(ctx context.Context, albumReq dto.AlbumRequest) (dto.AlbumResponse, int, error) {
+	judul, deskripsi, err := validateAlbumRequest(albumReq)
+	if err != nil {
+		return dto.AlbumResponse{}, http.StatusBadRequest, err
+	}
+
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return dto.AlbumResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	album := models.Album{
+		IdAlbum:   uuid.New().String(),
+		Judul:     judul,
+		Deskripsi: deskripsi,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err = a.repo.AddAlbum(ctx, tx, album)
+	if err != nil {
+		return dto.AlbumResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal menambahkan album: %v", err)
+	}
+
+	err = recordAudit(ctx, tx, a.auditRepo, models.AuditActionCreate, models.AuditEntityAlbum, album.IdAlbum, nil, album)
+	if err != nil {
+		return dto.AlbumResponse{}, http.StatusInternalServerError, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dto.AlbumResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return helpers.ConvertAlbumToResponseDTO(album, a.storage), http.StatusOK, nil
+}
+
+// GetAllAlbum implements AlbumService.
+func (a *albumServiceImpl) GetAllAlbum(ctx context.Context, albumQuery dto.AlbumQuery) ([]dto.AlbumResponse, dto.PaginationMeta, int, error) {
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	albumList, total, err := a.repo.GetAllAlbum(ctx, tx, albumQuery.PerPage, (albumQuery.Page-1)*albumQuery.PerPage)
+	if err != nil {
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan data album: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	albumResponseList := []dto.AlbumResponse{}
+	for _, album := range albumList {
+		albumResponseList = append(albumResponseList, helpers.ConvertAlbumToResponseDTO(album, a.storage))
+	}
+
+	return albumResponseList, helpers.NewPaginationMeta(albumQuery.Page, albumQuery.PerPage, total), http.StatusOK, nil
+}
+
+// GetAlbumById implements AlbumService.
+func (a *albumServiceImpl) GetAlbumById(ctx context.Context, idAlbum string) (dto.AlbumResponse, int, error) {
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return dto.AlbumResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	album, code, err := a.getAlbum(ctx, tx, idAlbum)
+	if err != nil {
+		return dto.AlbumResponse{}, code, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dto.AlbumResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return helpers.ConvertAlbumToResponseDTO(album, a.storage), http.StatusOK, nil
+}
+
+// UpdateAlbum implements AlbumService.
+func (a *albumServiceImpl) UpdateAlbum(ctx context.Context, idAlbum string, albumReq dto.AlbumRequest) (dto.AlbumResponse, int, error) {
+	judul, deskripsi, err := validateAlbumRequest(albumReq)
+	if err != nil {
+		return dto.AlbumResponse{}, http.StatusBadRequest, err
+	}
+
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return dto.AlbumResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	before, code, err := a.getAlbum(ctx, tx, idAlbum)
+	if err != nil {
+		return dto.AlbumResponse{}, code, err
+	}
+
+	album := before
+	album.Judul = judul
+	album.Deskripsi = deskripsi
+	album.UpdatedAt = time.Now()
+
+	err = a.repo.UpdateAlbum(ctx, tx, album)
+	if err != nil {
+		return dto.AlbumResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengupdate album: %v", err)
+	}
+
+	err = recordAudit(ctx, tx, a.auditRepo, models.AuditActionUpdate, models.AuditEntityAlbum, idAlbum, before, album)
+	if err != nil {
+		return dto.AlbumResponse{}, http.StatusInternalServerError, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dto.AlbumResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return helpers.ConvertAlbumToResponseDTO(album, a.storage), http.StatusOK, nil
+}
+
+// DeleteAlbum implements AlbumService.
+// Album dihapus permanen bersama seluruh fotonya, tidak melalui tempat sampah.
+func (a *albumServiceImpl) DeleteAlbum(ctx context.Context, idAlbum string) (int, error) {
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	album, code, err := a.getAlbum(ctx, tx, idAlbum)
+	if err != nil {
+		return code, err
+	}
+
+	// Foto album di tempat sampah ikut terhapus permanen, sehingga filenya juga perlu dihapus
+	trashedPhotos, _, err := a.galeriRepo.GetDeletedPhotos(ctx, tx, models.TrashFilter{IdAlbum: idAlbum})
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan foto album: %v", err)
+	}
+
+	// Hapus album dari database (termasuk galeri karena foreign key constraint)
+	err = a.repo.DeleteAlbum(ctx, tx, idAlbum)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal menghapus album: %v", err)
+	}
+
+	err = recordAudit(ctx, tx, a.auditRepo, models.AuditActionDelete, models.AuditEntityAlbum, idAlbum, album, nil)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	// Hapus file gambar dari storage setelah commit berhasil, file foto yang sudah di tempat sampah
+	// berada di folder tempat sampah
+	removeGaleriFiles(ctx, a.storage, album.Foto)
+	removeTrashedGaleriFiles(ctx, a.storage, trashedPhotos)
+
+	return http.StatusOK, nil
+}
+
+// CreatePhotoAlbum implements AlbumService.
+// Caption dan alt_text dikirim berulang mengikuti urutan file gambar, sama seperti upload foto berita.
+func (a *albumServiceImpl) CreatePhotoAlbum(ctx context.Context, r *http.Request, idAlbum string) (int, error) {
+	err := r.ParseMultipartForm(100 << 20) // 100MB
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to parse form: %v", err)
+	}
+
+	if r.MultipartForm == nil || r.MultipartForm.File == nil {
+		return http.StatusBadRequest, fmt.Errorf("form data tidak valid")
+	}
+
+	fileHeaders, exists := r.MultipartForm.File["gambar"]
+	if !exists || len(fileHeaders) == 0 {
+		return http.StatusBadRequest, fmt.Errorf("minimal satu gambar harus diupload")
+	}
+
+	if len(fileHeaders) > 10 {
+		return http.StatusBadRequest, fmt.Errorf("maksimal 10 gambar dapat diupload sekaligus")
+	}
+
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	album, code, err := a.getAlbum(ctx, tx, idAlbum)
+	if err != nil {
+		return code, err
+	}
+
+	// Foto baru ditaruh setelah foto yang sudah ada, dan menjadi sampul jika album belum punya sampul
+	nextUrutan, needCover := nextPhotoPosition(album.Foto)
+
+	var uploadedPhotos []models.Galeri
+	for i, fileHeader := range fileHeaders {
+		// Validasi ukuran file (maksimal 5MB per file)
+		if fileHeader.Size > 5*1024*1024 {
+			removeGaleriFiles(ctx, a.storage, uploadedPhotos)
+			return http.StatusBadRequest, fmt.Errorf("ukuran file %s terlalu besar. Maksimal 5MB per file", fileHeader.Filename)
+		}
+
+		// Generate nama file unik, ekstensinya ditentukan dari tipe gambar hasil validasi
+		timestamp := time.Now().Unix()
+		filename := fmt.Sprintf("album_%s_%d_%d", idAlbum, timestamp, i+1)
+
+		caption, altText, err := photoFormInfo(r, i)
+		if err != nil {
+			removeGaleriFiles(ctx, a.storage, uploadedPhotos)
+			return http.StatusBadRequest, err
+		}
+
+		galeri, status, err := saveGaleriPhoto(ctx, a.storage, fileHeader, filename)
+		if err != nil {
+			removeGaleriFiles(ctx, a.storage, uploadedPhotos)
+			return status, err
+		}
+
+		galeri.IdAlbum = idAlbum
+		galeri.Urutan = nextUrutan + i
+		galeri.Caption = caption
+		galeri.AltText = altText
+		galeri.IsCover = needCover && i == 0
+		uploadedPhotos = append(uploadedPhotos, galeri)
+	}
+
+	for _, photo := range uploadedPhotos {
+		err = a.galeriRepo.AddPhoto(ctx, tx, photo)
+		if err != nil {
+			removeGaleriFiles(ctx, a.storage, uploadedPhotos)
+			return http.StatusInternalServerError, fmt.Errorf("gagal menambahkan foto: %v", err)
+		}
+
+		err = recordAudit(ctx, tx, a.auditRepo, models.AuditActionCreate, models.AuditEntityGaleri, photo.IdGaleri, nil, photo)
+		if err != nil {
+			removeGaleriFiles(ctx, a.storage, uploadedPhotos)
+			return http.StatusInternalServerError, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		removeGaleriFiles(ctx, a.storage, uploadedPhotos)
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return http.StatusOK, nil
+}
+
+// UpdatePhotoAlbum implements AlbumService.
+func (a *albumServiceImpl) UpdatePhotoAlbum(ctx context.Context, filename string, photoReq dto.GaleriPhotoRequest) (int, error) {
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	photo, code, err := getGaleriPhoto(ctx, tx, a.galeriRepo, filename, isAlbumPhoto)
+	if err != nil {
+		return code, err
+	}
+
+	if code, err := updateGaleriPhotoInfo(ctx, tx, a.galeriRepo, a.auditRepo, photo, photoReq); err != nil {
+		return code, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return http.StatusOK, nil
+}
+
+// UpdateUrutanPhotoAlbum implements AlbumService.
+// Daftar id_galeri harus memuat seluruh foto album tepat satu kali, urutannya menjadi urutan tampil.
+func (a *albumServiceImpl) UpdateUrutanPhotoAlbum(ctx context.Context, idAlbum string, urutanReq dto.GaleriUrutanRequest) (int, error) {
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	album, code, err := a.getAlbum(ctx, tx, idAlbum)
+	if err != nil {
+		return code, err
+	}
+
+	if code, err := reorderGaleriPhotos(ctx, tx, a.galeriRepo, a.auditRepo, album.Foto, urutanReq.IdGaleri); err != nil {
+		return code, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return http.StatusOK, nil
+}
+
+// SetCoverPhotoAlbum implements AlbumService.
+func (a *albumServiceImpl) SetCoverPhotoAlbum(ctx context.Context, idAlbum string, coverReq dto.GaleriCoverRequest) (int, error) {
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	album, code, err := a.getAlbum(ctx, tx, idAlbum)
+	if err != nil {
+		return code, err
+	}
+
+	before, code, err := findCoverCandidate(album.Foto, coverReq.IdGaleri)
+	if err != nil {
+		return code, err
+	}
+	if before.IsCover {
+		return http.StatusOK, nil
+	}
+
+	err = a.repo.SetCoverPhotoAlbum(ctx, tx, idAlbum, before.IdGaleri)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengubah sampul album: %v", err)
+	}
+
+	photo := before
+	photo.IsCover = true
+	err = recordAudit(ctx, tx, a.auditRepo, models.AuditActionUpdate, models.AuditEntityGaleri, photo.IdGaleri, before, photo)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return http.StatusOK, nil
+}
+
+// DeletePhotoAlbumByFilename implements AlbumService.
+// Jika foto yang dihapus adalah sampul, foto pertama yang tersisa menjadi sampul baru.
+func (a *albumServiceImpl) DeletePhotoAlbumByFilename(ctx context.Context, filename string) (int, error) {
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	photo, code, err := getGaleriPhoto(ctx, tx, a.galeriRepo, filename, isAlbumPhoto)
+	if err != nil {
+		return code, err
+	}
+
+	if code, err := trashGaleriPhotos(ctx, tx, a.galeriRepo, a.auditRepo, []models.Galeri{photo}); err != nil {
+		return code, err
+	}
+
+	photos, err := a.repo.GetPhotosByAlbumId(ctx, tx, photo.IdAlbum)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan foto album: %v", err)
+	}
+
+	if cover, ok := missingCover(photos); ok {
+		err = a.repo.SetCoverPhotoAlbum(ctx, tx, photo.IdAlbum, cover.IdGaleri)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("gagal mengubah sampul album: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	// Pindahkan file ke tempat sampah setelah commit berhasil agar tidak lagi dilayani ke publik
+	trashGaleriFiles(ctx, a.storage, []models.Galeri{photo})
+
+	return http.StatusOK, nil
+}
+
+// getAlbum mengambil album beserta fotonya, 404 jika albumnya tidak ada
+func (a *albumServiceImpl) getAlbum(ctx context.Context, tx *sql.Tx, idAlbum string) (models.Album, int, error) {
+	album, err := a.repo.GetAlbumById(ctx, tx, idAlbum)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Album{}, http.StatusNotFound, fmt.Errorf("album dengan ID %s tidak ditemukan", idAlbum)
+		}
+		return models.Album{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan album: %v", err)
+	}
+
+	return album, http.StatusOK, nil
+}
+
+// validateAlbumRequest merapikan judul dan deskripsi album, judul wajib diisi
+func validateAlbumRequest(albumReq dto.AlbumRequest) (string, string, error) {
+	judul := strings.TrimSpace(albumReq.Judul)
+	if judul == "" {
+		return "", "", fmt.Errorf("judul album tidak boleh kosong")
+	}
+	if utf8.RuneCountInString(judul) > maxAlbumJudulLength {
+		return "", "", fmt.Errorf("judul album maksimal %d karakter", maxAlbumJudulLength)
+	}
+
+	return judul, strings.TrimSpace(albumReq.Deskripsi), nil
+}
+
+func isAlbumPhoto(photo models.Galeri) bool {
+	return photo.IdAlbum != ""
+}
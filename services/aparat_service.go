@@ -4,16 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"io"
 	"net/http"
-	"os"
-	"path/filepath"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/syrlramadhan/desa-sukamaju-api/dto"
 	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
 	"github.com/syrlramadhan/desa-sukamaju-api/models"
 	"github.com/syrlramadhan/desa-sukamaju-api/repositories"
+	"github.com/syrlramadhan/desa-sukamaju-api/storage"
 )
 
 type AparatService interface {
@@ -27,12 +26,16 @@ type AparatService interface {
 
 type aparatServiceImpl struct {
 	AparatRepository repositories.AparatRepository
+	AuditRepository  repositories.AuditRepository
+	Storage          storage.Storage
 	DB               *sql.DB
 }
 
-func NewAparatService(aparatRepository repositories.AparatRepository, db *sql.DB) AparatService {
+func NewAparatService(aparatRepository repositories.AparatRepository, auditRepository repositories.AuditRepository, fileStorage storage.Storage, db *sql.DB) AparatService {
 	return &aparatServiceImpl{
 		AparatRepository: aparatRepository,
+		AuditRepository:  auditRepository,
+		Storage:          fileStorage,
 		DB:               db,
 	}
 }
@@ -51,7 +54,7 @@ func (a *aparatServiceImpl) CreateAparat(ctx context.Context, r *http.Request, a
 	aparatReq.Status = r.FormValue("status")
 	aparatReq.PeriodeMulai = r.FormValue("periode_mulai")
 	aparatReq.PeriodeSelesai = r.FormValue("periode_selesai")
-	fotoFile, _, err := r.FormFile("foto")
+	fotoFile, fotoHeader, err := r.FormFile("foto")
 	if err != nil {
 		return dto.AparatResponse{}, http.StatusInternalServerError, fmt.Errorf("failed to get foto from form: %v", err)
 	}
@@ -59,23 +62,16 @@ func (a *aparatServiceImpl) CreateAparat(ctx context.Context, r *http.Request, a
 
 	uuid := uuid.New().String()
 
-	fotoFilename := fmt.Sprintf("aparat_%s_%s.jpg", aparatReq.Nama, uuid)
-
-	uploadDir := "uploads/aparat/"
-	if _, err := os.Stat(uploadDir); os.IsNotExist(err) {
-		os.Mkdir(uploadDir, os.ModePerm)
-	}
-
-	filePath := filepath.Join(uploadDir, fotoFilename)
-	out, err := os.Create(filePath)
+	foto, err := readImage(fotoFile, fotoHeader.Filename)
 	if err != nil {
-		return dto.AparatResponse{}, http.StatusInternalServerError, fmt.Errorf("failed to create file: %v", err)
+		return dto.AparatResponse{}, http.StatusBadRequest, err
 	}
-	defer out.Close()
 
-	_, err = io.Copy(out, fotoFile)
+	// Ekstensi file ditentukan dari tipe gambar hasil validasi, bukan dari nama file kiriman client.
+	// Nama aparat tidak dipakai di nama file karena bisa berisi "/", ".." atau spasi yang ikut ke key storage.
+	fotoFilename, varianFoto, err := saveImage(ctx, a.Storage, storage.DirAparat, "aparat_"+uuid, foto)
 	if err != nil {
-		return dto.AparatResponse{}, http.StatusInternalServerError, fmt.Errorf("failed to copy file: %v", err)
+		return dto.AparatResponse{}, http.StatusInternalServerError, err
 	}
 
 	aparatReq.Foto = fotoFilename
@@ -96,18 +92,27 @@ func (a *aparatServiceImpl) CreateAparat(ctx context.Context, r *http.Request, a
 		PeriodeMulai:   aparatReq.PeriodeMulai,
 		PeriodeSelesai: aparatReq.PeriodeSelesai,
 		Foto:           aparatReq.Foto,
+		VarianFoto:     varianFoto,
 	}
 
 	addAparat, err := a.AparatRepository.AddAparat(ctx, tx, aparatModel)
 	if err != nil {
+		deleteImage(ctx, a.Storage, storage.DirAparat, fotoFilename, varianFoto)
 		return dto.AparatResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal menambahkan aparat: %v", err)
 	}
 
+	err = recordAudit(ctx, tx, a.AuditRepository, models.AuditActionCreate, models.AuditEntityAparat, addAparat.IdAparat, nil, helpers.ConvertAparatToResponseDTO(addAparat, a.Storage))
+	if err != nil {
+		deleteImage(ctx, a.Storage, storage.DirAparat, fotoFilename, varianFoto)
+		return dto.AparatResponse{}, http.StatusInternalServerError, err
+	}
+
 	if err := tx.Commit(); err != nil {
+		deleteImage(ctx, a.Storage, storage.DirAparat, fotoFilename, varianFoto)
 		return dto.AparatResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
 	}
 
-	return helpers.ConvertAparatToResponseDTO(addAparat), http.StatusOK, nil
+	return helpers.ConvertAparatToResponseDTO(addAparat, a.Storage), http.StatusOK, nil
 }
 
 // GetAllAparat implements AparatService.
@@ -127,7 +132,7 @@ func (a *aparatServiceImpl) GetAllAparat(ctx context.Context) ([]dto.AparatRespo
 		return nil, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
 	}
 
-	return helpers.ConvertAparatToListResDTO(aparats), http.StatusOK, nil
+	return helpers.ConvertAparatToListResDTO(aparats, a.Storage), http.StatusOK, nil
 }
 
 // GetAparatById implements AparatService.
@@ -147,7 +152,7 @@ func (a *aparatServiceImpl) GetAparatById(ctx context.Context, idAparat string)
 		return dto.AparatResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
 	}
 
-	return helpers.ConvertAparatToResponseDTO(aparat), http.StatusOK, nil
+	return helpers.ConvertAparatToResponseDTO(aparat, a.Storage), http.StatusOK, nil
 }
 
 // UpdateAparat implements AparatService.
@@ -159,52 +164,46 @@ func (a *aparatServiceImpl) UpdateAparat(ctx context.Context, r *http.Request, i
 	aparatReq.Status = r.FormValue("status")
 	aparatReq.PeriodeMulai = r.FormValue("periode_mulai")
 	aparatReq.PeriodeSelesai = r.FormValue("periode_selesai")
-	fotoFile, _, err := r.FormFile("foto")
+	fotoFile, fotoHeader, err := r.FormFile("foto")
 	if err != nil {
 		return dto.AparatResponse{}, http.StatusInternalServerError, fmt.Errorf("failed to get foto from form: %v", err)
 	}
 	defer fotoFile.Close()
 
-	fotoFilename := fmt.Sprintf("aparat_%s_%s.jpg", aparatReq.Nama, idAparat)
-
-	uploadDir := "uploads/aparat/"
-	if _, err := os.Stat(uploadDir); os.IsNotExist(err) {
-		os.Mkdir(uploadDir, os.ModePerm)
-	}
-
-	filePath := filepath.Join(uploadDir, fotoFilename)
-	out, err := os.Create(filePath)
+	// Gambar diproses dan disimpan sebelum transaksi dimulai, seperti CreateAparat,
+	// agar koneksi database tidak tertahan selama decode dan resize.
+	foto, err := readImage(fotoFile, fotoHeader.Filename)
 	if err != nil {
-		return dto.AparatResponse{}, http.StatusInternalServerError, fmt.Errorf("failed to create file: %v", err)
+		return dto.AparatResponse{}, http.StatusBadRequest, err
 	}
-	defer out.Close()
 
-	_, err = io.Copy(out, fotoFile)
+	// Ekstensi file ditentukan dari tipe gambar hasil validasi, bukan dari nama file kiriman client.
+	// Nama file selalu baru agar foto lama tidak tertimpa sebelum update berhasil dikomit.
+	fotoFilename, varianFoto, err := saveImage(ctx, a.Storage, storage.DirAparat, fmt.Sprintf("aparat_%s_%d", idAparat, time.Now().UnixNano()), foto)
 	if err != nil {
-		return dto.AparatResponse{}, http.StatusInternalServerError, fmt.Errorf("failed to copy file: %v", err)
+		return dto.AparatResponse{}, http.StatusInternalServerError, err
 	}
 
 	aparatReq.Foto = fotoFilename
 
 	tx, err := a.DB.Begin()
 	if err != nil {
+		deleteImage(ctx, a.Storage, storage.DirAparat, fotoFilename, varianFoto)
 		return dto.AparatResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
 	defer tx.Rollback()
 
 	getAparat, err := a.AparatRepository.GetAparatById(ctx, tx, idAparat)
 	if err != nil {
+		deleteImage(ctx, a.Storage, storage.DirAparat, fotoFilename, varianFoto)
 		return dto.AparatResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan aparat: %v", err)
 	}
 
 	if getAparat.IdAparat == "" {
+		deleteImage(ctx, a.Storage, storage.DirAparat, fotoFilename, varianFoto)
 		return dto.AparatResponse{}, http.StatusNotFound, fmt.Errorf("aparat dengan id %s tidak ditemukan", idAparat)
 	}
 
-	if _, err := os.Stat(uploadDir); os.IsExist(err) {
-		os.Remove(filepath.Join(uploadDir, getAparat.Foto))
-	}
-
 	aparatModel := models.Aparat{
 		IdAparat:       idAparat,
 		Nama:           aparatReq.Nama,
@@ -215,21 +214,36 @@ func (a *aparatServiceImpl) UpdateAparat(ctx context.Context, r *http.Request, i
 		PeriodeMulai:   aparatReq.PeriodeMulai,
 		PeriodeSelesai: aparatReq.PeriodeSelesai,
 		Foto:           aparatReq.Foto,
+		VarianFoto:     varianFoto,
 	}
 
 	err = a.AparatRepository.UpdateAparat(ctx, tx, aparatModel)
 	if err != nil {
+		deleteImage(ctx, a.Storage, storage.DirAparat, fotoFilename, varianFoto)
 		return dto.AparatResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengupdate aparat: %v", err)
 	}
 
+	err = recordAudit(ctx, tx, a.AuditRepository, models.AuditActionUpdate, models.AuditEntityAparat, idAparat, helpers.ConvertAparatToResponseDTO(getAparat, a.Storage), helpers.ConvertAparatToResponseDTO(aparatModel, a.Storage))
+	if err != nil {
+		deleteImage(ctx, a.Storage, storage.DirAparat, fotoFilename, varianFoto)
+		return dto.AparatResponse{}, http.StatusInternalServerError, err
+	}
+
 	if err := tx.Commit(); err != nil {
+		deleteImage(ctx, a.Storage, storage.DirAparat, fotoFilename, varianFoto)
 		return dto.AparatResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
 	}
 
-	return helpers.ConvertAparatToResponseDTO(aparatModel), http.StatusOK, nil
+	// Foto lama baru dihapus setelah commit berhasil
+	if getAparat.Foto != fotoFilename {
+		deleteImage(ctx, a.Storage, storage.DirAparat, getAparat.Foto, getAparat.VarianFoto)
+	}
+
+	return helpers.ConvertAparatToResponseDTO(aparatModel, a.Storage), http.StatusOK, nil
 }
 
 // DeleteAparat implements AparatService.
+// Aparat dipindahkan ke tempat sampah dan masih dapat dipulihkan sampai masa simpannya habis.
 func (a *aparatServiceImpl) DeleteAparat(ctx context.Context, idAparat string) (int, error) {
 	tx, err := a.DB.Begin()
 	if err != nil {
@@ -239,27 +253,29 @@ func (a *aparatServiceImpl) DeleteAparat(ctx context.Context, idAparat string) (
 
 	getAparat, err := a.AparatRepository.GetAparatById(ctx, tx, idAparat)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return http.StatusNotFound, fmt.Errorf("aparat dengan id %s tidak ditemukan", idAparat)
+		}
 		return http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan aparat: %v", err)
 	}
 
-	if getAparat.IdAparat == "" {
-		return http.StatusNotFound, fmt.Errorf("aparat dengan id %s tidak ditemukan", idAparat)
-	}
-
-	err = a.AparatRepository.DeleteAparat(ctx, tx, idAparat)
+	err = a.AparatRepository.TrashAparat(ctx, tx, []string{idAparat}, time.Now())
 	if err != nil {
 		return http.StatusInternalServerError, fmt.Errorf("gagal menghapus aparat: %v", err)
 	}
 
-	uploadDir := "uploads/aparat/"
-	if _, err := os.Stat(uploadDir); os.IsExist(err) {
-		os.Remove(filepath.Join(uploadDir, getAparat.Foto))
+	err = recordAudit(ctx, tx, a.AuditRepository, models.AuditActionDelete, models.AuditEntityAparat, idAparat, helpers.ConvertAparatToResponseDTO(getAparat, a.Storage), nil)
+	if err != nil {
+		return http.StatusInternalServerError, err
 	}
 
 	if err := tx.Commit(); err != nil {
 		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
 	}
 
+	// Pindahkan foto ke tempat sampah setelah commit berhasil agar tidak lagi dilayani ke publik
+	moveImage(ctx, a.Storage, storage.DirAparat, trashDir(storage.DirAparat), getAparat.Foto, getAparat.VarianFoto)
+
 	return http.StatusOK, nil
 }
 
@@ -276,25 +292,24 @@ func (a *aparatServiceImpl) BulkDeleteAparat(ctx context.Context, idAparat []str
 	for _, id := range idAparat {
 		getAparat, err := a.AparatRepository.GetAparatById(ctx, tx, id)
 		if err != nil {
+			if err == sql.ErrNoRows {
+				return http.StatusNotFound, fmt.Errorf("aparat dengan id %s tidak ditemukan", id)
+			}
 			return http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan aparat: %v", err)
 		}
 
-		if getAparat.IdAparat == "" {
-			return http.StatusNotFound, fmt.Errorf("aparat dengan id %s tidak ditemukan", id)
-		}
-
 		aparat = append(aparat, getAparat)
 	}
 
-	err = a.AparatRepository.BulkDeleteAparat(ctx, tx, idAparat)
+	err = a.AparatRepository.TrashAparat(ctx, tx, idAparat, time.Now())
 	if err != nil {
 		return http.StatusInternalServerError, fmt.Errorf("gagal menghapus aparat: %v", err)
 	}
 
-	for _, aparat := range aparat {
-		uploadDir := "uploads/aparat/"
-		if _, err := os.Stat(uploadDir); os.IsExist(err) {
-			os.Remove(filepath.Join(uploadDir, aparat.Foto))
+	for _, deleted := range aparat {
+		err = recordAudit(ctx, tx, a.AuditRepository, models.AuditActionDelete, models.AuditEntityAparat, deleted.IdAparat, helpers.ConvertAparatToResponseDTO(deleted, a.Storage), nil)
+		if err != nil {
+			return http.StatusInternalServerError, err
 		}
 	}
 
@@ -302,5 +317,10 @@ func (a *aparatServiceImpl) BulkDeleteAparat(ctx context.Context, idAparat []str
 		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
 	}
 
+	// Pindahkan foto ke tempat sampah setelah commit berhasil agar tidak lagi dilayani ke publik
+	for _, deleted := range aparat {
+		moveImage(ctx, a.Storage, storage.DirAparat, trashDir(storage.DirAparat), deleted.Foto, deleted.VarianFoto)
+	}
+
 	return http.StatusOK, nil
 }
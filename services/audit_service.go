@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/syrlramadhan/desa-sukamaju-api/dto"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+	"github.com/syrlramadhan/desa-sukamaju-api/repositories"
+)
+
+type AuditService interface {
+	GetAllAudit(ctx context.Context, auditQuery dto.AuditQuery) ([]dto.AuditResponse, dto.PaginationMeta, int, error)
+}
+
+type auditServiceImpl struct {
+	AuditRepository repositories.AuditRepository
+	DB              *sql.DB
+}
+
+func NewAuditService(auditRepository repositories.AuditRepository, db *sql.DB) AuditService {
+	return &auditServiceImpl{
+		AuditRepository: auditRepository,
+		DB:              db,
+	}
+}
+
+// GetAllAudit implements AuditService.
+func (a *auditServiceImpl) GetAllAudit(ctx context.Context, auditQuery dto.AuditQuery) ([]dto.AuditResponse, dto.PaginationMeta, int, error) {
+	filter := models.AuditFilter{
+		IdAdmin:  auditQuery.IdAdmin,
+		Action:   auditQuery.Action,
+		Entity:   auditQuery.Entity,
+		EntityId: auditQuery.EntityId,
+		Limit:    auditQuery.PerPage,
+		Offset:   (auditQuery.Page - 1) * auditQuery.PerPage,
+	}
+
+	// Tanggal filter ditafsirkan sesuai zona waktu server (APP_TIMEZONE), bukan UTC
+	if auditQuery.From != "" {
+		from, err := time.ParseInLocation("2006-01-02", auditQuery.From, helpers.Location())
+		if err != nil {
+			return nil, dto.PaginationMeta{}, http.StatusBadRequest, fmt.Errorf("format tanggal from harus YYYY-MM-DD")
+		}
+		filter.From = from
+	}
+
+	if auditQuery.To != "" {
+		to, err := time.ParseInLocation("2006-01-02", auditQuery.To, helpers.Location())
+		if err != nil {
+			return nil, dto.PaginationMeta{}, http.StatusBadRequest, fmt.Errorf("format tanggal to harus YYYY-MM-DD")
+		}
+		// Tanggal akhir bersifat inklusif sehingga dihitung sampai akhir hari
+		filter.To = to.AddDate(0, 0, 1)
+	}
+
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	audits, total, err := a.AuditRepository.GetAllAudit(ctx, tx, filter)
+	if err != nil {
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan audit log: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	var auditResponse []dto.AuditResponse
+	for _, audit := range audits {
+		auditResponse = append(auditResponse, dto.AuditResponse{
+			IdAudit:   audit.IdAudit,
+			IdAdmin:   audit.IdAdmin,
+			Username:  audit.Username,
+			Action:    audit.Action,
+			Entity:    audit.Entity,
+			EntityId:  audit.EntityId,
+			Before:    rawJSONOrNull(audit.Before),
+			After:     rawJSONOrNull(audit.After),
+			CreatedAt: audit.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return auditResponse, helpers.NewPaginationMeta(auditQuery.Page, auditQuery.PerPage, total), http.StatusOK, nil
+}
+
+// recordAudit mencatat perubahan data di dalam transaksi yang sama dengan perubahannya,
+// sehingga audit log hanya tersimpan jika perubahan berhasil dikomit.
+// before dan after boleh nil, misalnya before pada aksi create.
+func recordAudit(ctx context.Context, tx *sql.Tx, repo repositories.AuditRepository, action, entity, entityId string, before, after interface{}) error {
+	audit := models.AuditLog{
+		IdAudit:   uuid.New().String(),
+		Action:    action,
+		Entity:    entity,
+		EntityId:  entityId,
+		CreatedAt: time.Now(),
+	}
+
+	if claims, ok := helpers.ClaimsFromContext(ctx); ok {
+		audit.IdAdmin = claims.Id
+		audit.Username = claims.Username
+	}
+
+	if before != nil {
+		data, err := json.Marshal(before)
+		if err != nil {
+			return fmt.Errorf("gagal membuat audit log: %v", err)
+		}
+		audit.Before = string(data)
+	}
+
+	if after != nil {
+		data, err := json.Marshal(after)
+		if err != nil {
+			return fmt.Errorf("gagal membuat audit log: %v", err)
+		}
+		audit.After = string(data)
+	}
+
+	if err := repo.AddAudit(ctx, tx, audit); err != nil {
+		return fmt.Errorf("gagal mencatat audit log: %v", err)
+	}
+
+	return nil
+}
+
+func rawJSONOrNull(data string) json.RawMessage {
+	if data == "" {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(data)
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+	"github.com/syrlramadhan/desa-sukamaju-api/repositories"
+)
+
+// beritaSchedulerMaxWait membatasi lama scheduler tidur, sehingga jadwal yang dibuat
+// setelah scheduler mulai menunggu tetap diproses tanpa perlu restart
+const beritaSchedulerMaxWait = time.Minute
+
+type BeritaScheduler interface {
+	Run(ctx context.Context)
+	ApplyDue(ctx context.Context, now time.Time) (int, error)
+}
+
+type beritaSchedulerImpl struct {
+	repo      repositories.BeritaRepository
+	auditRepo repositories.AuditRepository
+	DB        *sql.DB
+}
+
+func NewBeritaScheduler(repo repositories.BeritaRepository, auditRepo repositories.AuditRepository, db *sql.DB) BeritaScheduler {
+	return &beritaSchedulerImpl{
+		repo:      repo,
+		auditRepo: auditRepo,
+		DB:        db,
+	}
+}
+
+// Run implements BeritaScheduler.
+// Menerbitkan berita terjadwal dan mengarsipkan berita kedaluwarsa hingga ctx dibatalkan.
+// Scheduler tidur sampai jadwal terdekat berikutnya, paling lama beritaSchedulerMaxWait.
+// Jika beberapa instance berjalan, hanya satu yang memproses jadwal pada saat yang sama.
+func (b *beritaSchedulerImpl) Run(ctx context.Context) {
+	for {
+		wait := beritaSchedulerMaxWait
+
+		now := time.Now()
+		_, err := RunExclusive(ctx, b.DB, JobLockBeritaScheduler, func() error {
+			_, err := b.ApplyDue(ctx, now)
+			return err
+		})
+		if err != nil {
+			fmt.Printf("gagal menjalankan jadwal berita: %v\n", err)
+		} else if next, err := b.nextScheduleTime(ctx, now); err != nil {
+			fmt.Printf("gagal mendapatkan jadwal berita berikutnya: %v\n", err)
+		} else if !next.IsZero() && next.Sub(now) < wait {
+			wait = next.Sub(now)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// ApplyDue implements BeritaScheduler.
+// Setiap perubahan status dicatat di audit log tanpa admin karena dilakukan oleh sistem.
+func (b *beritaSchedulerImpl) ApplyDue(ctx context.Context, now time.Time) (int, error) {
+	tx, err := b.DB.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	idBeritaList, err := b.repo.GetDueBeritaIds(ctx, tx, now)
+	if err != nil {
+		return 0, fmt.Errorf("gagal mendapatkan berita terjadwal: %v", err)
+	}
+
+	applied := 0
+	for _, idBerita := range idBeritaList {
+		before, err := b.repo.GetBeritaById(ctx, tx, idBerita)
+		if err != nil {
+			return 0, fmt.Errorf("gagal mendapatkan berita: %v", err)
+		}
+
+		after := before
+		switch {
+		case before.Status == models.BeritaStatusScheduled:
+			after.Status = models.BeritaStatusPublished
+		case before.Status == models.BeritaStatusPublished && before.ExpireAt != nil:
+			after.Status = models.BeritaStatusArchived
+		default:
+			continue
+		}
+
+		// Berita terjadwal yang sudah kedaluwarsa sebelum sempat diterbitkan langsung diarsipkan
+		if after.Status == models.BeritaStatusPublished && before.ExpireAt != nil && !before.ExpireAt.After(now) {
+			after.Status = models.BeritaStatusArchived
+		}
+
+		err = b.repo.UpdateStatusBerita(ctx, tx, idBerita, after.Status)
+		if err != nil {
+			return 0, fmt.Errorf("gagal mengubah status berita: %v", err)
+		}
+
+		err = recordAudit(ctx, tx, b.auditRepo, models.AuditActionUpdate, models.AuditEntityBerita, idBerita, before, after)
+		if err != nil {
+			return 0, err
+		}
+		applied++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return applied, nil
+}
+
+func (b *beritaSchedulerImpl) nextScheduleTime(ctx context.Context, now time.Time) (time.Time, error) {
+	tx, err := b.DB.Begin()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	next, err := b.repo.GetNextScheduleTime(ctx, tx, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return time.Time{}, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return next, nil
+}
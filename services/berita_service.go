@@ -4,39 +4,65 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"io"
 	"net/http"
-	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/syrlramadhan/desa-sukamaju-api/dto"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
 	"github.com/syrlramadhan/desa-sukamaju-api/models"
 	"github.com/syrlramadhan/desa-sukamaju-api/repositories"
+	"github.com/syrlramadhan/desa-sukamaju-api/storage"
 )
 
+// maxBeritaTags adalah jumlah tag maksimal pada satu berita
+const maxBeritaTags = 10
+
 type BeritaService interface {
 	CreateBerita(ctx context.Context, r *http.Request, beritaReq dto.BeritaRequest) (int, error)
 	CreatePhoto(ctx context.Context, r *http.Request, photoReq dto.GaleriRequest) (int, error)
-	GetAllBerita(ctx context.Context) ([]dto.BeritaResponse, int, error)
+	GetAllBerita(ctx context.Context, beritaQuery dto.BeritaQuery) ([]dto.BeritaResponse, dto.PaginationMeta, int, error)
 	GetBeritaById(ctx context.Context, idBerita string) (dto.BeritaResponse, int, error)
+	GetBeritaBySlug(ctx context.Context, slug string) (dto.BeritaResponse, int, error)
+	GetReviewBerita(ctx context.Context, beritaQuery dto.BeritaQuery) ([]dto.BeritaResponse, dto.PaginationMeta, int, error)
+	GetReviewBeritaById(ctx context.Context, idBerita string) (dto.BeritaResponse, int, error)
 	UpdateBerita(ctx context.Context, idBerita string, beritaReq dto.BeritaRequest) (int, error)
+	UpdateStatusBerita(ctx context.Context, idBerita string, statusReq dto.BeritaStatusRequest) (int, error)
 	DeleteBerita(ctx context.Context, idBerita string) (int, error)
 	DeletePhotoByFilename(ctx context.Context, filename string) (int, error)
 	BulkDeletePhoto(ctx context.Context, filenames []string) (int, error)
+	UpdatePhotoBerita(ctx context.Context, filename string, photoReq dto.GaleriPhotoRequest) (int, error)
+	UpdateUrutanPhotoBerita(ctx context.Context, idBerita string, urutanReq dto.GaleriUrutanRequest) (int, error)
+	SetCoverPhotoBerita(ctx context.Context, idBerita string, coverReq dto.GaleriCoverRequest) (int, error)
+	GenerateMissingSlugs(ctx context.Context) (int, error)
+	RenderMissingDeskripsiHTML(ctx context.Context) (int, error)
+	GetBeritaRevisi(ctx context.Context, idBerita string) ([]dto.BeritaRevisiResponse, int, error)
+	GetBeritaRevisiDiff(ctx context.Context, idBerita string, from, to int) (dto.BeritaRevisiDiffResponse, int, error)
+	RestoreBeritaRevisi(ctx context.Context, idBerita string, versi int) (int, error)
 }
 
 type beritaServiceImpl struct {
-	repo repositories.BeritaRepository
-	DB   *sql.DB
+	repo         repositories.BeritaRepository
+	revisiRepo   repositories.BeritaRevisiRepository
+	kategoriRepo repositories.KategoriRepository
+	tagRepo      repositories.TagRepository
+	galeriRepo   repositories.GaleriRepository
+	auditRepo    repositories.AuditRepository
+	storage      storage.Storage
+	DB           *sql.DB
 }
 
-func NewBeritaService(repo repositories.BeritaRepository, db *sql.DB) BeritaService {
+func NewBeritaService(repo repositories.BeritaRepository, revisiRepo repositories.BeritaRevisiRepository, kategoriRepo repositories.KategoriRepository, tagRepo repositories.TagRepository, galeriRepo repositories.GaleriRepository, auditRepo repositories.AuditRepository, fileStorage storage.Storage, db *sql.DB) BeritaService {
 	return &beritaServiceImpl{
-		repo: repo,
-		DB:   db,
+		repo:         repo,
+		revisiRepo:   revisiRepo,
+		kategoriRepo: kategoriRepo,
+		tagRepo:      tagRepo,
+		galeriRepo:   galeriRepo,
+		auditRepo:    auditRepo,
+		storage:      fileStorage,
+		DB:           db,
 	}
 }
 
@@ -51,7 +77,12 @@ func (b *beritaServiceImpl) CreateBerita(ctx context.Context, r *http.Request, b
 
 	// Ambil data form
 	beritaReq.JudulBerita = r.FormValue("judul_berita")
+	beritaReq.IdKategori = r.FormValue("id_kategori")
 	beritaReq.Kategori = r.FormValue("kategori")
+	// Tag dapat dikirim sebagai field berulang maupun dipisah koma
+	for _, value := range r.MultipartForm.Value["tags"] {
+		beritaReq.Tags = append(beritaReq.Tags, strings.Split(value, ",")...)
+	}
 	beritaReq.TanggalPelaksanaan = r.FormValue("tanggal_pelaksanaan")
 	beritaReq.Deskripsi = r.FormValue("deskripsi")
 
@@ -60,6 +91,26 @@ func (b *beritaServiceImpl) CreateBerita(ctx context.Context, r *http.Request, b
 		return http.StatusBadRequest, fmt.Errorf("judul berita tidak boleh kosong")
 	}
 
+	publishAt, expireAt, err := parseBeritaSchedule(r.FormValue("publish_at"), r.FormValue("expire_at"))
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	// Berita baru disimpan sebagai draft kecuali role admin boleh langsung mengajukan atau menerbitkannya
+	status := r.FormValue("status")
+	if status == "" {
+		status = models.BeritaStatusDraft
+	}
+	if status != models.BeritaStatusDraft {
+		if code, err := checkBeritaTransition(ctx, models.BeritaStatusDraft, status); err != nil {
+			return code, err
+		}
+	}
+
+	if err := validateBeritaSchedule(status, publishAt, expireAt); err != nil {
+		return http.StatusBadRequest, err
+	}
+
 	// Ambil multiple files dari form dengan pengecekan yang lebih safe
 	if r.MultipartForm == nil || r.MultipartForm.File == nil {
 		return http.StatusBadRequest, fmt.Errorf("form data tidak valid")
@@ -76,65 +127,39 @@ func (b *beritaServiceImpl) CreateBerita(ctx context.Context, r *http.Request, b
 	}
 
 	// Validasi dan proses multiple files
-	var uploadedFilenames []string
-	uploadDir := "uploads/berita"
-
-	// Buat direktori jika belum ada
-	if err := os.MkdirAll(uploadDir, 0755); err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("gagal membuat direktori upload: %v", err)
-	}
+	var uploadedPhotos []models.Galeri
 
 	beritaId := uuid.New().String()
 
 	for i, fileHeader := range fileHeaders {
-		// Validasi tipe file
-		allowedTypes := []string{".jpg", ".jpeg", ".png", ".gif"}
-		ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
-		isAllowed := false
-		for _, allowedType := range allowedTypes {
-			if ext == allowedType {
-				isAllowed = true
-				break
-			}
-		}
-
-		if !isAllowed {
-			return http.StatusBadRequest, fmt.Errorf("tipe file tidak diizinkan untuk %s. Gunakan: jpg, jpeg, png, gif", fileHeader.Filename)
-		}
-
 		// Validasi ukuran file (maksimal 5MB per file untuk menghindari buffer issue)
 		if fileHeader.Size > 5*1024*1024 {
 			return http.StatusBadRequest, fmt.Errorf("ukuran file %s terlalu besar. Maksimal 5MB per file", fileHeader.Filename)
 		}
 
-		// Generate nama file unik
+		// Generate nama file unik, ekstensinya ditentukan dari tipe gambar hasil validasi
 		timestamp := time.Now().Unix()
-		filename := fmt.Sprintf("berita_%s_%d_%d%s", beritaId, timestamp, i+1, ext)
+		filename := fmt.Sprintf("berita_%s_%d_%d", beritaId, timestamp, i+1)
 
-		// Buka file yang diupload
-		file, err := fileHeader.Open()
+		caption, altText, err := photoFormInfo(r, i)
 		if err != nil {
-			return http.StatusInternalServerError, fmt.Errorf("gagal membuka file %s: %v", fileHeader.Filename, err)
+			removeGaleriFiles(ctx, b.storage, uploadedPhotos)
+			return http.StatusBadRequest, err
 		}
-		defer file.Close()
 
-		// Path file tujuan
-		filePath := filepath.Join(uploadDir, filename)
-
-		// Buat file di server
-		dst, err := os.Create(filePath)
+		galeri, status, err := saveGaleriPhoto(ctx, b.storage, fileHeader, filename)
 		if err != nil {
-			return http.StatusInternalServerError, fmt.Errorf("gagal membuat file %s: %v", filename, err)
-		}
-		defer dst.Close()
-
-		// Copy file dengan limit untuk mencegah memory issues
-		_, err = io.CopyN(dst, file, 5*1024*1024) // Limit 5MB
-		if err != nil && err != io.EOF {
-			return http.StatusInternalServerError, fmt.Errorf("gagal menyimpan file %s: %v", filename, err)
+			removeGaleriFiles(ctx, b.storage, uploadedPhotos)
+			return status, err
 		}
-
-		uploadedFilenames = append(uploadedFilenames, filename)
+		galeri.IdBerita = beritaId
+
+		// Foto pertama yang diupload menjadi sampul sampai diganti lewat endpoint cover
+		galeri.Urutan = i + 1
+		galeri.Caption = caption
+		galeri.AltText = altText
+		galeri.IsCover = i == 0
+		uploadedPhotos = append(uploadedPhotos, galeri)
 	}
 
 	tx, err := b.DB.Begin()
@@ -143,39 +168,65 @@ func (b *beritaServiceImpl) CreateBerita(ctx context.Context, r *http.Request, b
 	}
 	defer tx.Rollback()
 
+	slug, err := b.uniqueSlug(ctx, tx, beritaReq.JudulBerita, beritaId)
+	if err != nil {
+		removeGaleriFiles(ctx, b.storage, uploadedPhotos)
+		return http.StatusInternalServerError, err
+	}
+
+	kategori, code, err := b.resolveKategori(ctx, tx, beritaReq.IdKategori, beritaReq.Kategori)
+	if err != nil {
+		removeGaleriFiles(ctx, b.storage, uploadedPhotos)
+		return code, err
+	}
+
+	tags, code, err := b.resolveTags(ctx, tx, beritaReq.Tags)
+	if err != nil {
+		removeGaleriFiles(ctx, b.storage, uploadedPhotos)
+		return code, err
+	}
+
 	berita := models.Berita{
 		IdBerita:           beritaId,
 		JudulBerita:        beritaReq.JudulBerita,
-		Kategori:           beritaReq.Kategori,
+		Slug:               slug,
+		IdKategori:         kategori.IdKategori,
+		Kategori:           kategori.Nama,
+		KategoriSlug:       kategori.Slug,
+		Tags:               tags,
 		TanggalPelaksanaan: beritaReq.TanggalPelaksanaan,
 		Deskripsi:          beritaReq.Deskripsi,
+		DeskripsiHTML:      helpers.RenderMarkdown(beritaReq.Deskripsi),
+		Status:             status,
+		PublishAt:          publishAt,
+		ExpireAt:           expireAt,
+		CreatedAt:          time.Now(),
 	}
+	berita.DeskripsiText = helpers.HTMLToText(berita.DeskripsiHTML)
 
-	// Buat galeri untuk setiap gambar yang diupload
-	var galeriList []models.Galeri
-	for _, filename := range uploadedFilenames {
-		galeri := models.Galeri{
-			IdGaleri: uuid.New().String(),
-			IdBerita: beritaId,
-			Gambar:   filename,
-		}
-		galeriList = append(galeriList, galeri)
-	}
-
-	err = b.repo.AddBerita(ctx, tx, berita, galeriList)
+	err = b.repo.AddBerita(ctx, tx, berita, uploadedPhotos)
 	if err != nil {
 		// Hapus file yang sudah diupload jika gagal menyimpan ke database
-		for _, filename := range uploadedFilenames {
-			os.Remove(filepath.Join(uploadDir, filename))
-		}
+		removeGaleriFiles(ctx, b.storage, uploadedPhotos)
 		return http.StatusInternalServerError, fmt.Errorf("gagal menambahkan berita: %v", err)
 	}
 
+	err = b.repo.SetBeritaTags(ctx, tx, beritaId, tagIds(tags))
+	if err != nil {
+		removeGaleriFiles(ctx, b.storage, uploadedPhotos)
+		return http.StatusInternalServerError, fmt.Errorf("gagal menyimpan tag berita: %v", err)
+	}
+
+	berita.GambarBerita = uploadedPhotos
+	err = recordAudit(ctx, tx, b.auditRepo, models.AuditActionCreate, models.AuditEntityBerita, beritaId, nil, berita)
+	if err != nil {
+		removeGaleriFiles(ctx, b.storage, uploadedPhotos)
+		return http.StatusInternalServerError, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		// Hapus file yang sudah diupload jika gagal commit
-		for _, filename := range uploadedFilenames {
-			os.Remove(filepath.Join(uploadDir, filename))
-		}
+		removeGaleriFiles(ctx, b.storage, uploadedPhotos)
 		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
 	}
 
@@ -231,90 +282,62 @@ func (b *beritaServiceImpl) CreatePhoto(ctx context.Context, r *http.Request, ph
 		return http.StatusNotFound, fmt.Errorf("berita dengan ID %s tidak ditemukan", idBerita)
 	}
 
-	// Setup upload directory
-	uploadDir := "uploads/berita"
-	if err := os.MkdirAll(uploadDir, 0755); err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("gagal membuat direktori upload: %v", err)
-	}
+	// Foto baru ditaruh setelah foto yang sudah ada, dan menjadi sampul jika berita belum punya sampul
+	nextUrutan, needCover := nextPhotoPosition(berita.GambarBerita)
 
 	// Validasi dan proses multiple files
-	var uploadedFilenames []string
+	var uploadedPhotos []models.Galeri
 
 	for i, fileHeader := range fileHeaders {
-		// Validasi tipe file
-		allowedTypes := []string{".jpg", ".jpeg", ".png", ".gif"}
-		ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
-		isAllowed := false
-		for _, allowedType := range allowedTypes {
-			if ext == allowedType {
-				isAllowed = true
-				break
-			}
-		}
-
-		if !isAllowed {
-			return http.StatusBadRequest, fmt.Errorf("tipe file tidak diizinkan untuk %s. Gunakan: jpg, jpeg, png, gif", fileHeader.Filename)
-		}
-
 		// Validasi ukuran file (maksimal 5MB per file)
 		if fileHeader.Size > 5*1024*1024 {
 			return http.StatusBadRequest, fmt.Errorf("ukuran file %s terlalu besar. Maksimal 5MB per file", fileHeader.Filename)
 		}
 
-		// Generate nama file unik
+		// Generate nama file unik, ekstensinya ditentukan dari tipe gambar hasil validasi
 		timestamp := time.Now().Unix()
-		filename := fmt.Sprintf("berita_%s_%d_additional_%d%s", idBerita, timestamp, i+1, ext)
+		filename := fmt.Sprintf("berita_%s_%d_additional_%d", idBerita, timestamp, i+1)
 
-		// Buka file yang diupload
-		file, err := fileHeader.Open()
+		caption, altText, err := photoFormInfo(r, i)
 		if err != nil {
-			return http.StatusInternalServerError, fmt.Errorf("gagal membuka file %s: %v", fileHeader.Filename, err)
+			removeGaleriFiles(ctx, b.storage, uploadedPhotos)
+			return http.StatusBadRequest, err
 		}
-		defer file.Close()
-
-		// Path file tujuan
-		filePath := filepath.Join(uploadDir, filename)
 
-		// Buat file di server
-		dst, err := os.Create(filePath)
+		galeri, status, err := saveGaleriPhoto(ctx, b.storage, fileHeader, filename)
 		if err != nil {
-			return http.StatusInternalServerError, fmt.Errorf("gagal membuat file %s: %v", filename, err)
-		}
-		defer dst.Close()
-
-		// Copy file dengan limit untuk mencegah memory issues
-		_, err = io.CopyN(dst, file, 5*1024*1024) // Limit 5MB
-		if err != nil && err != io.EOF {
-			return http.StatusInternalServerError, fmt.Errorf("gagal menyimpan file %s: %v", filename, err)
+			removeGaleriFiles(ctx, b.storage, uploadedPhotos)
+			return status, err
 		}
 
-		uploadedFilenames = append(uploadedFilenames, filename)
+		galeri.IdBerita = idBerita
+		galeri.Urutan = nextUrutan + i
+		galeri.Caption = caption
+		galeri.AltText = altText
+		galeri.IsCover = needCover && i == 0
+		uploadedPhotos = append(uploadedPhotos, galeri)
 	}
 
 	// Buat galeri untuk setiap gambar yang diupload
-	for _, filename := range uploadedFilenames {
-		photo := models.Galeri{
-			IdGaleri: uuid.New().String(),
-			IdBerita: idBerita,
-			Gambar:   filename,
-		}
-
+	for _, photo := range uploadedPhotos {
 		// Simpan ke database
-		err = b.repo.AddPhoto(ctx, tx, photo)
+		err = b.galeriRepo.AddPhoto(ctx, tx, photo)
 		if err != nil {
 			// Hapus semua file yang sudah diupload jika gagal simpan ke database
-			for _, uploadedFile := range uploadedFilenames {
-				os.Remove(filepath.Join(uploadDir, uploadedFile))
-			}
+			removeGaleriFiles(ctx, b.storage, uploadedPhotos)
 			return http.StatusInternalServerError, fmt.Errorf("gagal menambahkan foto: %v", err)
 		}
+
+		err = recordAudit(ctx, tx, b.auditRepo, models.AuditActionCreate, models.AuditEntityGaleri, photo.IdGaleri, nil, photo)
+		if err != nil {
+			removeGaleriFiles(ctx, b.storage, uploadedPhotos)
+			return http.StatusInternalServerError, err
+		}
 	}
 
 	if err := tx.Commit(); err != nil {
 		// Hapus semua file jika gagal commit
-		for _, uploadedFile := range uploadedFilenames {
-			os.Remove(filepath.Join(uploadDir, uploadedFile))
-		}
+		removeGaleriFiles(ctx, b.storage, uploadedPhotos)
 		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
 	}
 
@@ -322,6 +345,7 @@ func (b *beritaServiceImpl) CreatePhoto(ctx context.Context, r *http.Request, ph
 }
 
 // DeleteBerita implements BeritaService.
+// Berita beserta fotonya dipindahkan ke tempat sampah dan masih dapat dipulihkan sampai masa simpannya habis.
 func (b *beritaServiceImpl) DeleteBerita(ctx context.Context, idBerita string) (int, error) {
 	// Validasi input
 	if idBerita == "" {
@@ -334,30 +358,35 @@ func (b *beritaServiceImpl) DeleteBerita(ctx context.Context, idBerita string) (
 	}
 	defer tx.Rollback()
 
-	// Ambil data foto berita untuk menghapus file
-	photos, err := b.repo.GetPhotosByBeritaId(ctx, tx, idBerita)
+	berita, err := b.repo.GetBeritaById(ctx, tx, idBerita)
 	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan data foto: %v", err)
+		if err == sql.ErrNoRows {
+			return http.StatusNotFound, fmt.Errorf("berita dengan ID %s tidak ditemukan", idBerita)
+		}
+		return http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan berita: %v", err)
+	}
+
+	// Menghapus berita yang sedang tayang sama dengan menurunkannya, hanya superadmin yang boleh
+	if code, err := checkBeritaEditable(ctx, berita.Status); err != nil {
+		return code, err
 	}
 
-	// Hapus berita dari database (termasuk galeri karena foreign key constraint)
-	err = b.repo.DeleteBerita(ctx, tx, idBerita)
+	err = b.repo.TrashBerita(ctx, tx, idBerita, time.Now())
 	if err != nil {
 		return http.StatusInternalServerError, fmt.Errorf("gagal menghapus berita: %v", err)
 	}
 
+	err = recordAudit(ctx, tx, b.auditRepo, models.AuditActionDelete, models.AuditEntityBerita, idBerita, berita, nil)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
 	}
 
-	// Hapus file gambar dari storage setelah commit berhasil
-	uploadDir := "uploads/berita"
-	for _, photo := range photos {
-		filePath := filepath.Join(uploadDir, photo.Gambar)
-		if _, err := os.Stat(filePath); err == nil {
-			os.Remove(filePath)
-		}
-	}
+	// Pindahkan file foto ke tempat sampah setelah commit berhasil agar tidak lagi dilayani ke publik
+	trashGaleriFiles(ctx, b.storage, berita.GambarBerita)
 
 	return http.StatusOK, nil
 }
@@ -370,27 +399,31 @@ func (b *beritaServiceImpl) DeletePhotoByFilename(ctx context.Context, filename
 	}
 	defer tx.Rollback()
 
-	// Hapus foto dari database
-	err = b.repo.DeletePhotoByFilename(ctx, tx, filename)
+	photo, code, err := getGaleriPhoto(ctx, tx, b.galeriRepo, filename, isBeritaPhoto)
 	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("gagal menghapus foto dari database: %v", err)
+		return code, err
+	}
+
+	if code, err := trashGaleriPhotos(ctx, tx, b.galeriRepo, b.auditRepo, []models.Galeri{photo}); err != nil {
+		return code, err
+	}
+
+	if code, err := b.ensureCoverPhoto(ctx, tx, photo.IdBerita); err != nil {
+		return code, err
 	}
 
 	if err := tx.Commit(); err != nil {
 		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
 	}
 
-	// Hapus file dari storage setelah commit berhasil
-	uploadDir := "uploads/berita"
-	filePath := filepath.Join(uploadDir, filename)
-	if _, err := os.Stat(filePath); err == nil {
-		os.Remove(filePath)
-	}
+	// Pindahkan file ke tempat sampah setelah commit berhasil agar tidak lagi dilayani ke publik
+	trashGaleriFiles(ctx, b.storage, []models.Galeri{photo})
 
 	return http.StatusOK, nil
 }
 
 // BulkDeletePhoto implements BeritaService.
+// Nama file yang tidak ditemukan atau bukan foto berita dilewati.
 func (b *beritaServiceImpl) BulkDeletePhoto(ctx context.Context, filenames []string) (int, error) {
 	// Validasi input
 	if len(filenames) == 0 {
@@ -403,79 +436,282 @@ func (b *beritaServiceImpl) BulkDeletePhoto(ctx context.Context, filenames []str
 	}
 	defer tx.Rollback()
 
-	// Hapus multiple foto dari database
-	err = b.repo.BulkDeletePhoto(ctx, tx, filenames)
+	var photos []models.Galeri
+	for _, filename := range filenames {
+		photo, code, err := getGaleriPhoto(ctx, tx, b.galeriRepo, filename, isBeritaPhoto)
+		if err != nil {
+			if code == http.StatusNotFound {
+				continue
+			}
+			return code, err
+		}
+		photos = append(photos, photo)
+	}
+
+	if code, err := trashGaleriPhotos(ctx, tx, b.galeriRepo, b.auditRepo, photos); err != nil {
+		return code, err
+	}
+
+	checkedBerita := make(map[string]bool)
+	for _, photo := range photos {
+		if checkedBerita[photo.IdBerita] {
+			continue
+		}
+		checkedBerita[photo.IdBerita] = true
+
+		if code, err := b.ensureCoverPhoto(ctx, tx, photo.IdBerita); err != nil {
+			return code, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	// Pindahkan file ke tempat sampah setelah commit berhasil agar tidak lagi dilayani ke publik
+	trashGaleriFiles(ctx, b.storage, photos)
+
+	return http.StatusOK, nil
+}
+
+// UpdatePhotoBerita implements BeritaService.
+func (b *beritaServiceImpl) UpdatePhotoBerita(ctx context.Context, filename string, photoReq dto.GaleriPhotoRequest) (int, error) {
+	tx, err := b.DB.Begin()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	photo, code, err := getGaleriPhoto(ctx, tx, b.galeriRepo, filename, isBeritaPhoto)
 	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("gagal menghapus foto dari database: %v", err)
+		return code, err
+	}
+
+	if code, err := updateGaleriPhotoInfo(ctx, tx, b.galeriRepo, b.auditRepo, photo, photoReq); err != nil {
+		return code, err
 	}
 
 	if err := tx.Commit(); err != nil {
 		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
 	}
 
-	// Hapus file dari storage setelah commit berhasil
-	uploadDir := "uploads/berita"
-	for _, filename := range filenames {
-		filePath := filepath.Join(uploadDir, filename)
-		if _, err := os.Stat(filePath); err == nil {
-			os.Remove(filePath)
+	return http.StatusOK, nil
+}
+
+// UpdateUrutanPhotoBerita implements BeritaService.
+// Daftar id_galeri harus memuat seluruh foto berita tepat satu kali, urutannya menjadi urutan tampil.
+func (b *beritaServiceImpl) UpdateUrutanPhotoBerita(ctx context.Context, idBerita string, urutanReq dto.GaleriUrutanRequest) (int, error) {
+	tx, err := b.DB.Begin()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	photos, code, err := b.getPhotosBerita(ctx, tx, idBerita)
+	if err != nil {
+		return code, err
+	}
+
+	if code, err := reorderGaleriPhotos(ctx, tx, b.galeriRepo, b.auditRepo, photos, urutanReq.IdGaleri); err != nil {
+		return code, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return http.StatusOK, nil
+}
+
+// SetCoverPhotoBerita implements BeritaService.
+func (b *beritaServiceImpl) SetCoverPhotoBerita(ctx context.Context, idBerita string, coverReq dto.GaleriCoverRequest) (int, error) {
+	tx, err := b.DB.Begin()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	photos, code, err := b.getPhotosBerita(ctx, tx, idBerita)
+	if err != nil {
+		return code, err
+	}
+
+	before, code, err := findCoverCandidate(photos, coverReq.IdGaleri)
+	if err != nil {
+		return code, err
+	}
+	if before.IsCover {
+		return http.StatusOK, nil
+	}
+
+	err = b.repo.SetCoverPhoto(ctx, tx, idBerita, before.IdGaleri)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengubah sampul berita: %v", err)
+	}
+
+	photo := before
+	photo.IsCover = true
+	err = recordAudit(ctx, tx, b.auditRepo, models.AuditActionUpdate, models.AuditEntityGaleri, photo.IdGaleri, before, photo)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return http.StatusOK, nil
+}
+
+// getPhotosBerita mengambil foto berita sesuai urutan tampil, 404 jika beritanya tidak ada
+func (b *beritaServiceImpl) getPhotosBerita(ctx context.Context, tx *sql.Tx, idBerita string) ([]models.Galeri, int, error) {
+	berita, err := b.repo.GetBeritaById(ctx, tx, idBerita)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, http.StatusNotFound, fmt.Errorf("berita dengan ID %s tidak ditemukan", idBerita)
 		}
+		return nil, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan berita: %v", err)
+	}
+
+	return berita.GambarBerita, http.StatusOK, nil
+}
+
+// ensureCoverPhoto menjadikan foto pertama sebagai sampul jika sampul berita ikut terhapus
+func (b *beritaServiceImpl) ensureCoverPhoto(ctx context.Context, tx *sql.Tx, idBerita string) (int, error) {
+	photos, err := b.repo.GetPhotosByBeritaId(ctx, tx, idBerita)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan foto berita: %v", err)
+	}
+
+	cover, ok := missingCover(photos)
+	if !ok {
+		return http.StatusOK, nil
+	}
+
+	err = b.repo.SetCoverPhoto(ctx, tx, idBerita, cover.IdGaleri)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengubah sampul berita: %v", err)
 	}
 
 	return http.StatusOK, nil
 }
 
+func isBeritaPhoto(photo models.Galeri) bool {
+	return photo.IdBerita != ""
+}
+
 // GetAllBerita implements BeritaService.
-func (b *beritaServiceImpl) GetAllBerita(ctx context.Context) ([]dto.BeritaResponse, int, error) {
+// Listing publik hanya menampilkan berita yang sudah diterbitkan.
+func (b *beritaServiceImpl) GetAllBerita(ctx context.Context, beritaQuery dto.BeritaQuery) ([]dto.BeritaResponse, dto.PaginationMeta, int, error) {
+	return b.listBerita(ctx, beritaQuery, []string{models.BeritaStatusPublished}, time.Now())
+}
+
+// GetReviewBerita implements BeritaService.
+// Listing admin untuk berita yang belum atau tidak lagi diterbitkan, dapat difilter per status.
+func (b *beritaServiceImpl) GetReviewBerita(ctx context.Context, beritaQuery dto.BeritaQuery) ([]dto.BeritaResponse, dto.PaginationMeta, int, error) {
+	statuses := []string{models.BeritaStatusDraft, models.BeritaStatusInReview, models.BeritaStatusScheduled, models.BeritaStatusArchived}
+	if beritaQuery.Status != "" {
+		if !models.IsValidBeritaStatus(beritaQuery.Status) {
+			return nil, dto.PaginationMeta{}, http.StatusBadRequest, fmt.Errorf("status berita %s tidak valid", beritaQuery.Status)
+		}
+		statuses = []string{beritaQuery.Status}
+	}
+
+	return b.listBerita(ctx, beritaQuery, statuses, time.Time{})
+}
+
+// listBerita mengambil berita dengan status tertentu. Jika visibleAt diisi, hanya berita
+// yang jendela publish_at dan expire_at-nya mencakup waktu tersebut yang dikembalikan.
+func (b *beritaServiceImpl) listBerita(ctx context.Context, beritaQuery dto.BeritaQuery, statuses []string, visibleAt time.Time) ([]dto.BeritaResponse, dto.PaginationMeta, int, error) {
+	filter := models.BeritaFilter{
+		Statuses:  statuses,
+		VisibleAt: visibleAt,
+		Kategori:  beritaQuery.Kategori,
+		Tag:       beritaQuery.Tag,
+		SortBy:    models.BeritaSortCreatedAt,
+		SortOrder: models.SortOrderDesc,
+		Limit:     beritaQuery.PerPage,
+		Offset:    (beritaQuery.Page - 1) * beritaQuery.PerPage,
+	}
+
+	switch beritaQuery.Sort {
+	case "":
+	case models.BeritaSortCreatedAt, models.BeritaSortTanggalPelaksanaan:
+		filter.SortBy = beritaQuery.Sort
+	default:
+		return nil, dto.PaginationMeta{}, http.StatusBadRequest, fmt.Errorf("sort harus %s atau %s", models.BeritaSortCreatedAt, models.BeritaSortTanggalPelaksanaan)
+	}
+
+	switch beritaQuery.Order {
+	case "":
+	case models.SortOrderAsc, models.SortOrderDesc:
+		filter.SortOrder = beritaQuery.Order
+	default:
+		return nil, dto.PaginationMeta{}, http.StatusBadRequest, fmt.Errorf("order harus %s atau %s", models.SortOrderAsc, models.SortOrderDesc)
+	}
+
+	if beritaQuery.From != "" {
+		from, err := time.Parse("2006-01-02", beritaQuery.From)
+		if err != nil {
+			return nil, dto.PaginationMeta{}, http.StatusBadRequest, fmt.Errorf("format tanggal from harus YYYY-MM-DD")
+		}
+		filter.From = from
+	}
+
+	if beritaQuery.To != "" {
+		to, err := time.Parse("2006-01-02", beritaQuery.To)
+		if err != nil {
+			return nil, dto.PaginationMeta{}, http.StatusBadRequest, fmt.Errorf("format tanggal to harus YYYY-MM-DD")
+		}
+		filter.To = to
+	}
+
 	tx, err := b.DB.Begin()
 	if err != nil {
-		return nil, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
 	defer tx.Rollback()
 
-	// Ambil semua berita dengan galeri menggunakan JOIN dari repository
-	beritaList, err := b.repo.GetAllBerita(ctx, tx)
+	// Ambil berita pada halaman yang diminta beserta galerinya dari repository
+	beritaList, total, err := b.repo.GetAllBerita(ctx, tx, filter)
 	if err != nil {
-		return nil, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan data berita: %v", err)
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan data berita: %v", err)
 	}
 
 	if err := tx.Commit(); err != nil {
-		return nil, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
 	}
 
 	// Convert models.Berita ke dto.BeritaResponse
 	var beritaResponseList []dto.BeritaResponse
 	for _, berita := range beritaList {
-		// Convert galeri ke format response
-		var gambarBerita []string
-		for _, galeri := range berita.GambarBerita {
-			if galeri.Gambar != "" {
-				// Tambahkan path lengkap untuk URL gambar
-				gambarBerita = append(gambarBerita, galeri.Gambar)
-			}
-		}
-
-		beritaResponse := dto.BeritaResponse{
-			IdBerita:           berita.IdBerita,
-			JudulBerita:        berita.JudulBerita,
-			Kategori:           berita.Kategori,
-			TanggalPelaksanaan: berita.TanggalPelaksanaan,
-			Deskripsi:          berita.Deskripsi,
-			GambarBerita:       gambarBerita,
-			CreatedAt:          berita.TanggalPelaksanaan, // Menggunakan tanggal pelaksanaan sebagai created_at untuk sementara
-		}
-
-		beritaResponseList = append(beritaResponseList, beritaResponse)
+		beritaResponseList = append(beritaResponseList, helpers.ConvertBeritaToResponseDTO(berita, b.storage))
 	}
 
-	return beritaResponseList, http.StatusOK, nil
+	return beritaResponseList, helpers.NewPaginationMeta(beritaQuery.Page, beritaQuery.PerPage, total), http.StatusOK, nil
 }
 
 // GetBeritaById implements BeritaService.
+// Berita yang belum diterbitkan atau berada di luar jendela tayangnya diperlakukan seperti tidak ada bagi publik.
 func (b *beritaServiceImpl) GetBeritaById(ctx context.Context, idBerita string) (dto.BeritaResponse, int, error) {
-	// Validasi input
-	if idBerita == "" {
-		return dto.BeritaResponse{}, http.StatusBadRequest, fmt.Errorf("id berita tidak boleh kosong")
+	berita, code, err := b.getBerita(ctx, idBerita)
+	if err != nil {
+		return dto.BeritaResponse{}, code, err
+	}
+
+	if !berita.IsVisible(time.Now()) {
+		return dto.BeritaResponse{}, http.StatusNotFound, fmt.Errorf("berita dengan ID %s tidak ditemukan", idBerita)
+	}
+
+	return helpers.ConvertBeritaToResponseDTO(berita, b.storage), http.StatusOK, nil
+}
+
+// GetBeritaBySlug implements BeritaService.
+// Slug lama dari riwayat juga dikenali, response selalu berisi slug terbaru sehingga
+// controller dapat mengarahkan client ke permalink yang baru.
+func (b *beritaServiceImpl) GetBeritaBySlug(ctx context.Context, slug string) (dto.BeritaResponse, int, error) {
+	if slug == "" {
+		return dto.BeritaResponse{}, http.StatusBadRequest, fmt.Errorf("slug tidak boleh kosong")
 	}
 
 	tx, err := b.DB.Begin()
@@ -484,11 +720,18 @@ func (b *beritaServiceImpl) GetBeritaById(ctx context.Context, idBerita string)
 	}
 	defer tx.Rollback()
 
-	// Ambil berita berdasarkan ID dari repository
+	idBerita, err := b.repo.GetBeritaIdBySlug(ctx, tx, slug)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return dto.BeritaResponse{}, http.StatusNotFound, fmt.Errorf("berita dengan slug %s tidak ditemukan", slug)
+		}
+		return dto.BeritaResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan data berita: %v", err)
+	}
+
 	berita, err := b.repo.GetBeritaById(ctx, tx, idBerita)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return dto.BeritaResponse{}, http.StatusNotFound, fmt.Errorf("berita dengan ID %s tidak ditemukan", idBerita)
+			return dto.BeritaResponse{}, http.StatusNotFound, fmt.Errorf("berita dengan slug %s tidak ditemukan", slug)
 		}
 		return dto.BeritaResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan data berita: %v", err)
 	}
@@ -497,27 +740,49 @@ func (b *beritaServiceImpl) GetBeritaById(ctx context.Context, idBerita string)
 		return dto.BeritaResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
 	}
 
-	// Convert galeri ke format response
-	var gambarBerita []string
-	for _, galeri := range berita.GambarBerita {
-		if galeri.Gambar != "" {
-			// Tambahkan nama file gambar ke response
-			gambarBerita = append(gambarBerita, galeri.Gambar)
+	if !berita.IsVisible(time.Now()) {
+		return dto.BeritaResponse{}, http.StatusNotFound, fmt.Errorf("berita dengan slug %s tidak ditemukan", slug)
+	}
+
+	return helpers.ConvertBeritaToResponseDTO(berita, b.storage), http.StatusOK, nil
+}
+
+// GetReviewBeritaById implements BeritaService.
+func (b *beritaServiceImpl) GetReviewBeritaById(ctx context.Context, idBerita string) (dto.BeritaResponse, int, error) {
+	berita, code, err := b.getBerita(ctx, idBerita)
+	if err != nil {
+		return dto.BeritaResponse{}, code, err
+	}
+
+	return helpers.ConvertBeritaToResponseDTO(berita, b.storage), http.StatusOK, nil
+}
+
+func (b *beritaServiceImpl) getBerita(ctx context.Context, idBerita string) (models.Berita, int, error) {
+	// Validasi input
+	if idBerita == "" {
+		return models.Berita{}, http.StatusBadRequest, fmt.Errorf("id berita tidak boleh kosong")
+	}
+
+	tx, err := b.DB.Begin()
+	if err != nil {
+		return models.Berita{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	// Ambil berita berdasarkan ID dari repository
+	berita, err := b.repo.GetBeritaById(ctx, tx, idBerita)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Berita{}, http.StatusNotFound, fmt.Errorf("berita dengan ID %s tidak ditemukan", idBerita)
 		}
+		return models.Berita{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan data berita: %v", err)
 	}
 
-	// Convert models.Berita ke dto.BeritaResponse
-	beritaResponse := dto.BeritaResponse{
-		IdBerita:           berita.IdBerita,
-		JudulBerita:        berita.JudulBerita,
-		Kategori:           berita.Kategori,
-		TanggalPelaksanaan: berita.TanggalPelaksanaan,
-		Deskripsi:          berita.Deskripsi,
-		GambarBerita:       gambarBerita,
-		CreatedAt:          berita.TanggalPelaksanaan, // Menggunakan tanggal pelaksanaan sebagai created_at untuk sementara
+	if err := tx.Commit(); err != nil {
+		return models.Berita{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
 	}
 
-	return beritaResponse, http.StatusOK, nil
+	return berita, http.StatusOK, nil
 }
 
 // UpdateBerita implements BeritaService.
@@ -529,7 +794,7 @@ func (b *beritaServiceImpl) UpdateBerita(ctx context.Context, idBerita string, b
 	if beritaReq.JudulBerita == "" {
 		return http.StatusBadRequest, fmt.Errorf("judul berita tidak boleh kosong")
 	}
-	if beritaReq.Kategori == "" {
+	if beritaReq.IdKategori == "" && beritaReq.Kategori == "" {
 		return http.StatusBadRequest, fmt.Errorf("kategori tidak boleh kosong")
 	}
 	if beritaReq.TanggalPelaksanaan == "" {
@@ -539,6 +804,11 @@ func (b *beritaServiceImpl) UpdateBerita(ctx context.Context, idBerita string, b
 		return http.StatusBadRequest, fmt.Errorf("deskripsi tidak boleh kosong")
 	}
 
+	publishAt, expireAt, err := parseBeritaSchedule(beritaReq.PublishAt, beritaReq.ExpireAt)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
 	tx, err := b.DB.Begin()
 	if err != nil {
 		return http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
@@ -546,7 +816,7 @@ func (b *beritaServiceImpl) UpdateBerita(ctx context.Context, idBerita string, b
 	defer tx.Rollback()
 
 	// Validasi apakah berita exists
-	_, err = b.repo.GetBeritaById(ctx, tx, idBerita)
+	before, err := b.repo.GetBeritaById(ctx, tx, idBerita)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return http.StatusNotFound, fmt.Errorf("berita dengan ID %s tidak ditemukan", idBerita)
@@ -554,23 +824,589 @@ func (b *beritaServiceImpl) UpdateBerita(ctx context.Context, idBerita string, b
 		return http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan berita: %v", err)
 	}
 
-	// Update data berita
+	kategori, code, err := b.resolveKategori(ctx, tx, beritaReq.IdKategori, beritaReq.Kategori)
+	if err != nil {
+		return code, err
+	}
+
 	berita := models.Berita{
 		IdBerita:           idBerita,
 		JudulBerita:        beritaReq.JudulBerita,
-		Kategori:           beritaReq.Kategori,
+		IdKategori:         kategori.IdKategori,
+		Kategori:           kategori.Nama,
+		KategoriSlug:       kategori.Slug,
 		TanggalPelaksanaan: beritaReq.TanggalPelaksanaan,
 		Deskripsi:          beritaReq.Deskripsi,
+		PublishAt:          publishAt,
+		ExpireAt:           expireAt,
 	}
 
-	err = b.repo.UpdateBerita(ctx, tx, berita)
-	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("gagal mengupdate berita: %v", err)
+	// Tag yang tidak dikirim dibiarkan, daftar kosong menghapus seluruh tag
+	if beritaReq.Tags != nil {
+		berita.Tags, code, err = b.resolveTags(ctx, tx, beritaReq.Tags)
+		if err != nil {
+			return code, err
+		}
 	}
 
-	if err := tx.Commit(); err != nil {
+	if code, err := b.applyBeritaUpdate(ctx, tx, before, berita); err != nil {
+		return code, err
+	}
+
+	if err := tx.Commit(); err != nil {
 		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
 	}
 
 	return http.StatusOK, nil
-}
\ No newline at end of file
+}
+
+// applyBeritaUpdate menyimpan isi baru berita. Isi sebelumnya disalin ke tabel revisi terlebih
+// dahulu sehingga perubahan yang tidak disengaja dapat dikembalikan. Tag hanya diganti jika
+// berita.Tags tidak nil. Editor tidak dapat mengubah berita yang sudah disetujui.
+func (b *beritaServiceImpl) applyBeritaUpdate(ctx context.Context, tx *sql.Tx, before, berita models.Berita) (int, error) {
+	if code, err := checkBeritaEditable(ctx, before.Status); err != nil {
+		return code, err
+	}
+
+	if err := validateBeritaSchedule(before.Status, berita.PublishAt, berita.ExpireAt); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	// Revisi hanya dibuat jika isi berita benar-benar berubah
+	if len(diffBeritaRevisi(revisiFromBerita(before), revisiFromBerita(berita))) > 0 {
+		versi, err := b.revisiRepo.GetLastVersi(ctx, tx, before.IdBerita)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan versi revisi berita: %v", err)
+		}
+
+		revisi := revisiFromBerita(before)
+		revisi.IdRevisi = uuid.New().String()
+		revisi.Versi = versi + 1
+		revisi.CreatedAt = time.Now()
+		if claims, ok := helpers.ClaimsFromContext(ctx); ok {
+			revisi.IdAdmin = claims.Id
+			revisi.Username = claims.Username
+		}
+
+		err = b.revisiRepo.AddRevisi(ctx, tx, revisi)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("gagal menyimpan revisi berita: %v", err)
+		}
+	}
+
+	// Slug hanya dibuat ulang jika judul berubah, slug lama disimpan di riwayat agar tautan lama tetap berfungsi
+	slug := before.Slug
+	if slug == "" || helpers.Slugify(berita.JudulBerita) != helpers.Slugify(before.JudulBerita) {
+		var err error
+		slug, err = b.uniqueSlug(ctx, tx, berita.JudulBerita, before.IdBerita)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+	}
+
+	if slug != before.Slug {
+		// Slug yang dipakai kembali mungkin masih tercatat sebagai slug lama berita ini
+		err := b.repo.DeleteSlugHistory(ctx, tx, slug)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("gagal menghapus riwayat slug: %v", err)
+		}
+
+		if before.Slug != "" {
+			err = b.repo.AddSlugHistory(ctx, tx, before.Slug, before.IdBerita, time.Now())
+			if err != nil {
+				return http.StatusInternalServerError, fmt.Errorf("gagal menyimpan riwayat slug: %v", err)
+			}
+		}
+	}
+
+	berita.Slug = slug
+	berita.DeskripsiHTML = helpers.RenderMarkdown(berita.Deskripsi)
+	berita.DeskripsiText = helpers.HTMLToText(berita.DeskripsiHTML)
+	err := b.repo.UpdateBerita(ctx, tx, berita)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengupdate berita: %v", err)
+	}
+
+	if berita.Tags == nil {
+		berita.Tags = before.Tags
+	} else {
+		err = b.repo.SetBeritaTags(ctx, tx, before.IdBerita, tagIds(berita.Tags))
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("gagal menyimpan tag berita: %v", err)
+		}
+	}
+
+	berita.Status = before.Status
+	berita.GambarBerita = before.GambarBerita
+	berita.CreatedAt = before.CreatedAt
+	err = recordAudit(ctx, tx, b.auditRepo, models.AuditActionUpdate, models.AuditEntityBerita, before.IdBerita, before, berita)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// GetBeritaRevisi implements BeritaService.
+func (b *beritaServiceImpl) GetBeritaRevisi(ctx context.Context, idBerita string) ([]dto.BeritaRevisiResponse, int, error) {
+	tx, err := b.DB.Begin()
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := b.repo.GetBeritaById(ctx, tx, idBerita); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, http.StatusNotFound, fmt.Errorf("berita dengan ID %s tidak ditemukan", idBerita)
+		}
+		return nil, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan berita: %v", err)
+	}
+
+	revisiList, err := b.revisiRepo.GetRevisiByBeritaId(ctx, tx, idBerita)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan revisi berita: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	revisiResponseList := []dto.BeritaRevisiResponse{}
+	for _, revisi := range revisiList {
+		revisiResponseList = append(revisiResponseList, helpers.ConvertBeritaRevisiToResponseDTO(revisi))
+	}
+
+	return revisiResponseList, http.StatusOK, nil
+}
+
+// GetBeritaRevisiDiff implements BeritaService.
+// Membandingkan revisi from dengan revisi to, atau dengan isi berita saat ini jika to bernilai 0.
+func (b *beritaServiceImpl) GetBeritaRevisiDiff(ctx context.Context, idBerita string, from, to int) (dto.BeritaRevisiDiffResponse, int, error) {
+	if from <= 0 || to < 0 {
+		return dto.BeritaRevisiDiffResponse{}, http.StatusBadRequest, fmt.Errorf("versi revisi tidak valid")
+	}
+
+	tx, err := b.DB.Begin()
+	if err != nil {
+		return dto.BeritaRevisiDiffResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	fromRevisi, code, err := b.getRevisi(ctx, tx, idBerita, from)
+	if err != nil {
+		return dto.BeritaRevisiDiffResponse{}, code, err
+	}
+
+	var toRevisi models.BeritaRevisi
+	if to == 0 {
+		berita, err := b.repo.GetBeritaById(ctx, tx, idBerita)
+		if err != nil {
+			return dto.BeritaRevisiDiffResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan berita: %v", err)
+		}
+		toRevisi = revisiFromBerita(berita)
+	} else {
+		toRevisi, code, err = b.getRevisi(ctx, tx, idBerita, to)
+		if err != nil {
+			return dto.BeritaRevisiDiffResponse{}, code, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dto.BeritaRevisiDiffResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return dto.BeritaRevisiDiffResponse{
+		IdBerita: idBerita,
+		From:     from,
+		To:       to,
+		Changes:  diffBeritaRevisi(fromRevisi, toRevisi),
+	}, http.StatusOK, nil
+}
+
+// RestoreBeritaRevisi implements BeritaService.
+// Isi revisi disimpan sebagai versi baru, sehingga isi sebelum pemulihan tetap tercatat di revisi.
+func (b *beritaServiceImpl) RestoreBeritaRevisi(ctx context.Context, idBerita string, versi int) (int, error) {
+	tx, err := b.DB.Begin()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	before, err := b.repo.GetBeritaById(ctx, tx, idBerita)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return http.StatusNotFound, fmt.Errorf("berita dengan ID %s tidak ditemukan", idBerita)
+		}
+		return http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan berita: %v", err)
+	}
+
+	revisi, code, err := b.getRevisi(ctx, tx, idBerita, versi)
+	if err != nil {
+		return code, err
+	}
+
+	kategori, code, err := b.resolveKategori(ctx, tx, revisi.IdKategori, "")
+	if err != nil {
+		return code, fmt.Errorf("kategori pada revisi versi %d tidak dapat dipulihkan: %v", versi, err)
+	}
+
+	berita := models.Berita{
+		IdBerita:           idBerita,
+		JudulBerita:        revisi.JudulBerita,
+		IdKategori:         kategori.IdKategori,
+		Kategori:           kategori.Nama,
+		KategoriSlug:       kategori.Slug,
+		TanggalPelaksanaan: revisi.TanggalPelaksanaan,
+		Deskripsi:          revisi.Deskripsi,
+		PublishAt:          revisi.PublishAt,
+		ExpireAt:           revisi.ExpireAt,
+	}
+
+	if code, err := b.applyBeritaUpdate(ctx, tx, before, berita); err != nil {
+		return code, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return http.StatusOK, nil
+}
+
+func (b *beritaServiceImpl) getRevisi(ctx context.Context, tx *sql.Tx, idBerita string, versi int) (models.BeritaRevisi, int, error) {
+	revisi, err := b.revisiRepo.GetRevisi(ctx, tx, idBerita, versi)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.BeritaRevisi{}, http.StatusNotFound, fmt.Errorf("revisi versi %d untuk berita %s tidak ditemukan", versi, idBerita)
+		}
+		return models.BeritaRevisi{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan revisi berita: %v", err)
+	}
+
+	return revisi, http.StatusOK, nil
+}
+
+// revisiFromBerita mengambil field berita yang disimpan di revisi
+func revisiFromBerita(berita models.Berita) models.BeritaRevisi {
+	return models.BeritaRevisi{
+		IdBerita:           berita.IdBerita,
+		JudulBerita:        berita.JudulBerita,
+		IdKategori:         berita.IdKategori,
+		Kategori:           berita.Kategori,
+		TanggalPelaksanaan: berita.TanggalPelaksanaan,
+		Deskripsi:          berita.Deskripsi,
+		PublishAt:          berita.PublishAt,
+		ExpireAt:           berita.ExpireAt,
+	}
+}
+
+// diffBeritaRevisi mengembalikan field yang berbeda antara dua revisi, sesuai urutan field berita
+func diffBeritaRevisi(from, to models.BeritaRevisi) []dto.BeritaRevisiFieldDiff {
+	fields := []struct {
+		name   string
+		before string
+		after  string
+	}{
+		{"judul_berita", from.JudulBerita, to.JudulBerita},
+		{"kategori", from.Kategori, to.Kategori},
+		{"tanggal_pelaksanaan", from.TanggalPelaksanaan, to.TanggalPelaksanaan},
+		{"deskripsi", from.Deskripsi, to.Deskripsi},
+		{"publish_at", helpers.FormatLocalTime(from.PublishAt), helpers.FormatLocalTime(to.PublishAt)},
+		{"expire_at", helpers.FormatLocalTime(from.ExpireAt), helpers.FormatLocalTime(to.ExpireAt)},
+	}
+
+	changes := []dto.BeritaRevisiFieldDiff{}
+	for _, field := range fields {
+		if field.before != field.after {
+			changes = append(changes, dto.BeritaRevisiFieldDiff{
+				Field:  field.name,
+				Before: field.before,
+				After:  field.after,
+			})
+		}
+	}
+
+	return changes
+}
+
+// UpdateStatusBerita implements BeritaService.
+func (b *beritaServiceImpl) UpdateStatusBerita(ctx context.Context, idBerita string, statusReq dto.BeritaStatusRequest) (int, error) {
+	if !models.IsValidBeritaStatus(statusReq.Status) {
+		return http.StatusBadRequest, fmt.Errorf("status berita %s tidak valid", statusReq.Status)
+	}
+
+	tx, err := b.DB.Begin()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	before, err := b.repo.GetBeritaById(ctx, tx, idBerita)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return http.StatusNotFound, fmt.Errorf("berita dengan ID %s tidak ditemukan", idBerita)
+		}
+		return http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan berita: %v", err)
+	}
+
+	if before.Status == statusReq.Status {
+		return http.StatusBadRequest, fmt.Errorf("status berita sudah %s", statusReq.Status)
+	}
+
+	if code, err := checkBeritaTransition(ctx, before.Status, statusReq.Status); err != nil {
+		return code, err
+	}
+
+	if err := validateBeritaSchedule(statusReq.Status, before.PublishAt, before.ExpireAt); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	err = b.repo.UpdateStatusBerita(ctx, tx, idBerita, statusReq.Status)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengubah status berita: %v", err)
+	}
+
+	after := before
+	after.Status = statusReq.Status
+	err = recordAudit(ctx, tx, b.auditRepo, models.AuditActionUpdate, models.AuditEntityBerita, idBerita, before, after)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return http.StatusOK, nil
+}
+
+// GenerateMissingSlugs implements BeritaService.
+// Mengisi slug untuk berita yang dibuat sebelum kolom slug ada.
+func (b *beritaServiceImpl) GenerateMissingSlugs(ctx context.Context) (int, error) {
+	tx, err := b.DB.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	beritaList, err := b.repo.GetBeritaWithoutSlug(ctx, tx)
+	if err != nil {
+		return 0, fmt.Errorf("gagal mendapatkan berita tanpa slug: %v", err)
+	}
+
+	for _, berita := range beritaList {
+		slug, err := b.uniqueSlug(ctx, tx, berita.JudulBerita, berita.IdBerita)
+		if err != nil {
+			return 0, err
+		}
+
+		err = b.repo.UpdateSlugBerita(ctx, tx, berita.IdBerita, slug)
+		if err != nil {
+			return 0, fmt.Errorf("gagal menyimpan slug berita: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return len(beritaList), nil
+}
+
+// RenderMissingDeskripsiHTML implements BeritaService.
+// Merender HTML dan teks pencarian deskripsi untuk berita yang dibuat sebelum kolom
+// deskripsi_html atau deskripsi_text ada.
+func (b *beritaServiceImpl) RenderMissingDeskripsiHTML(ctx context.Context) (int, error) {
+	tx, err := b.DB.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	beritaList, err := b.repo.GetBeritaWithoutDeskripsiHTML(ctx, tx)
+	if err != nil {
+		return 0, fmt.Errorf("gagal mendapatkan berita tanpa deskripsi HTML: %v", err)
+	}
+
+	for _, berita := range beritaList {
+		deskripsiHTML := helpers.RenderMarkdown(berita.Deskripsi)
+		err = b.repo.UpdateDeskripsiHTMLBerita(ctx, tx, berita.IdBerita, deskripsiHTML, helpers.HTMLToText(deskripsiHTML))
+		if err != nil {
+			return 0, fmt.Errorf("gagal menyimpan deskripsi HTML berita: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return len(beritaList), nil
+}
+
+// uniqueSlug membuat slug dari judul berita. Jika slug sudah dipakai berita lain, baik sebagai
+// slug aktif maupun slug lama, ditambahkan akhiran angka: "rapat-desa", "rapat-desa-2", dst.
+func (b *beritaServiceImpl) uniqueSlug(ctx context.Context, tx *sql.Tx, judulBerita, idBerita string) (string, error) {
+	base := helpers.Slugify(judulBerita)
+	if base == "" {
+		base = "berita"
+	}
+
+	slug := base
+	for i := 2; ; i++ {
+		taken, err := b.repo.IsSlugTaken(ctx, tx, slug, idBerita)
+		if err != nil {
+			return "", fmt.Errorf("gagal memeriksa slug berita: %v", err)
+		}
+		if !taken {
+			return slug, nil
+		}
+
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// resolveKategori mencari kategori berdasarkan id, atau berdasarkan slug maupun nama untuk client
+// lama yang masih mengirim kategori sebagai teks. Kategori kosong berarti berita tanpa kategori.
+func (b *beritaServiceImpl) resolveKategori(ctx context.Context, tx *sql.Tx, idKategori, nama string) (models.KategoriBerita, int, error) {
+	var kategori models.KategoriBerita
+	var err error
+
+	value := idKategori
+	switch {
+	case idKategori != "":
+		kategori, err = b.kategoriRepo.GetKategoriById(ctx, tx, idKategori)
+	case strings.TrimSpace(nama) != "":
+		value = strings.TrimSpace(nama)
+		kategori, err = b.kategoriRepo.GetKategoriBySlugOrNama(ctx, tx, value)
+	default:
+		return models.KategoriBerita{}, http.StatusOK, nil
+	}
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.KategoriBerita{}, http.StatusBadRequest, fmt.Errorf("kategori %s tidak ditemukan", value)
+		}
+		return models.KategoriBerita{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan kategori: %v", err)
+	}
+
+	return kategori, http.StatusOK, nil
+}
+
+// resolveTags mengubah daftar nama tag menjadi tag tersimpan. Tag dengan slug yang sama dianggap
+// satu tag, dan tag yang belum ada dibuat otomatis. Hasilnya tidak pernah nil.
+func (b *beritaServiceImpl) resolveTags(ctx context.Context, tx *sql.Tx, names []string) ([]models.Tag, int, error) {
+	tags := []models.Tag{}
+	seen := make(map[string]bool)
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		slug := helpers.Slugify(name)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+
+		if len(tags) == maxBeritaTags {
+			return nil, http.StatusBadRequest, fmt.Errorf("maksimal %d tag per berita", maxBeritaTags)
+		}
+
+		tag, err := b.tagRepo.GetTagBySlug(ctx, tx, slug)
+		if err == sql.ErrNoRows {
+			tag = models.Tag{
+				IdTag:     uuid.New().String(),
+				Nama:      name,
+				Slug:      slug,
+				CreatedAt: time.Now(),
+			}
+			err = b.tagRepo.AddTag(ctx, tx, tag)
+		}
+		if err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("gagal menyimpan tag %s: %v", name, err)
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, http.StatusOK, nil
+}
+
+func tagIds(tags []models.Tag) []string {
+	idTags := make([]string, len(tags))
+	for i, tag := range tags {
+		idTags[i] = tag.IdTag
+	}
+	return idTags
+}
+
+// checkBeritaTransition memastikan admin yang login boleh mengubah status berita dari from ke to
+func checkBeritaTransition(ctx context.Context, from, to string) (int, error) {
+	if !models.IsValidBeritaStatus(to) {
+		return http.StatusBadRequest, fmt.Errorf("status berita %s tidak valid", to)
+	}
+
+	claims, ok := helpers.ClaimsFromContext(ctx)
+	if !ok {
+		return http.StatusUnauthorized, fmt.Errorf("admin belum login")
+	}
+
+	if !models.CanChangeBeritaStatus(from, to, claims.Role) {
+		return http.StatusForbidden, fmt.Errorf("role %s tidak dapat mengubah status berita dari %s ke %s", claims.Role, from, to)
+	}
+
+	return http.StatusOK, nil
+}
+
+// checkBeritaEditable memastikan admin yang login boleh mengubah atau menghapus berita dengan status tersebut
+func checkBeritaEditable(ctx context.Context, status string) (int, error) {
+	claims, ok := helpers.ClaimsFromContext(ctx)
+	if !ok {
+		return http.StatusUnauthorized, fmt.Errorf("admin belum login")
+	}
+
+	if !models.CanEditBerita(status, claims.Role) {
+		return http.StatusForbidden, fmt.Errorf("role %s tidak dapat mengubah berita berstatus %s, minta superadmin mengembalikannya ke draft terlebih dahulu", claims.Role, status)
+	}
+
+	return http.StatusOK, nil
+}
+
+// parseBeritaSchedule membaca publish_at dan expire_at opsional, nilai kosong berarti tanpa batas
+func parseBeritaSchedule(publishAtValue, expireAtValue string) (*time.Time, *time.Time, error) {
+	var publishAt, expireAt *time.Time
+
+	if publishAtValue != "" {
+		t, err := helpers.ParseLocalTime(publishAtValue)
+		if err != nil {
+			return nil, nil, fmt.Errorf("publish_at tidak valid: %v", err)
+		}
+		publishAt = &t
+	}
+
+	if expireAtValue != "" {
+		t, err := helpers.ParseLocalTime(expireAtValue)
+		if err != nil {
+			return nil, nil, fmt.Errorf("expire_at tidak valid: %v", err)
+		}
+		expireAt = &t
+	}
+
+	return publishAt, expireAt, nil
+}
+
+// validateBeritaSchedule memastikan jadwal berita konsisten dengan statusnya. Berita berstatus
+// scheduled wajib memiliki publish_at di masa depan agar dapat diterbitkan oleh scheduler.
+func validateBeritaSchedule(status string, publishAt, expireAt *time.Time) error {
+	if publishAt != nil && expireAt != nil && !expireAt.After(*publishAt) {
+		return fmt.Errorf("expire_at harus setelah publish_at")
+	}
+
+	if status == models.BeritaStatusScheduled {
+		if publishAt == nil {
+			return fmt.Errorf("publish_at wajib diisi untuk berita terjadwal")
+		}
+		if !publishAt.After(time.Now()) {
+			return fmt.Errorf("publish_at berita terjadwal harus di masa depan")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"mime"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/dto"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+	"github.com/syrlramadhan/desa-sukamaju-api/repositories"
+	"github.com/syrlramadhan/desa-sukamaju-api/storage"
+)
+
+// feedMaxItems adalah jumlah berita terbaru yang dimuat di feed
+const feedMaxItems = 50
+
+const feedTitle = "Berita Desa Sukamaju"
+
+type FeedService interface {
+	GetBeritaRSS(ctx context.Context, feedQuery dto.FeedQuery) (dto.RSSFeed, time.Time, int, error)
+	GetBeritaAtom(ctx context.Context, feedQuery dto.FeedQuery) (dto.AtomFeed, time.Time, int, error)
+}
+
+type feedServiceImpl struct {
+	repo      repositories.BeritaRepository
+	auditRepo repositories.AuditRepository
+	storage   storage.Storage
+	DB        *sql.DB
+}
+
+func NewFeedService(repo repositories.BeritaRepository, auditRepo repositories.AuditRepository, fileStorage storage.Storage, db *sql.DB) FeedService {
+	return &feedServiceImpl{
+		repo:      repo,
+		auditRepo: auditRepo,
+		storage:   fileStorage,
+		DB:        db,
+	}
+}
+
+// feedBerita adalah berita di feed beserta waktu perubahan terakhirnya
+type feedBerita struct {
+	models.Berita
+	UpdatedAt time.Time
+}
+
+// GetBeritaRSS implements FeedService.
+// Waktu yang dikembalikan adalah waktu perubahan terakhir berita di feed, dipakai sebagai Last-Modified.
+func (f *feedServiceImpl) GetBeritaRSS(ctx context.Context, feedQuery dto.FeedQuery) (dto.RSSFeed, time.Time, int, error) {
+	beritaList, lastModified, code, err := f.getFeedBerita(ctx, feedQuery.Kategori)
+	if err != nil {
+		return dto.RSSFeed{}, time.Time{}, code, err
+	}
+
+	location := helpers.Location()
+	siteURL := helpers.SiteURL(feedQuery.BaseURL)
+
+	channel := dto.RSSChannel{
+		Title:       feedTitleFor(feedQuery.Kategori),
+		Link:        siteURL,
+		Description: "Kabar dan kegiatan terbaru dari " + feedTitleFor(feedQuery.Kategori),
+		Language:    "id",
+		SelfLink:    dto.AtomLink{Rel: "self", Href: feedQuery.SelfURL, Type: "application/rss+xml"},
+		Items:       []dto.RSSItem{},
+	}
+	if !lastModified.IsZero() {
+		channel.LastBuildDate = lastModified.In(location).Format(time.RFC1123Z)
+	}
+
+	for _, berita := range beritaList {
+		item := dto.RSSItem{
+			Title:       berita.JudulBerita,
+			Link:        helpers.BeritaURL(feedQuery.BaseURL, berita.Slug),
+			Description: berita.DeskripsiHTML,
+			Category:    berita.Kategori,
+			// Slug dapat berubah saat judul diedit, sehingga guid memakai id berita
+			GUID:    dto.RSSGUID{IsPermaLink: "false", Value: "urn:uuid:" + berita.IdBerita},
+			PubDate: beritaPublishedAt(berita.Berita).In(location).Format(time.RFC1123Z),
+		}
+
+		if imageURL, imageType := f.coverImage(berita.Berita, feedQuery.BaseURL); imageURL != "" {
+			// Ukuran file tidak disimpan di database, spesifikasi RSS mengizinkan 0 jika tidak diketahui
+			item.Enclosure = &dto.RSSEnclosure{URL: imageURL, Length: "0", Type: imageType}
+		}
+
+		channel.Items = append(channel.Items, item)
+	}
+
+	return dto.RSSFeed{Version: "2.0", Channel: channel}, lastModified, http.StatusOK, nil
+}
+
+// GetBeritaAtom implements FeedService.
+// Waktu yang dikembalikan adalah waktu perubahan terakhir berita di feed, dipakai sebagai Last-Modified.
+func (f *feedServiceImpl) GetBeritaAtom(ctx context.Context, feedQuery dto.FeedQuery) (dto.AtomFeed, time.Time, int, error) {
+	beritaList, lastModified, code, err := f.getFeedBerita(ctx, feedQuery.Kategori)
+	if err != nil {
+		return dto.AtomFeed{}, time.Time{}, code, err
+	}
+
+	location := helpers.Location()
+	siteURL := helpers.SiteURL(feedQuery.BaseURL)
+
+	updated := lastModified
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+
+	feed := dto.AtomFeed{
+		Title:   feedTitleFor(feedQuery.Kategori),
+		ID:      feedQuery.SelfURL,
+		Updated: updated.In(location).Format(time.RFC3339),
+		Author:  dto.AtomAuthor{Name: "Pemerintah Desa Sukamaju"},
+		Links: []dto.AtomLink{
+			{Rel: "self", Href: feedQuery.SelfURL, Type: "application/atom+xml"},
+			{Rel: "alternate", Href: siteURL, Type: "text/html"},
+		},
+		Entries: []dto.AtomEntry{},
+	}
+
+	for _, berita := range beritaList {
+		entry := dto.AtomEntry{
+			Title:     berita.JudulBerita,
+			ID:        "urn:uuid:" + berita.IdBerita,
+			Updated:   berita.UpdatedAt.In(location).Format(time.RFC3339),
+			Published: beritaPublishedAt(berita.Berita).In(location).Format(time.RFC3339),
+			Links: []dto.AtomLink{
+				{Rel: "alternate", Href: helpers.BeritaURL(feedQuery.BaseURL, berita.Slug), Type: "text/html"},
+			},
+			Content: dto.AtomContent{Type: "html", Value: berita.DeskripsiHTML},
+		}
+
+		if berita.Kategori != "" {
+			entry.Category = &dto.AtomCategory{Term: berita.Kategori}
+		}
+
+		if imageURL, imageType := f.coverImage(berita.Berita, feedQuery.BaseURL); imageURL != "" {
+			entry.Links = append(entry.Links, dto.AtomLink{Rel: "enclosure", Href: imageURL, Type: imageType})
+		}
+
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return feed, lastModified, http.StatusOK, nil
+}
+
+// getFeedBerita mengambil berita terbit yang sedang tayang, terbaru menurut waktu terbit lebih dulu.
+// Waktu perubahan terakhir diambil dari audit log seperti sitemap, karena tabel berita tidak menyimpan
+// waktu update, dengan waktu terbit sebagai batas bawahnya. Waktu terbaru di antara seluruh berita
+// ikut dikembalikan agar suntingan berita lama juga memperbarui Last-Modified feed.
+func (f *feedServiceImpl) getFeedBerita(ctx context.Context, kategori string) ([]feedBerita, time.Time, int, error) {
+	tx, err := f.DB.Begin()
+	if err != nil {
+		return nil, time.Time{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	beritaList, _, err := f.repo.GetAllBerita(ctx, tx, models.BeritaFilter{
+		Statuses:  []string{models.BeritaStatusPublished},
+		VisibleAt: time.Now(),
+		Kategori:  kategori,
+		SortBy:    models.BeritaSortPublishedAt,
+		SortOrder: models.SortOrderDesc,
+		Limit:     feedMaxItems,
+	})
+	if err != nil {
+		return nil, time.Time{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan data berita: %v", err)
+	}
+
+	var idBerita []string
+	for _, berita := range beritaList {
+		idBerita = append(idBerita, berita.IdBerita)
+	}
+
+	lastAudit, err := f.auditRepo.GetLastAuditTimes(ctx, tx, models.AuditEntityBerita, idBerita)
+	if err != nil {
+		return nil, time.Time{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan audit log berita: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, time.Time{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	var feedList []feedBerita
+	var lastModified time.Time
+	for _, berita := range beritaList {
+		updatedAt := beritaPublishedAt(berita)
+		if auditedAt, ok := lastAudit[berita.IdBerita]; ok && auditedAt.After(updatedAt) {
+			updatedAt = auditedAt
+		}
+		if updatedAt.After(lastModified) {
+			lastModified = updatedAt
+		}
+		feedList = append(feedList, feedBerita{Berita: berita, UpdatedAt: updatedAt})
+	}
+
+	return feedList, lastModified, http.StatusOK, nil
+}
+
+// coverImage mengembalikan URL absolut dan tipe konten foto sampul berita
+func (f *feedServiceImpl) coverImage(berita models.Berita, baseURL string) (string, string) {
+	cover, ok := berita.CoverPhoto()
+	if !ok {
+		return "", ""
+	}
+
+	imageURL := helpers.AbsoluteURL(baseURL, f.storage.URL(storage.Key(storage.DirBerita, cover.Gambar)))
+	return imageURL, mime.TypeByExtension(path.Ext(cover.Gambar))
+}
+
+// beritaPublishedAt adalah waktu berita tayang, yaitu publish_at jika dijadwalkan atau waktu dibuat
+func beritaPublishedAt(berita models.Berita) time.Time {
+	if berita.PublishAt != nil {
+		return *berita.PublishAt
+	}
+	return berita.CreatedAt
+}
+
+func feedTitleFor(kategori string) string {
+	if kategori == "" {
+		return feedTitle
+	}
+	return feedTitle + " - " + kategori
+}
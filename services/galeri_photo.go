@@ -0,0 +1,258 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/syrlramadhan/desa-sukamaju-api/dto"
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+	"github.com/syrlramadhan/desa-sukamaju-api/repositories"
+	"github.com/syrlramadhan/desa-sukamaju-api/storage"
+)
+
+// Foto berita dan foto album sama-sama disimpan di tabel galeri dan folder storage yang sama,
+// sehingga logika upload, urutan, sampul dan penghapusan di file ini dipakai oleh keduanya.
+// Foto yang dihapus masuk tempat sampah, filenya baru dihapus saat tempat sampah dikosongkan.
+
+// maxPhotoInfoLength adalah panjang maksimal caption dan alt text foto, sesuai kolom di database
+const maxPhotoInfoLength = 255
+
+// saveGaleriPhoto memvalidasi lalu menyimpan satu file gambar galeri beserta variannya ke storage.
+// Pemilik foto, yaitu berita atau album, diisi oleh pemanggil.
+func saveGaleriPhoto(ctx context.Context, fileStorage storage.Storage, fileHeader *multipart.FileHeader, base string) (models.Galeri, int, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return models.Galeri{}, http.StatusInternalServerError, fmt.Errorf("gagal membuka file %s: %v", fileHeader.Filename, err)
+	}
+	defer file.Close()
+
+	upload, err := readImage(file, fileHeader.Filename)
+	if err != nil {
+		return models.Galeri{}, http.StatusBadRequest, err
+	}
+
+	filename, varian, err := saveImage(ctx, fileStorage, storage.DirBerita, base, upload)
+	if err != nil {
+		return models.Galeri{}, http.StatusInternalServerError, err
+	}
+
+	return models.Galeri{
+		IdGaleri: uuid.New().String(),
+		Gambar:   filename,
+		Varian:   varian,
+	}, http.StatusOK, nil
+}
+
+// removeGaleriFiles menghapus file gambar galeri beserta variannya dari storage
+func removeGaleriFiles(ctx context.Context, fileStorage storage.Storage, photos []models.Galeri) {
+	for _, photo := range photos {
+		deleteImage(ctx, fileStorage, storage.DirBerita, photo.Gambar, photo.Varian)
+	}
+}
+
+// trashGaleriFiles memindahkan file foto galeri ke folder tempat sampah agar tidak lagi dilayani ke publik
+func trashGaleriFiles(ctx context.Context, fileStorage storage.Storage, photos []models.Galeri) {
+	for _, photo := range photos {
+		moveImage(ctx, fileStorage, storage.DirBerita, trashDir(storage.DirBerita), photo.Gambar, photo.Varian)
+	}
+}
+
+// restoreGaleriFiles mengembalikan file foto galeri dari folder tempat sampah
+func restoreGaleriFiles(ctx context.Context, fileStorage storage.Storage, photos []models.Galeri) {
+	for _, photo := range photos {
+		moveImage(ctx, fileStorage, trashDir(storage.DirBerita), storage.DirBerita, photo.Gambar, photo.Varian)
+	}
+}
+
+// removeTrashedGaleriFiles menghapus file foto galeri yang berada di folder tempat sampah
+func removeTrashedGaleriFiles(ctx context.Context, fileStorage storage.Storage, photos []models.Galeri) {
+	for _, photo := range photos {
+		deleteImage(ctx, fileStorage, trashDir(storage.DirBerita), photo.Gambar, photo.Varian)
+	}
+}
+
+// validatePhotoInfo merapikan dan memeriksa panjang caption dan alt text foto
+func validatePhotoInfo(caption, altText string) (string, string, error) {
+	caption = strings.TrimSpace(caption)
+	altText = strings.TrimSpace(altText)
+
+	if utf8.RuneCountInString(caption) > maxPhotoInfoLength {
+		return "", "", fmt.Errorf("caption foto maksimal %d karakter", maxPhotoInfoLength)
+	}
+	if utf8.RuneCountInString(altText) > maxPhotoInfoLength {
+		return "", "", fmt.Errorf("alt text foto maksimal %d karakter", maxPhotoInfoLength)
+	}
+
+	return caption, altText, nil
+}
+
+// photoFormInfo membaca caption dan alt_text untuk file ke-i dari form upload. Field dikirim
+// berulang dengan urutan yang sama seperti file gambarnya, dan boleh tidak diisi.
+func photoFormInfo(r *http.Request, i int) (string, string, error) {
+	valueAt := func(key string) string {
+		if values := r.MultipartForm.Value[key]; i < len(values) {
+			return values[i]
+		}
+		return ""
+	}
+
+	return validatePhotoInfo(valueAt("caption"), valueAt("alt_text"))
+}
+
+// nextPhotoPosition mengembalikan urutan untuk foto yang ditambahkan setelah foto yang sudah ada,
+// dan apakah foto baru pertama perlu dijadikan sampul karena belum ada sampul
+func nextPhotoPosition(photos []models.Galeri) (int, bool) {
+	lastUrutan := 0
+	hasCover := false
+	for _, photo := range photos {
+		if photo.Urutan > lastUrutan {
+			lastUrutan = photo.Urutan
+		}
+		hasCover = hasCover || photo.IsCover
+	}
+	return lastUrutan + 1, !hasCover
+}
+
+// missingCover mengembalikan foto pertama jika belum ada foto yang menjadi sampul,
+// misalnya setelah foto sampul dihapus
+func missingCover(photos []models.Galeri) (models.Galeri, bool) {
+	for _, photo := range photos {
+		if photo.IsCover {
+			return models.Galeri{}, false
+		}
+	}
+	if len(photos) == 0 {
+		return models.Galeri{}, false
+	}
+	return photos[0], true
+}
+
+// getGaleriPhoto mengambil foto berdasarkan nama file, owned memeriksa apakah foto milik jenis
+// pemilik yang benar sehingga endpoint berita tidak dapat mengubah foto album dan sebaliknya
+func getGaleriPhoto(ctx context.Context, tx *sql.Tx, galeriRepo repositories.GaleriRepository, filename string, owned func(models.Galeri) bool) (models.Galeri, int, error) {
+	photo, err := galeriRepo.GetPhotoByFilename(ctx, tx, filename)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Galeri{}, http.StatusNotFound, fmt.Errorf("foto %s tidak ditemukan", filename)
+		}
+		return models.Galeri{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan foto: %v", err)
+	}
+
+	if !owned(photo) {
+		return models.Galeri{}, http.StatusNotFound, fmt.Errorf("foto %s tidak ditemukan", filename)
+	}
+
+	return photo, http.StatusOK, nil
+}
+
+// updateGaleriPhotoInfo mengubah caption dan alt text satu foto
+func updateGaleriPhotoInfo(ctx context.Context, tx *sql.Tx, galeriRepo repositories.GaleriRepository, auditRepo repositories.AuditRepository, before models.Galeri, photoReq dto.GaleriPhotoRequest) (int, error) {
+	caption, altText, err := validatePhotoInfo(photoReq.Caption, photoReq.AltText)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	photo := before
+	photo.Caption = caption
+	photo.AltText = altText
+
+	err = galeriRepo.UpdatePhotoInfo(ctx, tx, photo)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengupdate foto: %v", err)
+	}
+
+	err = recordAudit(ctx, tx, auditRepo, models.AuditActionUpdate, models.AuditEntityGaleri, photo.IdGaleri, before, photo)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// reorderGaleriPhotos menyimpan urutan baru foto. idGaleri harus memuat seluruh foto tepat satu kali.
+func reorderGaleriPhotos(ctx context.Context, tx *sql.Tx, galeriRepo repositories.GaleriRepository, auditRepo repositories.AuditRepository, photos []models.Galeri, idGaleri []string) (int, error) {
+	photoMap := make(map[string]models.Galeri)
+	for _, photo := range photos {
+		photoMap[photo.IdGaleri] = photo
+	}
+
+	if len(idGaleri) != len(photos) {
+		return http.StatusBadRequest, fmt.Errorf("urutan harus memuat seluruh %d foto", len(photos))
+	}
+
+	seen := make(map[string]bool)
+	for i, id := range idGaleri {
+		before, ok := photoMap[id]
+		if !ok {
+			return http.StatusBadRequest, fmt.Errorf("foto dengan ID %s tidak ditemukan di galeri ini", id)
+		}
+		if seen[id] {
+			return http.StatusBadRequest, fmt.Errorf("foto dengan ID %s disebut lebih dari sekali", id)
+		}
+		seen[id] = true
+
+		if before.Urutan == i+1 {
+			continue
+		}
+
+		photo := before
+		photo.Urutan = i + 1
+		err := galeriRepo.UpdatePhotoUrutan(ctx, tx, id, photo.Urutan)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("gagal mengupdate urutan foto: %v", err)
+		}
+
+		err = recordAudit(ctx, tx, auditRepo, models.AuditActionUpdate, models.AuditEntityGaleri, id, before, photo)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+	}
+
+	return http.StatusOK, nil
+}
+
+// findCoverCandidate mencari foto yang akan dijadikan sampul di antara foto milik berita atau album
+func findCoverCandidate(photos []models.Galeri, idGaleri string) (models.Galeri, int, error) {
+	if idGaleri == "" {
+		return models.Galeri{}, http.StatusBadRequest, fmt.Errorf("id_galeri tidak boleh kosong")
+	}
+
+	for _, photo := range photos {
+		if photo.IdGaleri == idGaleri {
+			return photo, http.StatusOK, nil
+		}
+	}
+
+	return models.Galeri{}, http.StatusBadRequest, fmt.Errorf("foto dengan ID %s tidak ditemukan di galeri ini", idGaleri)
+}
+
+// trashGaleriPhotos memindahkan foto ke tempat sampah dan mencatat audit-nya. File di storage
+// tetap disimpan agar foto dapat dipulihkan, pemanggil memindahkannya dengan trashGaleriFiles
+// setelah commit berhasil.
+func trashGaleriPhotos(ctx context.Context, tx *sql.Tx, galeriRepo repositories.GaleriRepository, auditRepo repositories.AuditRepository, photos []models.Galeri) (int, error) {
+	filenames := make([]string, len(photos))
+	for i, photo := range photos {
+		filenames[i] = photo.Gambar
+	}
+
+	err := galeriRepo.TrashPhotos(ctx, tx, filenames, time.Now())
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal menghapus foto dari database: %v", err)
+	}
+
+	for _, photo := range photos {
+		err = recordAudit(ctx, tx, auditRepo, models.AuditActionDelete, models.AuditEntityGaleri, photo.IdGaleri, photo, nil)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+	}
+
+	return http.StatusOK, nil
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+	"github.com/syrlramadhan/desa-sukamaju-api/storage"
+)
+
+// maxImageSize adalah ukuran maksimal satu file gambar yang diupload
+const maxImageSize = 5 * 1024 * 1024
+
+// Batas dimensi gambar, diperiksa dari header sebelum gambar di-decode penuh agar file kecil
+// yang mengklaim dimensi sangat besar (decompression bomb) tidak menghabiskan memori
+const (
+	maxImageDimension = 8000
+	maxImagePixels    = 24000000
+)
+
+const (
+	jpegQuality = 90
+	webpQuality = 80
+)
+
+// imageTypes memetakan tipe konten hasil sniffing ke format decoder dan ekstensi file yang disimpan
+var imageTypes = map[string]struct {
+	format string
+	ext    string
+}{
+	"image/jpeg": {"jpeg", ".jpg"},
+	"image/png":  {"png", ".png"},
+	"image/gif":  {"gif", ".gif"},
+}
+
+// markupSignatures adalah penanda HTML/SVG/script yang tidak boleh ada di dalam file gambar,
+// untuk menolak file polyglot yang sekaligus valid sebagai gambar dan sebagai dokumen
+var markupSignatures = [][]byte{
+	[]byte("<!doctype"),
+	[]byte("<html"),
+	[]byte("<head"),
+	[]byte("<body"),
+	[]byte("<script"),
+	[]byte("<iframe"),
+	[]byte("<svg"),
+	[]byte("<?xml"),
+	[]byte("<?php"),
+	[]byte("javascript:"),
+}
+
+// uploadedImage adalah file upload yang sudah divalidasi dan di-decode
+type uploadedImage struct {
+	data   []byte
+	image  image.Image
+	format string
+	ext    string
+}
+
+// readImage membaca dan memvalidasi file gambar yang diupload. Tipe file ditentukan dari
+// isi file (magic bytes), bukan dari nama atau Content-Type kiriman client, lalu gambar
+// di-decode penuh untuk memastikan file benar-benar gambar yang utuh.
+func readImage(file io.Reader, name string) (uploadedImage, error) {
+	data, err := io.ReadAll(io.LimitReader(file, maxImageSize+1))
+	if err != nil {
+		return uploadedImage{}, fmt.Errorf("gagal membaca file %s: %v", name, err)
+	}
+
+	if len(data) > maxImageSize {
+		return uploadedImage{}, fmt.Errorf("ukuran file %s terlalu besar. Maksimal 5MB per file", name)
+	}
+
+	imageType, ok := imageTypes[http.DetectContentType(data)]
+	if !ok {
+		return uploadedImage{}, fmt.Errorf("tipe file tidak diizinkan untuk %s. Gunakan: jpg, jpeg, png, gif", name)
+	}
+
+	// JPEG dan PNG selalu di-encode ulang oleh saveImage sehingga sisipan markup ikut terbuang.
+	// Blok frame GIF disalin apa adanya, sehingga hanya GIF yang diperiksa dari penanda markup;
+	// memeriksa seluruh isi JPEG terkompresi akan menolak foto biasa yang kebetulan memuat "<svg".
+	if imageType.format == "gif" && containsMarkup(data) {
+		return uploadedImage{}, fmt.Errorf("file %s mengandung konten yang tidak diizinkan", name)
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || format != imageType.format {
+		return uploadedImage{}, fmt.Errorf("file %s bukan gambar yang valid", name)
+	}
+
+	if config.Width <= 0 || config.Height <= 0 ||
+		config.Width > maxImageDimension || config.Height > maxImageDimension ||
+		config.Width*config.Height > maxImagePixels {
+		return uploadedImage{}, fmt.Errorf("dimensi gambar %s terlalu besar. Maksimal %dx%d piksel", name, maxImageDimension, maxImageDimension)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return uploadedImage{}, fmt.Errorf("file %s bukan gambar yang valid: %v", name, err)
+	}
+
+	if format == "jpeg" {
+		img = helpers.ApplyOrientation(img, helpers.JPEGOrientation(data))
+	}
+
+	return uploadedImage{
+		data:   data,
+		image:  img,
+		format: format,
+		ext:    imageType.ext,
+	}, nil
+}
+
+// containsMarkup memeriksa apakah data memuat salah satu markupSignatures tanpa membedakan huruf besar kecil
+func containsMarkup(data []byte) bool {
+	lower := bytes.ToLower(data)
+	for _, signature := range markupSignatures {
+		if bytes.Contains(lower, signature) {
+			return true
+		}
+	}
+	return false
+}
+
+// saveImage menyimpan gambar upload beserta varian thumbnail, medium dan large-nya dengan
+// nama base ditambah ekstensi sesuai tipe gambar, lalu mengembalikan nama file aslinya.
+// Gambar di-encode ulang sehingga metadata EXIF (termasuk lokasi GPS) tidak ikut tersimpan;
+// orientasi EXIF sudah diterapkan oleh readImage agar gambar tetap tampil tegak. Varian WebP
+// dilewati jika encoder WebP tidak tersedia di server.
+func saveImage(ctx context.Context, fileStorage storage.Storage, dir, base string, upload uploadedImage) (string, models.ImageVariants, error) {
+	img := upload.image
+	ext := upload.ext
+	filename := base + ext
+
+	// GIF tidak di-encode ulang agar animasinya tetap utuh, tetapi comment, XMP dan extension lain
+	// yang dapat berisi metadata dibuang terlebih dahulu
+	var original []byte
+	var err error
+	if upload.format == "gif" {
+		original, err = helpers.StripGIFMetadata(upload.data)
+	} else {
+		original, err = encodeImage(img, ext)
+	}
+	if err != nil {
+		return "", models.ImageVariants{}, fmt.Errorf("gagal memproses gambar %s: %v", filename, err)
+	}
+
+	// Varian dari GIF dan PNG disimpan sebagai PNG, selain itu sebagai JPEG
+	variantExt := ".jpg"
+	if ext == ".png" || ext == ".gif" {
+		variantExt = ".png"
+	}
+
+	var variants models.ImageVariants
+	var saved []string
+
+	put := func(name string, content []byte) error {
+		err := fileStorage.Put(ctx, storage.Key(dir, name), bytes.NewReader(content), mime.TypeByExtension(filepath.Ext(name)))
+		if err != nil {
+			return fmt.Errorf("gagal menyimpan file %s: %v", name, err)
+		}
+		saved = append(saved, name)
+		return nil
+	}
+
+	if err := put(filename, original); err != nil {
+		return "", models.ImageVariants{}, err
+	}
+
+	for _, size := range []struct {
+		name     string
+		maxSize  int
+		filename *string
+		webp     *string
+	}{
+		{"thumbnail", models.ImageSizeThumbnail, &variants.Thumbnail, &variants.ThumbnailWebp},
+		{"medium", models.ImageSizeMedium, &variants.Medium, &variants.MediumWebp},
+		{"large", models.ImageSizeLarge, &variants.Large, &variants.LargeWebp},
+	} {
+		resized := helpers.ResizeImage(img, size.maxSize)
+
+		content, err := encodeImage(resized, variantExt)
+		if err != nil {
+			removeImageFiles(ctx, fileStorage, dir, saved)
+			return "", models.ImageVariants{}, fmt.Errorf("gagal membuat varian %s untuk %s: %v", size.name, filename, err)
+		}
+
+		variantFilename := fmt.Sprintf("%s_%s%s", base, size.name, variantExt)
+		if err := put(variantFilename, content); err != nil {
+			removeImageFiles(ctx, fileStorage, dir, saved)
+			return "", models.ImageVariants{}, err
+		}
+		*size.filename = variantFilename
+
+		webp, err := helpers.EncodeWebP(resized, webpQuality)
+		if errors.Is(err, helpers.ErrWebPUnavailable) {
+			continue
+		}
+		if err != nil {
+			removeImageFiles(ctx, fileStorage, dir, saved)
+			return "", models.ImageVariants{}, fmt.Errorf("gagal membuat varian webp %s untuk %s: %v", size.name, filename, err)
+		}
+
+		webpFilename := fmt.Sprintf("%s_%s.webp", base, size.name)
+		if err := put(webpFilename, webp); err != nil {
+			removeImageFiles(ctx, fileStorage, dir, saved)
+			return "", models.ImageVariants{}, err
+		}
+		*size.webp = webpFilename
+	}
+
+	return filename, variants, nil
+}
+
+func encodeImage(img image.Image, ext string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+
+	switch ext {
+	case ".png":
+		err = png.Encode(&buf, img)
+	default:
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// deleteImage menghapus gambar beserta seluruh variannya dari storage
+func deleteImage(ctx context.Context, fileStorage storage.Storage, dir, filename string, variants models.ImageVariants) {
+	if filename == "" {
+		return
+	}
+
+	removeImageFiles(ctx, fileStorage, dir, append([]string{filename}, variants.Filenames()...))
+}
+
+// trashDir mengembalikan folder tempat sampah untuk folder upload dir
+func trashDir(dir string) string {
+	return storage.Key(storage.DirTrash, dir)
+}
+
+// moveImage memindahkan gambar beserta seluruh variannya dari folder from ke folder to. Dipanggil
+// setelah commit, sehingga file yang gagal dipindahkan hanya dicatat ke log.
+func moveImage(ctx context.Context, fileStorage storage.Storage, from, to, filename string, variants models.ImageVariants) {
+	if filename == "" {
+		return
+	}
+
+	for _, name := range append([]string{filename}, variants.Filenames()...) {
+		err := storage.Move(ctx, fileStorage, storage.Key(from, name), storage.Key(to, name))
+		if err != nil && !errors.Is(err, storage.ErrNotExist) {
+			fmt.Printf("gagal memindahkan file %s ke %s: %v\n", storage.Key(from, name), to, err)
+		}
+	}
+}
+
+// removeImageFiles menghapus file dari storage, error diabaikan karena file yang
+// tertinggal masih dapat dibersihkan lewat check-uploads
+func removeImageFiles(ctx context.Context, fileStorage storage.Storage, dir string, filenames []string) {
+	for _, filename := range filenames {
+		fileStorage.Delete(ctx, storage.Key(dir, filename))
+	}
+}
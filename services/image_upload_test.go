@@ -0,0 +1,135 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func testImage(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{uint8(x * 16), uint8(y * 16), 128, 255})
+		}
+	}
+	return img
+}
+
+func encodeTestJPEG(t *testing.T) []byte {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, testImage(8, 8), nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodeTestPNG(t *testing.T, w, h int) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, testImage(w, h)); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodeTestGIF(t *testing.T) []byte {
+	var buf bytes.Buffer
+	if err := gif.Encode(&buf, testImage(8, 8), nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadImageDetectsType(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		format  string
+		ext     string
+		wantErr string
+	}{
+		{name: "foto.jpg", data: encodeTestJPEG(t), format: "jpeg", ext: ".jpg"},
+		// Ekstensi mengikuti isi file, bukan nama file kiriman client
+		{name: "foto.jpg", data: encodeTestPNG(t, 8, 8), format: "png", ext: ".png"},
+		{name: "animasi.png", data: encodeTestGIF(t), format: "gif", ext: ".gif"},
+		{name: "dokumen.jpg", data: []byte("%PDF-1.4 bukan gambar"), wantErr: "tipe file tidak diizinkan"},
+		// Magic bytes JPEG tanpa isi gambar yang valid
+		{name: "rusak.jpg", data: append([]byte{0xFF, 0xD8, 0xFF, 0xE0}, make([]byte, 64)...), wantErr: "bukan gambar yang valid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"_"+tt.ext+tt.wantErr, func(t *testing.T) {
+			upload, err := readImage(bytes.NewReader(tt.data), tt.name)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if upload.format != tt.format || upload.ext != tt.ext {
+				t.Errorf("format, ext = %s, %s, want %s, %s", upload.format, upload.ext, tt.format, tt.ext)
+			}
+		})
+	}
+}
+
+func TestReadImageRejectsMarkup(t *testing.T) {
+	html := []byte("<!DOCTYPE html><html><body><script>alert(1)</script></body></html>")
+	if _, err := readImage(bytes.NewReader(html), "foto.jpg"); err == nil || !strings.Contains(err.Error(), "tipe file tidak diizinkan") {
+		t.Errorf("HTML error = %v", err)
+	}
+
+	// GIF disimpan apa adanya, sehingga sisipan script setelah trailer GIF ditolak
+	polyglot := append(encodeTestGIF(t), []byte("<SCRIPT>alert(1)</SCRIPT>")...)
+	if _, err := readImage(bytes.NewReader(polyglot), "animasi.gif"); err == nil || !strings.Contains(err.Error(), "konten yang tidak diizinkan") {
+		t.Errorf("GIF polyglot error = %v", err)
+	}
+
+	// JPEG selalu di-encode ulang, sehingga byte yang kebetulan berbunyi "<svg" tidak membuat foto ditolak
+	jpegWithMarker := append(encodeTestJPEG(t), []byte("<svg")...)
+	if _, err := readImage(bytes.NewReader(jpegWithMarker), "foto.jpg"); err != nil {
+		t.Errorf("JPEG dengan byte <svg ditolak: %v", err)
+	}
+}
+
+func TestReadImageRejectsDimensionBomb(t *testing.T) {
+	// PNG kecil yang header IHDR-nya mengklaim 50000x50000 piksel
+	data := encodeTestPNG(t, 1, 1)
+	binary.BigEndian.PutUint32(data[16:20], 50000)
+	binary.BigEndian.PutUint32(data[20:24], 50000)
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+
+	_, err := readImage(bytes.NewReader(data), "bom.png")
+	if err == nil || !strings.Contains(err.Error(), "dimensi gambar") {
+		t.Errorf("error = %v, want dimensi gambar terlalu besar", err)
+	}
+
+	// Dimensi masing-masing sisi masih di bawah batas, tetapi jumlah pikselnya melebihi batas
+	data = encodeTestPNG(t, 1, 1)
+	binary.BigEndian.PutUint32(data[16:20], maxImageDimension)
+	binary.BigEndian.PutUint32(data[20:24], maxImageDimension)
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+
+	_, err = readImage(bytes.NewReader(data), "bom.png")
+	if err == nil || !strings.Contains(err.Error(), "dimensi gambar") {
+		t.Errorf("error = %v, want dimensi gambar terlalu besar", err)
+	}
+}
+
+func TestReadImageRejectsOversizedFile(t *testing.T) {
+	data := append(encodeTestJPEG(t), make([]byte, maxImageSize)...)
+	_, err := readImage(bytes.NewReader(data), "besar.jpg")
+	if err == nil || !strings.Contains(err.Error(), "terlalu besar") {
+		t.Errorf("error = %v, want ukuran terlalu besar", err)
+	}
+}
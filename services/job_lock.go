@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+// Nama lock MySQL untuk job berkala, dipakai bersama oleh seluruh instance api yang
+// terhubung ke database yang sama
+const (
+	JobLockBeritaScheduler = "desa_sukamaju_berita_scheduler"
+	JobLockTrashPurger     = "desa_sukamaju_trash_purger"
+	JobLockUploadCheck     = "desa_sukamaju_upload_check"
+)
+
+// RunExclusive menjalankan fn hanya jika lock MySQL bernama name berhasil didapat, sehingga
+// job berkala tidak berjalan bersamaan di beberapa instance api di belakang load balancer.
+// ran bernilai false tanpa error jika lock sedang dipegang instance lain.
+// Lock dipegang oleh satu koneksi khusus sampai fn selesai, sedangkan fn tetap bebas
+// memakai koneksi lain dari pool untuk transaksinya.
+func RunExclusive(ctx context.Context, db *sql.DB, name string, fn func() error) (ran bool, err error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan koneksi untuk lock %s: %v", name, err)
+	}
+	defer conn.Close()
+
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", name).Scan(&acquired); err != nil {
+		return false, fmt.Errorf("gagal mengambil lock %s: %v", name, err)
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		return false, nil
+	}
+
+	defer func() {
+		if _, releaseErr := conn.ExecContext(context.Background(), "DO RELEASE_LOCK(?)", name); releaseErr != nil {
+			// Koneksi dibuang dari pool agar lock ikut lepas saat sesi MySQL ditutup
+			conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+	}()
+
+	return true, fn()
+}
@@ -0,0 +1,292 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/syrlramadhan/desa-sukamaju-api/dto"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+	"github.com/syrlramadhan/desa-sukamaju-api/repositories"
+)
+
+type KategoriService interface {
+	CreateKategori(ctx context.Context, kategoriReq dto.KategoriRequest) (dto.KategoriResponse, int, error)
+	GetAllKategori(ctx context.Context) ([]dto.KategoriResponse, int, error)
+	UpdateKategori(ctx context.Context, idKategori string, kategoriReq dto.KategoriRequest) (dto.KategoriResponse, int, error)
+	DeleteKategori(ctx context.Context, idKategori string) (int, error)
+	GetAllTag(ctx context.Context) ([]dto.TagResponse, int, error)
+	GenerateMissingSlugs(ctx context.Context) (int, error)
+}
+
+type kategoriServiceImpl struct {
+	repo      repositories.KategoriRepository
+	tagRepo   repositories.TagRepository
+	auditRepo repositories.AuditRepository
+	DB        *sql.DB
+}
+
+func NewKategoriService(repo repositories.KategoriRepository, tagRepo repositories.TagRepository, auditRepo repositories.AuditRepository, db *sql.DB) KategoriService {
+	return &kategoriServiceImpl{
+		repo:      repo,
+		tagRepo:   tagRepo,
+		auditRepo: auditRepo,
+		DB:        db,
+	}
+}
+
+// CreateKategori implements KategoriService.
+func (k *kategoriServiceImpl) CreateKategori(ctx context.Context, kategoriReq dto.KategoriRequest) (dto.KategoriResponse, int, error) {
+	tx, err := k.DB.Begin()
+	if err != nil {
+		return dto.KategoriResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	kategori := models.KategoriBerita{
+		IdKategori: uuid.New().String(),
+		CreatedAt:  time.Now(),
+	}
+
+	if code, err := k.applyKategoriRequest(ctx, tx, &kategori, kategoriReq); err != nil {
+		return dto.KategoriResponse{}, code, err
+	}
+
+	err = k.repo.AddKategori(ctx, tx, kategori)
+	if err != nil {
+		return dto.KategoriResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal menambahkan kategori: %v", err)
+	}
+
+	err = recordAudit(ctx, tx, k.auditRepo, models.AuditActionCreate, models.AuditEntityKategori, kategori.IdKategori, nil, kategori)
+	if err != nil {
+		return dto.KategoriResponse{}, http.StatusInternalServerError, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dto.KategoriResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return helpers.ConvertKategoriToResponseDTO(kategori), http.StatusOK, nil
+}
+
+// GetAllKategori implements KategoriService.
+func (k *kategoriServiceImpl) GetAllKategori(ctx context.Context) ([]dto.KategoriResponse, int, error) {
+	tx, err := k.DB.Begin()
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	kategoriList, err := k.repo.GetAllKategori(ctx, tx, time.Now())
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan data kategori: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	kategoriResponseList := []dto.KategoriResponse{}
+	for _, kategori := range kategoriList {
+		kategoriResponseList = append(kategoriResponseList, helpers.ConvertKategoriToResponseDTO(kategori))
+	}
+
+	return kategoriResponseList, http.StatusOK, nil
+}
+
+// UpdateKategori implements KategoriService.
+// Slug lama tidak disimpan, sehingga link feed per kategori yang memakai slug ikut berubah.
+func (k *kategoriServiceImpl) UpdateKategori(ctx context.Context, idKategori string, kategoriReq dto.KategoriRequest) (dto.KategoriResponse, int, error) {
+	tx, err := k.DB.Begin()
+	if err != nil {
+		return dto.KategoriResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	before, err := k.repo.GetKategoriById(ctx, tx, idKategori)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return dto.KategoriResponse{}, http.StatusNotFound, fmt.Errorf("kategori dengan ID %s tidak ditemukan", idKategori)
+		}
+		return dto.KategoriResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan kategori: %v", err)
+	}
+
+	kategori := before
+	if code, err := k.applyKategoriRequest(ctx, tx, &kategori, kategoriReq); err != nil {
+		return dto.KategoriResponse{}, code, err
+	}
+
+	err = k.repo.UpdateKategori(ctx, tx, kategori)
+	if err != nil {
+		return dto.KategoriResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengupdate kategori: %v", err)
+	}
+
+	err = recordAudit(ctx, tx, k.auditRepo, models.AuditActionUpdate, models.AuditEntityKategori, idKategori, before, kategori)
+	if err != nil {
+		return dto.KategoriResponse{}, http.StatusInternalServerError, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dto.KategoriResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return helpers.ConvertKategoriToResponseDTO(kategori), http.StatusOK, nil
+}
+
+// DeleteKategori implements KategoriService.
+// Kategori yang masih dipakai berita tidak dapat dihapus, berita harus dipindahkan ke kategori lain dahulu.
+func (k *kategoriServiceImpl) DeleteKategori(ctx context.Context, idKategori string) (int, error) {
+	tx, err := k.DB.Begin()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	kategori, err := k.repo.GetKategoriById(ctx, tx, idKategori)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return http.StatusNotFound, fmt.Errorf("kategori dengan ID %s tidak ditemukan", idKategori)
+		}
+		return http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan kategori: %v", err)
+	}
+
+	total, err := k.repo.CountBeritaByKategori(ctx, tx, idKategori)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal menghitung berita pada kategori: %v", err)
+	}
+
+	if total > 0 {
+		return http.StatusConflict, fmt.Errorf("kategori %s masih dipakai oleh %d berita", kategori.Nama, total)
+	}
+
+	err = k.repo.DeleteKategori(ctx, tx, idKategori)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal menghapus kategori: %v", err)
+	}
+
+	err = recordAudit(ctx, tx, k.auditRepo, models.AuditActionDelete, models.AuditEntityKategori, idKategori, kategori, nil)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return http.StatusOK, nil
+}
+
+// GetAllTag implements KategoriService.
+func (k *kategoriServiceImpl) GetAllTag(ctx context.Context) ([]dto.TagResponse, int, error) {
+	tx, err := k.DB.Begin()
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	tags, err := k.tagRepo.GetAllTag(ctx, tx, time.Now())
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan data tag: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	tagResponseList := []dto.TagResponse{}
+	for _, tag := range tags {
+		tagResponseList = append(tagResponseList, helpers.ConvertTagToResponseDTO(tag))
+	}
+
+	return tagResponseList, http.StatusOK, nil
+}
+
+// applyKategoriRequest memvalidasi request lalu mengisi nama, slug dan urutan kategori.
+// Slug dibuat dari nama jika tidak diisi, nama dan slug harus unik.
+func (k *kategoriServiceImpl) applyKategoriRequest(ctx context.Context, tx *sql.Tx, kategori *models.KategoriBerita, kategoriReq dto.KategoriRequest) (int, error) {
+	nama := strings.TrimSpace(kategoriReq.Nama)
+	if nama == "" {
+		return http.StatusBadRequest, fmt.Errorf("nama kategori tidak boleh kosong")
+	}
+
+	slug := helpers.Slugify(kategoriReq.Slug)
+	if slug == "" {
+		slug = helpers.Slugify(nama)
+	}
+	if slug == "" {
+		return http.StatusBadRequest, fmt.Errorf("slug kategori harus mengandung huruf atau angka")
+	}
+
+	taken, err := k.repo.IsNamaTaken(ctx, tx, nama, kategori.IdKategori)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memeriksa nama kategori: %v", err)
+	}
+	if taken {
+		return http.StatusConflict, fmt.Errorf("kategori %s sudah ada", nama)
+	}
+
+	taken, err = k.repo.IsSlugTaken(ctx, tx, slug, kategori.IdKategori)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memeriksa slug kategori: %v", err)
+	}
+	if taken {
+		return http.StatusConflict, fmt.Errorf("slug kategori %s sudah digunakan", slug)
+	}
+
+	kategori.Nama = nama
+	kategori.Slug = slug
+	kategori.Urutan = kategoriReq.Urutan
+	return http.StatusOK, nil
+}
+
+// GenerateMissingSlugs implements KategoriService.
+// Mengisi slug untuk kategori hasil migrasi dari kategori teks bebas. Nama yang berbeda bisa
+// menghasilkan slug yang sama, misalnya "Kegiatan Desa" dan "Kegiatan-Desa", sehingga slug
+// berikutnya diberi akhiran angka: "kegiatan-desa", "kegiatan-desa-2", dst.
+func (k *kategoriServiceImpl) GenerateMissingSlugs(ctx context.Context) (int, error) {
+	tx, err := k.DB.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	kategoriList, err := k.repo.GetKategoriWithoutSlug(ctx, tx)
+	if err != nil {
+		return 0, fmt.Errorf("gagal mendapatkan kategori tanpa slug: %v", err)
+	}
+
+	for _, kategori := range kategoriList {
+		base := helpers.Slugify(kategori.Nama)
+		if base == "" {
+			base = "kategori"
+		}
+
+		slug := base
+		for i := 2; ; i++ {
+			taken, err := k.repo.IsSlugTaken(ctx, tx, slug, kategori.IdKategori)
+			if err != nil {
+				return 0, fmt.Errorf("gagal memeriksa slug kategori: %v", err)
+			}
+			if !taken {
+				break
+			}
+			slug = fmt.Sprintf("%s-%d", base, i)
+		}
+
+		err = k.repo.UpdateSlugKategori(ctx, tx, kategori.IdKategori, slug)
+		if err != nil {
+			return 0, fmt.Errorf("gagal menyimpan slug kategori: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	return len(kategoriList), nil
+}
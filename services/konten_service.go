@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/syrlramadhan/desa-sukamaju-api/dto"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
 	"github.com/syrlramadhan/desa-sukamaju-api/models"
 	"github.com/syrlramadhan/desa-sukamaju-api/repositories"
 )
@@ -18,12 +19,14 @@ type KontenService interface {
 
 type kontenServiceImpl struct {
 	KontenRepository repositories.KontenRepository
+	AuditRepository  repositories.AuditRepository
 	DB               *sql.DB
 }
 
-func NewKontenService(kontenRepository repositories.KontenRepository, db *sql.DB) KontenService {
+func NewKontenService(kontenRepository repositories.KontenRepository, auditRepository repositories.AuditRepository, db *sql.DB) KontenService {
 	return &kontenServiceImpl{
 		KontenRepository: kontenRepository,
+		AuditRepository:  auditRepository,
 		DB:               db,
 	}
 }
@@ -45,14 +48,7 @@ func (k *kontenServiceImpl) GetKontak(ctx context.Context, idKontak string) (dto
 		return dto.KontakResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan kontak: %v", err)
 	}
 
-	kontakResponse := dto.KontakResponse{
-		IdKontak:  kontak.IdKontak,
-		Email:     kontak.Email,
-		Telepon:   kontak.Telepon,
-		Facebook:  kontak.Facebook,
-		Youtube:   kontak.Youtube,
-		Instagram: kontak.Instagram,
-	}
+	kontakResponse := helpers.ConvertKontakToResponseDTO(kontak)
 
 	if err := tx.Commit(); err != nil {
 		return dto.KontakResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
@@ -67,6 +63,12 @@ func (k *kontenServiceImpl) UpdateKontak(ctx context.Context, idKontak string, k
 	if err != nil {
 		return http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
+	defer tx.Rollback()
+
+	before, err := k.KontenRepository.GetKontak(ctx, tx, models.Kontak{IdKontak: idKontak})
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan kontak: %v", err)
+	}
 
 	kontakModel := models.Kontak{
 		IdKontak:  idKontak,
@@ -82,6 +84,11 @@ func (k *kontenServiceImpl) UpdateKontak(ctx context.Context, idKontak string, k
 		return http.StatusInternalServerError, fmt.Errorf("gagal mengupdate kontak: %v", err)
 	}
 
+	err = recordAudit(ctx, tx, k.AuditRepository, models.AuditActionUpdate, models.AuditEntityKontak, idKontak, helpers.ConvertKontakToResponseDTO(before), helpers.ConvertKontakToResponseDTO(kontakModel))
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
 	}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/syrlramadhan/desa-sukamaju-api/dto"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
 	"github.com/syrlramadhan/desa-sukamaju-api/models"
 	"github.com/syrlramadhan/desa-sukamaju-api/repositories"
 )
@@ -17,14 +18,16 @@ type PendudukService interface {
 }
 
 type pendudukServiceImpl struct {
-	repo repositories.PendudukRepository
-	DB   *sql.DB
+	repo      repositories.PendudukRepository
+	auditRepo repositories.AuditRepository
+	DB        *sql.DB
 }
 
-func NewPendudukService(repo repositories.PendudukRepository, DB *sql.DB) PendudukService {
+func NewPendudukService(repo repositories.PendudukRepository, auditRepo repositories.AuditRepository, DB *sql.DB) PendudukService {
 	return &pendudukServiceImpl{
-		repo: repo,
-		DB:   DB,
+		repo:      repo,
+		auditRepo: auditRepo,
+		DB:        DB,
 	}
 }
 
@@ -80,10 +83,11 @@ func (p *pendudukServiceImpl) UpdatePenduduk(ctx context.Context, pendudukReques
 		return dto.PendudukResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memperbarui data penduduk: %v", err)
 	}
 
-	updatedPendudukResponse := dto.PendudukResponse{
-		IdPenduduk:          pendudukModel.IdPenduduk,
-		TotalPenduduk:       pendudukModel.TotalPenduduk,
-		TotalKepalaKeluarga: pendudukModel.TotalKepalaKeluarga,
+	updatedPendudukResponse := helpers.ConvertPendudukToResponseDTO(pendudukModel)
+
+	err = recordAudit(ctx, tx, p.auditRepo, models.AuditActionUpdate, models.AuditEntityPenduduk, pendudukModel.IdPenduduk, helpers.ConvertPendudukToResponseDTO(penduduk), updatedPendudukResponse)
+	if err != nil {
+		return dto.PendudukResponse{}, http.StatusInternalServerError, err
 	}
 
 	if err := tx.Commit(); err != nil {
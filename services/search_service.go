@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/dto"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/repositories"
+)
+
+const (
+	searchMaxQueryLength = 100
+	searchSnippetRadius  = 80
+)
+
+type SearchService interface {
+	Search(ctx context.Context, keyword string, page, perPage int) ([]dto.SearchResponse, dto.PaginationMeta, int, error)
+}
+
+type searchServiceImpl struct {
+	SearchRepository repositories.SearchRepository
+	DB               *sql.DB
+}
+
+func NewSearchService(searchRepository repositories.SearchRepository, db *sql.DB) SearchService {
+	return &searchServiceImpl{
+		SearchRepository: searchRepository,
+		DB:               db,
+	}
+}
+
+// Search implements SearchService.
+func (s *searchServiceImpl) Search(ctx context.Context, keyword string, page, perPage int) ([]dto.SearchResponse, dto.PaginationMeta, int, error) {
+	keyword = strings.TrimSpace(keyword)
+	if keyword == "" {
+		return nil, dto.PaginationMeta{}, http.StatusBadRequest, fmt.Errorf("kata kunci pencarian tidak boleh kosong")
+	}
+
+	if utf8.RuneCountInString(keyword) > searchMaxQueryLength {
+		return nil, dto.PaginationMeta{}, http.StatusBadRequest, fmt.Errorf("kata kunci pencarian maksimal %d karakter", searchMaxQueryLength)
+	}
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	limit, offset := perPage, (page-1)*perPage
+	now := time.Now()
+
+	// FULLTEXT mengabaikan kata yang terlalu pendek atau stopword, sehingga pencarian
+	// dilanjutkan dengan LIKE jika tidak ada hasil atau index belum tersedia
+	results, total, err := s.SearchRepository.SearchFulltext(ctx, tx, keyword, now, limit, offset)
+	if err != nil && err != repositories.ErrFulltextUnavailable {
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal melakukan pencarian: %v", err)
+	}
+
+	if err == repositories.ErrFulltextUnavailable || total == 0 {
+		results, total, err = s.SearchRepository.SearchLike(ctx, tx, keyword, now, limit, offset)
+		if err != nil {
+			return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal melakukan pencarian: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	searchResponse := []dto.SearchResponse{}
+	for _, result := range results {
+		snippetSource := result.Content
+		if snippetSource == "" {
+			snippetSource = result.Title
+		}
+
+		searchResponse = append(searchResponse, dto.SearchResponse{
+			Type:    result.Type,
+			Id:      result.Id,
+			Title:   result.Title,
+			Snippet: helpers.HighlightSnippet(snippetSource, keyword, searchSnippetRadius),
+			Score:   result.Score,
+		})
+	}
+
+	return searchResponse, helpers.NewPaginationMeta(page, perPage, total), http.StatusOK, nil
+}
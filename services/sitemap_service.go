@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/dto"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+	"github.com/syrlramadhan/desa-sukamaju-api/repositories"
+)
+
+// sitemapMaxURLs adalah batas jumlah URL dalam satu file sitemap menurut protokol sitemap
+const sitemapMaxURLs = 50000
+
+// defaultRobotsDisallow adalah path api khusus admin yang tidak perlu dijelajahi mesin pencari,
+// dipakai jika ROBOTS_DISALLOW tidak diisi
+var defaultRobotsDisallow = []string{"/api/v1/admin", "/api/v1/audit", "/api/v1/review/"}
+
+type SitemapService interface {
+	CountSitemapPages(ctx context.Context) (int, int, error)
+	GetSitemapPage(ctx context.Context, page int) (dto.SitemapURLSet, int, error)
+	GetSitemapIndex(ctx context.Context, pages int, baseURL string) (dto.SitemapIndex, int, error)
+	GetRobotsTxt(baseURL string) string
+}
+
+type sitemapServiceImpl struct {
+	repo repositories.SitemapRepository
+	DB   *sql.DB
+}
+
+func NewSitemapService(repo repositories.SitemapRepository, db *sql.DB) SitemapService {
+	return &sitemapServiceImpl{
+		repo: repo,
+		DB:   db,
+	}
+}
+
+// sitemapPage adalah halaman statis website desa beserta entity audit yang menentukan lastmod-nya
+type sitemapPage struct {
+	path     string
+	entities []string
+}
+
+var sitemapStaticPages = []sitemapPage{
+	{"/", []string{models.AuditEntityBerita, models.AuditEntityAparat, models.AuditEntityKontak}},
+	{"/berita", []string{models.AuditEntityBerita}},
+	{"/aparat", []string{models.AuditEntityAparat}},
+	{"/kontak", []string{models.AuditEntityKontak}},
+}
+
+// CountSitemapPages implements SitemapService.
+// Sitemap hanya dapat dibuat jika SITE_URL diisi, karena URL di dalamnya harus mengarah ke website desa.
+func (s *sitemapServiceImpl) CountSitemapPages(ctx context.Context) (int, int, error) {
+	if _, code, err := sitemapSiteURL(); err != nil {
+		return 0, code, err
+	}
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return 0, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	totalBerita, err := s.repo.CountBerita(ctx, tx, time.Now())
+	if err != nil {
+		return 0, http.StatusInternalServerError, fmt.Errorf("gagal menghitung berita: %v", err)
+	}
+
+	aparatEntries, err := s.repo.GetAparatEntries(ctx, tx)
+	if err != nil {
+		return 0, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan data aparat: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	total := len(sitemapStaticPages) + len(aparatEntries) + totalBerita
+	return (total + sitemapMaxURLs - 1) / sitemapMaxURLs, http.StatusOK, nil
+}
+
+// GetSitemapPage implements SitemapService.
+// URL diurutkan halaman statis, profil aparat, lalu berita, dan dibagi per sitemapMaxURLs.
+func (s *sitemapServiceImpl) GetSitemapPage(ctx context.Context, page int) (dto.SitemapURLSet, int, error) {
+	siteURL, code, err := sitemapSiteURL()
+	if err != nil {
+		return dto.SitemapURLSet{}, code, err
+	}
+
+	if page < 1 {
+		return dto.SitemapURLSet{}, http.StatusNotFound, fmt.Errorf("sitemap %d tidak ditemukan", page)
+	}
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return dto.SitemapURLSet{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	var urls []dto.SitemapURL
+
+	// Halaman statis dan profil aparat jumlahnya kecil sehingga dimuat seluruhnya
+	for _, staticPage := range sitemapStaticPages {
+		var lastMod time.Time
+		for _, entity := range staticPage.entities {
+			var entityLastMod time.Time
+			if entity == models.AuditEntityBerita {
+				entityLastMod, err = s.repo.GetBeritaLastModified(ctx, tx, now)
+			} else {
+				entityLastMod, err = s.repo.GetEntityLastModified(ctx, tx, entity)
+			}
+			if err != nil {
+				return dto.SitemapURLSet{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan waktu perubahan %s: %v", entity, err)
+			}
+
+			if entityLastMod.After(lastMod) {
+				lastMod = entityLastMod
+			}
+		}
+
+		urls = append(urls, sitemapURL(siteURL+staticPage.path, &lastMod))
+	}
+
+	aparatEntries, err := s.repo.GetAparatEntries(ctx, tx)
+	if err != nil {
+		return dto.SitemapURLSet{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan data aparat: %v", err)
+	}
+
+	for _, entry := range aparatEntries {
+		urls = append(urls, sitemapURL(siteURL+"/aparat/"+entry.Key, entry.LastMod))
+	}
+
+	offset := (page - 1) * sitemapMaxURLs
+	if offset < len(urls) {
+		urls = urls[offset:]
+	} else {
+		urls = nil
+	}
+
+	// Sisa kuota halaman ini diisi berita, offset berita digeser sebanyak URL statis dan aparat
+	if limit := sitemapMaxURLs - len(urls); limit > 0 {
+		beritaOffset := offset - (len(sitemapStaticPages) + len(aparatEntries))
+		if beritaOffset < 0 {
+			beritaOffset = 0
+		}
+
+		beritaEntries, err := s.repo.GetBeritaEntries(ctx, tx, now, limit, beritaOffset)
+		if err != nil {
+			return dto.SitemapURLSet{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan data berita: %v", err)
+		}
+
+		for _, entry := range beritaEntries {
+			urls = append(urls, sitemapURL(helpers.BeritaURL(siteURL, entry.Key), entry.LastMod))
+		}
+	} else {
+		urls = urls[:sitemapMaxURLs]
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dto.SitemapURLSet{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	if len(urls) == 0 {
+		return dto.SitemapURLSet{}, http.StatusNotFound, fmt.Errorf("sitemap %d tidak ditemukan", page)
+	}
+
+	return dto.SitemapURLSet{URLs: urls}, http.StatusOK, nil
+}
+
+// GetSitemapIndex implements SitemapService.
+// File sitemap dilayani oleh api, sehingga index menunjuk ke alamat api (API_URL), bukan SITE_URL.
+func (s *sitemapServiceImpl) GetSitemapIndex(ctx context.Context, pages int, baseURL string) (dto.SitemapIndex, int, error) {
+	if _, code, err := sitemapSiteURL(); err != nil {
+		return dto.SitemapIndex{}, code, err
+	}
+
+	apiURL := helpers.APIURL(baseURL)
+	index := dto.SitemapIndex{}
+	for page := 1; page <= pages; page++ {
+		index.Sitemaps = append(index.Sitemaps, dto.SitemapURL{Loc: apiURL + "/sitemap/" + strconv.Itoa(page) + ".xml"})
+	}
+
+	return index, http.StatusOK, nil
+}
+
+// GetRobotsTxt implements SitemapService.
+// Path yang dilarang diatur lewat ROBOTS_DISALLOW (dipisah koma), isi "/" untuk menutup seluruh
+// situs misalnya di server staging. Baris Sitemap hanya ditulis jika SITE_URL diisi dan menunjuk
+// ke sitemap.xml di alamat api (API_URL). Jika website desa berada di host lain, robots.txt
+// website tersebut perlu memuat baris Sitemap yang sama agar mesin pencari menerima sitemap lintas host.
+func (s *sitemapServiceImpl) GetRobotsTxt(baseURL string) string {
+	disallow := defaultRobotsDisallow
+	if value, ok := os.LookupEnv("ROBOTS_DISALLOW"); ok {
+		disallow = nil
+		for _, path := range strings.Split(value, ",") {
+			if path = strings.TrimSpace(path); path != "" {
+				disallow = append(disallow, path)
+			}
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString("User-agent: *\n")
+	if len(disallow) == 0 {
+		builder.WriteString("Disallow:\n")
+	}
+	for _, path := range disallow {
+		builder.WriteString("Disallow: " + path + "\n")
+	}
+
+	if _, _, err := sitemapSiteURL(); err == nil {
+		builder.WriteString("\nSitemap: " + helpers.APIURL(baseURL) + "/sitemap.xml\n")
+	}
+
+	return builder.String()
+}
+
+func sitemapSiteURL() (string, int, error) {
+	siteURL := strings.TrimRight(os.Getenv("SITE_URL"), "/")
+	if siteURL == "" {
+		return "", http.StatusNotFound, fmt.Errorf("sitemap tidak tersedia, SITE_URL belum diatur")
+	}
+
+	return siteURL, http.StatusOK, nil
+}
+
+func sitemapURL(loc string, lastMod *time.Time) dto.SitemapURL {
+	url := dto.SitemapURL{Loc: loc}
+	if lastMod != nil && !lastMod.IsZero() {
+		url.LastMod = lastMod.In(helpers.Location()).Format(time.RFC3339)
+	}
+	return url
+}
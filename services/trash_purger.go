@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+	"github.com/syrlramadhan/desa-sukamaju-api/repositories"
+	"github.com/syrlramadhan/desa-sukamaju-api/storage"
+)
+
+// DefaultTrashRetention adalah lama data disimpan di tempat sampah jika TRASH_RETENTION_DAYS tidak diisi
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// trashPurgeInterval adalah jeda antar pemeriksaan tempat sampah
+const trashPurgeInterval = time.Hour
+
+// trashPurgeBatchSize membatasi jumlah data yang dihapus dalam satu transaksi
+const trashPurgeBatchSize = 100
+
+type TrashPurger interface {
+	Run(ctx context.Context)
+	Purge(ctx context.Context, before time.Time) (int, error)
+}
+
+type trashPurgerImpl struct {
+	beritaRepo repositories.BeritaRepository
+	aparatRepo repositories.AparatRepository
+	galeriRepo repositories.GaleriRepository
+	auditRepo  repositories.AuditRepository
+	storage    storage.Storage
+	retention  time.Duration
+	DB         *sql.DB
+}
+
+func NewTrashPurger(beritaRepo repositories.BeritaRepository, aparatRepo repositories.AparatRepository, galeriRepo repositories.GaleriRepository, auditRepo repositories.AuditRepository, fileStorage storage.Storage, retention time.Duration, db *sql.DB) TrashPurger {
+	return &trashPurgerImpl{
+		beritaRepo: beritaRepo,
+		aparatRepo: aparatRepo,
+		galeriRepo: galeriRepo,
+		auditRepo:  auditRepo,
+		storage:    fileStorage,
+		retention:  retention,
+		DB:         db,
+	}
+}
+
+// Run implements TrashPurger.
+// Menghapus permanen data yang sudah melewati masa simpan setiap trashPurgeInterval hingga ctx dibatalkan.
+// Jika beberapa instance berjalan, hanya satu yang mengosongkan tempat sampah pada saat yang sama.
+func (t *trashPurgerImpl) Run(ctx context.Context) {
+	for {
+		_, err := RunExclusive(ctx, t.DB, JobLockTrashPurger, func() error {
+			_, err := t.Purge(ctx, time.Now().Add(-t.retention))
+			return err
+		})
+		if err != nil {
+			fmt.Printf("gagal mengosongkan tempat sampah: %v\n", err)
+		}
+
+		timer := time.NewTimer(trashPurgeInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// Purge implements TrashPurger.
+// Menghapus permanen berita, foto dan aparat yang masuk tempat sampah sebelum waktu before beserta
+// filenya. Berita dihapus lebih dahulu karena foto berita ikut terhapus oleh foreign key.
+// Setiap penghapusan dicatat di audit log tanpa admin karena dilakukan oleh sistem.
+func (t *trashPurgerImpl) Purge(ctx context.Context, before time.Time) (int, error) {
+	purged := 0
+	for _, purge := range []func(context.Context, time.Time) (int, error){t.purgeBerita, t.purgePhotos, t.purgeAparat} {
+		for {
+			n, err := purge(ctx, before)
+			if err != nil {
+				return purged, err
+			}
+			purged += n
+			if n < trashPurgeBatchSize {
+				break
+			}
+		}
+	}
+
+	return purged, nil
+}
+
+func (t *trashPurgerImpl) purgeBerita(ctx context.Context, before time.Time) (int, error) {
+	tx, err := t.DB.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	filter := models.BeritaFilter{Trashed: true, DeletedBefore: before, Limit: trashPurgeBatchSize}
+	beritaList, _, err := t.beritaRepo.GetAllBerita(ctx, tx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("gagal mendapatkan berita di tempat sampah: %v", err)
+	}
+
+	var photos []models.Galeri
+	for _, berita := range beritaList {
+		// Foto berita yang dihapus satu per satu juga ikut terhapus bersama beritanya
+		trashedPhotos, _, err := t.galeriRepo.GetDeletedPhotos(ctx, tx, models.TrashFilter{IdBerita: berita.IdBerita})
+		if err != nil {
+			return 0, fmt.Errorf("gagal mendapatkan foto berita: %v", err)
+		}
+		photos = append(photos, berita.GambarBerita...)
+		photos = append(photos, trashedPhotos...)
+
+		err = t.beritaRepo.DeleteBerita(ctx, tx, berita.IdBerita)
+		if err != nil {
+			return 0, fmt.Errorf("gagal menghapus berita: %v", err)
+		}
+
+		err = recordAudit(ctx, tx, t.auditRepo, models.AuditActionPurge, models.AuditEntityBerita, berita.IdBerita, berita, nil)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	// Hapus file gambar dari folder tempat sampah setelah commit berhasil
+	removeTrashedGaleriFiles(ctx, t.storage, photos)
+
+	return len(beritaList), nil
+}
+
+func (t *trashPurgerImpl) purgePhotos(ctx context.Context, before time.Time) (int, error) {
+	tx, err := t.DB.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	photos, _, err := t.galeriRepo.GetDeletedPhotos(ctx, tx, models.TrashFilter{Before: before, Limit: trashPurgeBatchSize})
+	if err != nil {
+		return 0, fmt.Errorf("gagal mendapatkan foto di tempat sampah: %v", err)
+	}
+
+	filenames := make([]string, len(photos))
+	for i, photo := range photos {
+		filenames[i] = photo.Gambar
+	}
+
+	err = t.galeriRepo.BulkDeletePhoto(ctx, tx, filenames)
+	if err != nil {
+		return 0, fmt.Errorf("gagal menghapus foto: %v", err)
+	}
+
+	for _, photo := range photos {
+		err = recordAudit(ctx, tx, t.auditRepo, models.AuditActionPurge, models.AuditEntityGaleri, photo.IdGaleri, photo, nil)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	// Hapus file gambar dari folder tempat sampah setelah commit berhasil
+	removeTrashedGaleriFiles(ctx, t.storage, photos)
+
+	return len(photos), nil
+}
+
+func (t *trashPurgerImpl) purgeAparat(ctx context.Context, before time.Time) (int, error) {
+	tx, err := t.DB.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	aparatList, _, err := t.aparatRepo.GetDeletedAparat(ctx, tx, models.TrashFilter{Before: before, Limit: trashPurgeBatchSize})
+	if err != nil {
+		return 0, fmt.Errorf("gagal mendapatkan aparat di tempat sampah: %v", err)
+	}
+
+	idAparat := make([]string, len(aparatList))
+	for i, aparat := range aparatList {
+		idAparat[i] = aparat.IdAparat
+	}
+
+	err = t.aparatRepo.BulkDeleteAparat(ctx, tx, idAparat)
+	if err != nil {
+		return 0, fmt.Errorf("gagal menghapus aparat: %v", err)
+	}
+
+	for _, aparat := range aparatList {
+		err = recordAudit(ctx, tx, t.auditRepo, models.AuditActionPurge, models.AuditEntityAparat, aparat.IdAparat, helpers.ConvertAparatToResponseDTO(aparat, t.storage), nil)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	// Hapus file foto dari folder tempat sampah setelah commit berhasil
+	for _, aparat := range aparatList {
+		deleteImage(ctx, t.storage, trashDir(storage.DirAparat), aparat.Foto, aparat.VarianFoto)
+	}
+
+	return len(aparatList), nil
+}
@@ -0,0 +1,286 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/dto"
+	"github.com/syrlramadhan/desa-sukamaju-api/helpers"
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+	"github.com/syrlramadhan/desa-sukamaju-api/repositories"
+	"github.com/syrlramadhan/desa-sukamaju-api/storage"
+)
+
+// TrashService menampilkan dan memulihkan berita, aparat dan foto yang ada di tempat sampah.
+// Data di tempat sampah dihapus permanen oleh TrashPurger setelah masa simpannya habis.
+type TrashService interface {
+	GetTrashBerita(ctx context.Context, trashQuery dto.TrashQuery) ([]dto.BeritaResponse, dto.PaginationMeta, int, error)
+	RestoreBerita(ctx context.Context, idBerita string) (dto.BeritaResponse, int, error)
+	GetTrashAparat(ctx context.Context, trashQuery dto.TrashQuery) ([]dto.AparatResponse, dto.PaginationMeta, int, error)
+	RestoreAparat(ctx context.Context, idAparat string) (dto.AparatResponse, int, error)
+	GetTrashPhoto(ctx context.Context, trashQuery dto.TrashQuery) ([]dto.TrashPhotoResponse, dto.PaginationMeta, int, error)
+	RestorePhoto(ctx context.Context, filename string) (int, error)
+}
+
+type trashServiceImpl struct {
+	beritaRepo repositories.BeritaRepository
+	aparatRepo repositories.AparatRepository
+	albumRepo  repositories.AlbumRepository
+	galeriRepo repositories.GaleriRepository
+	auditRepo  repositories.AuditRepository
+	storage    storage.Storage
+	DB         *sql.DB
+}
+
+func NewTrashService(beritaRepo repositories.BeritaRepository, aparatRepo repositories.AparatRepository, albumRepo repositories.AlbumRepository, galeriRepo repositories.GaleriRepository, auditRepo repositories.AuditRepository, fileStorage storage.Storage, db *sql.DB) TrashService {
+	return &trashServiceImpl{
+		beritaRepo: beritaRepo,
+		aparatRepo: aparatRepo,
+		albumRepo:  albumRepo,
+		galeriRepo: galeriRepo,
+		auditRepo:  auditRepo,
+		storage:    fileStorage,
+		DB:         db,
+	}
+}
+
+// GetTrashBerita implements TrashService.
+func (t *trashServiceImpl) GetTrashBerita(ctx context.Context, trashQuery dto.TrashQuery) ([]dto.BeritaResponse, dto.PaginationMeta, int, error) {
+	tx, err := t.DB.Begin()
+	if err != nil {
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	filter := models.BeritaFilter{
+		Trashed: true,
+		Limit:   trashQuery.PerPage,
+		Offset:  (trashQuery.Page - 1) * trashQuery.PerPage,
+	}
+
+	beritaList, total, err := t.beritaRepo.GetAllBerita(ctx, tx, filter)
+	if err != nil {
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan berita di tempat sampah: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	beritaResponseList := []dto.BeritaResponse{}
+	for _, berita := range beritaList {
+		beritaResponseList = append(beritaResponseList, helpers.ConvertBeritaToResponseDTO(berita, t.storage))
+	}
+
+	return beritaResponseList, helpers.NewPaginationMeta(trashQuery.Page, trashQuery.PerPage, total), http.StatusOK, nil
+}
+
+// RestoreBerita implements TrashService.
+// Berita kembali dengan status terakhirnya, berita terbit langsung tampil lagi untuk publik
+// sehingga hanya superadmin yang dapat memulihkan berita scheduled atau published.
+func (t *trashServiceImpl) RestoreBerita(ctx context.Context, idBerita string) (dto.BeritaResponse, int, error) {
+	tx, err := t.DB.Begin()
+	if err != nil {
+		return dto.BeritaResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	before, err := t.beritaRepo.GetDeletedBeritaById(ctx, tx, idBerita)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return dto.BeritaResponse{}, http.StatusNotFound, fmt.Errorf("berita dengan ID %s tidak ditemukan di tempat sampah", idBerita)
+		}
+		return dto.BeritaResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan berita: %v", err)
+	}
+
+	if code, err := checkBeritaEditable(ctx, before.Status); err != nil {
+		return dto.BeritaResponse{}, code, err
+	}
+
+	err = t.beritaRepo.RestoreBerita(ctx, tx, idBerita)
+	if err != nil {
+		return dto.BeritaResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memulihkan berita: %v", err)
+	}
+
+	berita := before
+	berita.DeletedAt = nil
+	err = recordAudit(ctx, tx, t.auditRepo, models.AuditActionRestore, models.AuditEntityBerita, idBerita, before, berita)
+	if err != nil {
+		return dto.BeritaResponse{}, http.StatusInternalServerError, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dto.BeritaResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	// Kembalikan file foto dari tempat sampah setelah commit berhasil
+	restoreGaleriFiles(ctx, t.storage, before.GambarBerita)
+
+	return helpers.ConvertBeritaToResponseDTO(berita, t.storage), http.StatusOK, nil
+}
+
+// GetTrashAparat implements TrashService.
+func (t *trashServiceImpl) GetTrashAparat(ctx context.Context, trashQuery dto.TrashQuery) ([]dto.AparatResponse, dto.PaginationMeta, int, error) {
+	tx, err := t.DB.Begin()
+	if err != nil {
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	filter := models.TrashFilter{
+		Limit:  trashQuery.PerPage,
+		Offset: (trashQuery.Page - 1) * trashQuery.PerPage,
+	}
+
+	aparatList, total, err := t.aparatRepo.GetDeletedAparat(ctx, tx, filter)
+	if err != nil {
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan aparat di tempat sampah: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	aparatResponseList := []dto.AparatResponse{}
+	for _, aparat := range aparatList {
+		aparatResponseList = append(aparatResponseList, helpers.ConvertAparatToResponseDTO(aparat, t.storage))
+	}
+
+	return aparatResponseList, helpers.NewPaginationMeta(trashQuery.Page, trashQuery.PerPage, total), http.StatusOK, nil
+}
+
+// RestoreAparat implements TrashService.
+func (t *trashServiceImpl) RestoreAparat(ctx context.Context, idAparat string) (dto.AparatResponse, int, error) {
+	tx, err := t.DB.Begin()
+	if err != nil {
+		return dto.AparatResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	before, err := t.aparatRepo.GetDeletedAparatById(ctx, tx, idAparat)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return dto.AparatResponse{}, http.StatusNotFound, fmt.Errorf("aparat dengan id %s tidak ditemukan di tempat sampah", idAparat)
+		}
+		return dto.AparatResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan aparat: %v", err)
+	}
+
+	err = t.aparatRepo.RestoreAparat(ctx, tx, idAparat)
+	if err != nil {
+		return dto.AparatResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memulihkan aparat: %v", err)
+	}
+
+	aparat := before
+	aparat.DeletedAt = nil
+	aparatResponse := helpers.ConvertAparatToResponseDTO(aparat, t.storage)
+	err = recordAudit(ctx, tx, t.auditRepo, models.AuditActionRestore, models.AuditEntityAparat, idAparat, helpers.ConvertAparatToResponseDTO(before, t.storage), aparatResponse)
+	if err != nil {
+		return dto.AparatResponse{}, http.StatusInternalServerError, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dto.AparatResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	// Kembalikan file foto dari tempat sampah setelah commit berhasil
+	moveImage(ctx, t.storage, trashDir(storage.DirAparat), storage.DirAparat, before.Foto, before.VarianFoto)
+
+	return aparatResponse, http.StatusOK, nil
+}
+
+// GetTrashPhoto implements TrashService.
+// Hasil dapat dibatasi pada foto milik satu berita atau satu album.
+func (t *trashServiceImpl) GetTrashPhoto(ctx context.Context, trashQuery dto.TrashQuery) ([]dto.TrashPhotoResponse, dto.PaginationMeta, int, error) {
+	tx, err := t.DB.Begin()
+	if err != nil {
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	filter := models.TrashFilter{
+		IdBerita: trashQuery.IdBerita,
+		IdAlbum:  trashQuery.IdAlbum,
+		Limit:    trashQuery.PerPage,
+		Offset:   (trashQuery.Page - 1) * trashQuery.PerPage,
+	}
+
+	photos, total, err := t.galeriRepo.GetDeletedPhotos(ctx, tx, filter)
+	if err != nil {
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan foto di tempat sampah: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, dto.PaginationMeta{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	photoResponseList := []dto.TrashPhotoResponse{}
+	for _, photo := range photos {
+		photoResponseList = append(photoResponseList, helpers.ConvertTrashPhotoToResponseDTO(photo, t.storage))
+	}
+
+	return photoResponseList, helpers.NewPaginationMeta(trashQuery.Page, trashQuery.PerPage, total), http.StatusOK, nil
+}
+
+// RestorePhoto implements TrashService.
+// Foto dipulihkan ke urutan paling akhir, dan menjadi sampul jika pemiliknya belum punya sampul.
+func (t *trashServiceImpl) RestorePhoto(ctx context.Context, filename string) (int, error) {
+	tx, err := t.DB.Begin()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	before, err := t.galeriRepo.GetDeletedPhotoByFilename(ctx, tx, filename)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return http.StatusNotFound, fmt.Errorf("foto %s tidak ditemukan di tempat sampah", filename)
+		}
+		return http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan foto: %v", err)
+	}
+
+	// Foto milik berita di tempat sampah harus tetap tersembunyi sampai beritanya dipulihkan
+	if before.IdBerita != "" {
+		_, err := t.beritaRepo.GetBeritaById(ctx, tx, before.IdBerita)
+		if err == sql.ErrNoRows {
+			return http.StatusBadRequest, fmt.Errorf("berita foto %s masih di tempat sampah, pulihkan beritanya terlebih dahulu", filename)
+		}
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan berita: %v", err)
+		}
+	}
+
+	var photos []models.Galeri
+	if before.IdBerita != "" {
+		photos, err = t.beritaRepo.GetPhotosByBeritaId(ctx, tx, before.IdBerita)
+	} else {
+		photos, err = t.albumRepo.GetPhotosByAlbumId(ctx, tx, before.IdAlbum)
+	}
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan foto: %v", err)
+	}
+
+	photo := before
+	photo.Urutan, photo.IsCover = nextPhotoPosition(photos)
+	photo.DeletedAt = nil
+
+	err = t.galeriRepo.RestorePhoto(ctx, tx, photo)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal memulihkan foto: %v", err)
+	}
+
+	err = recordAudit(ctx, tx, t.auditRepo, models.AuditActionRestore, models.AuditEntityGaleri, photo.IdGaleri, before, photo)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	// Kembalikan file dari tempat sampah setelah commit berhasil
+	restoreGaleriFiles(ctx, t.storage, []models.Galeri{photo})
+
+	return http.StatusOK, nil
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/syrlramadhan/desa-sukamaju-api/dto"
+	"github.com/syrlramadhan/desa-sukamaju-api/models"
+	"github.com/syrlramadhan/desa-sukamaju-api/repositories"
+	"github.com/syrlramadhan/desa-sukamaju-api/storage"
+)
+
+type UploadCheckService interface {
+	CheckUploads(ctx context.Context, quarantine bool, minAge time.Duration) (dto.UploadCheckResponse, int, error)
+}
+
+type uploadCheckServiceImpl struct {
+	AparatRepository repositories.AparatRepository
+	BeritaRepository repositories.BeritaRepository
+	Storage          storage.Storage
+	DB               *sql.DB
+}
+
+func NewUploadCheckService(aparatRepository repositories.AparatRepository, beritaRepository repositories.BeritaRepository, fileStorage storage.Storage, db *sql.DB) UploadCheckService {
+	return &uploadCheckServiceImpl{
+		AparatRepository: aparatRepository,
+		BeritaRepository: beritaRepository,
+		Storage:          fileStorage,
+		DB:               db,
+	}
+}
+
+// uploadReference adalah file di storage yang direferensikan oleh sebuah baris database
+type uploadReference struct {
+	entity   string
+	idEntity string
+}
+
+// CheckUploads implements UploadCheckService.
+// File yang tidak direferensikan database dan lebih tua dari minAge dilaporkan sebagai yatim;
+// batas umur mencegah file dari upload yang transaksinya belum selesai ikut terhitung.
+// Jika quarantine bernilai true, file yatim dipindahkan ke folder quarantine alih-alih dihapus
+// sehingga masih dapat dikembalikan secara manual.
+func (u *uploadCheckServiceImpl) CheckUploads(ctx context.Context, quarantine bool, minAge time.Duration) (dto.UploadCheckResponse, int, error) {
+	tx, err := u.DB.Begin()
+	if err != nil {
+		return dto.UploadCheckResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal memulai transaksi: %v", err)
+	}
+	defer tx.Rollback()
+
+	aparatList, err := u.AparatRepository.GetAllAparat(ctx, tx)
+	if err != nil {
+		return dto.UploadCheckResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan data aparat: %v", err)
+	}
+
+	// Foto aparat di tempat sampah masih dipakai sampai dihapus permanen oleh TrashPurger
+	trashedAparat, _, err := u.AparatRepository.GetDeletedAparat(ctx, tx, models.TrashFilter{})
+	if err != nil {
+		return dto.UploadCheckResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan data aparat: %v", err)
+	}
+	aparatList = append(aparatList, trashedAparat...)
+
+	photos, err := u.BeritaRepository.GetAllPhotos(ctx, tx)
+	if err != nil {
+		return dto.UploadCheckResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mendapatkan data foto berita: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dto.UploadCheckResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal mengkomit transaksi: %v", err)
+	}
+
+	referenced := make(map[string]uploadReference)
+	for _, aparat := range aparatList {
+		if aparat.Foto == "" {
+			continue
+		}
+		for _, filename := range append([]string{aparat.Foto}, aparat.VarianFoto.Filenames()...) {
+			referenced[storage.Key(storage.DirAparat, filename)] = uploadReference{models.AuditEntityAparat, aparat.IdAparat}
+		}
+	}
+	for _, photo := range photos {
+		for _, filename := range append([]string{photo.Gambar}, photo.Varian.Filenames()...) {
+			referenced[storage.Key(storage.DirBerita, filename)] = uploadReference{models.AuditEntityGaleri, photo.IdGaleri}
+		}
+	}
+
+	response := dto.UploadCheckResponse{
+		Missing:     []dto.MissingUploadResponse{},
+		Orphaned:    []string{},
+		Quarantined: []string{},
+	}
+
+	onStorage := make(map[string]bool)
+	cutoff := time.Now().Add(-minAge)
+
+	// File milik data di tempat sampah disimpan di bawah folder tempat sampah dengan path aslinya
+	for _, dir := range []string{storage.DirAparat, storage.DirBerita, trashDir(storage.DirAparat), trashDir(storage.DirBerita)} {
+		objects, err := u.Storage.List(ctx, dir+"/")
+		if err != nil {
+			return dto.UploadCheckResponse{}, http.StatusInternalServerError, fmt.Errorf("gagal membaca folder %s: %v", dir, err)
+		}
+
+		for _, object := range objects {
+			onStorage[object.Key] = true
+			if _, ok := referenced[strings.TrimPrefix(object.Key, storage.DirTrash+"/")]; ok || object.ModTime.After(cutoff) {
+				continue
+			}
+			response.Orphaned = append(response.Orphaned, object.Key)
+		}
+	}
+
+	for key, reference := range referenced {
+		if !onStorage[key] && !onStorage[storage.Key(storage.DirTrash, key)] {
+			response.Missing = append(response.Missing, dto.MissingUploadResponse{
+				Entity:   reference.entity,
+				IdEntity: reference.idEntity,
+				Key:      key,
+			})
+		}
+	}
+
+	sort.Strings(response.Orphaned)
+	sort.Slice(response.Missing, func(i, j int) bool {
+		return response.Missing[i].Key < response.Missing[j].Key
+	})
+
+	if !quarantine {
+		return response, http.StatusOK, nil
+	}
+
+	for _, key := range response.Orphaned {
+		if err := u.quarantineFile(ctx, key); err != nil {
+			return response, http.StatusInternalServerError, err
+		}
+		response.Quarantined = append(response.Quarantined, key)
+	}
+
+	return response, http.StatusOK, nil
+}
+
+// quarantineFile memindahkan file ke folder quarantine dengan mempertahankan path aslinya
+func (u *uploadCheckServiceImpl) quarantineFile(ctx context.Context, key string) error {
+	if err := storage.Move(ctx, u.Storage, key, storage.Key(storage.DirQuarantine, key)); err != nil {
+		return fmt.Errorf("gagal memindahkan file %s ke karantina: %v", key, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type localStorage struct {
+	root    string
+	baseURL string
+}
+
+// NewLocalStorage menyimpan file di folder root pada disk, diakses lewat baseURL, misalnya /uploads
+func NewLocalStorage(root, baseURL string) Storage {
+	return &localStorage{
+		root:    root,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// Put implements Storage.
+func (l *localStorage) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	filePath := filepath.Join(l.root, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+
+	// Tulis ke file sementara lalu rename agar file tidak pernah terbaca setengah jadi
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filePath)
+}
+
+// Get implements Storage.
+func (l *localStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return nil, ErrNotExist
+	}
+
+	file, err := os.Open(filepath.Join(l.root, filepath.FromSlash(key)))
+	if os.IsNotExist(err) {
+		return nil, ErrNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	if info.IsDir() {
+		file.Close()
+		return nil, ErrNotExist
+	}
+
+	return file, nil
+}
+
+// Delete implements Storage.
+func (l *localStorage) Delete(ctx context.Context, key string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filepath.Join(l.root, filepath.FromSlash(key)))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
+
+// List implements Storage.
+func (l *localStorage) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+
+	err := filepath.WalkDir(l.root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".upload-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(l.root, filePath)
+		if err != nil {
+			return err
+		}
+
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+
+		objects = append(objects, Object{Key: key, ModTime: info.ModTime()})
+		return nil
+	})
+
+	return objects, err
+}
+
+// URL implements Storage.
+func (l *localStorage) URL(key string) string {
+	return l.baseURL + "/" + key
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"time"
+)
+
+// Folder penyimpanan untuk setiap jenis upload
+const (
+	DirBerita = "berita"
+	DirAparat = "aparat"
+	// DirQuarantine menampung file yatim yang dipindahkan oleh check-uploads, tidak dilayani ke publik
+	DirQuarantine = "quarantine"
+	// DirTrash menampung file milik data di tempat sampah dengan path asli di bawahnya,
+	// tidak dilayani ke publik sampai datanya dipulihkan
+	DirTrash = "trash"
+)
+
+// ErrNotExist dikembalikan oleh Get jika file dengan key tersebut tidak ditemukan
+var ErrNotExist = errors.New("file tidak ditemukan")
+
+// Object adalah informasi file yang dikembalikan oleh List
+type Object struct {
+	Key     string
+	ModTime time.Time
+}
+
+// Storage menyimpan file upload berdasarkan key berbentuk path relatif, misalnya
+// "berita/berita_xxx.jpg". Implementasinya dipilih lewat konfigurasi env sehingga
+// beberapa instance api dapat berbagi penyimpanan yang sama.
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	List(ctx context.Context, prefix string) ([]Object, error)
+	URL(key string) string
+}
+
+// Key menggabungkan folder dan nama file menjadi key storage
+func Key(dir, filename string) string {
+	return dir + "/" + filename
+}
+
+// Move memindahkan file dari key from ke key to di storage yang sama
+func Move(ctx context.Context, s Storage, from, to string) error {
+	file, err := s.Get(ctx, from)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := s.Put(ctx, to, file, mime.TypeByExtension(path.Ext(from))); err != nil {
+		return err
+	}
+
+	return s.Delete(ctx, from)
+}
+
+// cleanKey menolak key kosong, absolut, atau yang keluar dari root storage
+func cleanKey(key string) (string, error) {
+	cleaned := path.Clean("/" + key)[1:]
+	if cleaned == "" || cleaned != key {
+		return "", fmt.Errorf("key storage %q tidak valid", key)
+	}
+
+	return cleaned, nil
+}